@@ -10,15 +10,20 @@ import (
 
 // App config struct
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Logger   LoggerConfig
-	Jaeger   JaegerConfig
-	Session  SessionConfig
-	Metric   MetricConfig
-	RabbitMQ RabbitMQConfig
-	Resend   ResendConfig
+	Server       ServerConfig
+	Postgres     PostgresConfig
+	Redis        RedisConfig
+	Logger       LoggerConfig
+	Jaeger       JaegerConfig
+	Session      SessionConfig
+	Metric       MetricConfig
+	RabbitMQ     RabbitMQConfig
+	Resend       ResendConfig
+	RateLimit    RateLimitConfig
+	Notification NotificationConfig
+	Tracking     TrackingConfig
+	Bridge       BridgeConfig
+	Idempotency  IdempotencyConfig
 }
 
 // Server config struct
@@ -105,11 +110,82 @@ type RabbitMQConfig struct {
 	RoutingKey     string
 	ConsumerTag    string
 	WorkerPoolSize int
+	ConfirmTimeout time.Duration
+	Lanes          []EmailLaneConfig
+}
+
+// Email lane config, one queue and worker pool per email category
+type EmailLaneConfig struct {
+	Category       string
+	Queue          string
+	RoutingKey     string
+	ConsumerTag    string
+	WorkerPoolSize int
+	PrefetchCount  int
 }
 
 // Mailer config
 type ResendConfig struct {
-	ApiKey string
+	ApiKey      string
+	SendEnabled bool // emails and email notifications are only handed to the provider when enabled, the rate limits apply either way
+}
+
+// Email send rate limit config
+type RateLimitConfig struct {
+	Enabled         bool
+	Backend         string
+	GlobalPerSecond float64
+	GlobalBurst     int
+	GlobalPerDay    int
+	DomainPerSecond float64
+	DomainBurst     int
+	MaxDelay        time.Duration
+}
+
+// Notification providers config, sms and push use local stand-ins: log or file
+type NotificationConfig struct {
+	SMSProvider  string
+	PushProvider string
+	OutboxDir    string
+}
+
+// Email open and click tracking config
+type TrackingConfig struct {
+	Enabled   bool
+	BaseURL   string
+	Port      string
+	SecretKey string
+}
+
+// Idempotency keys config
+type IdempotencyConfig struct {
+	TTL time.Duration // seconds a key and its email id are kept
+}
+
+// Kafka to email bridge config
+type BridgeConfig struct {
+	Enabled bool
+	Brokers []string
+	GroupID string
+	Workers int
+	Rules   []EventRuleConfig
+}
+
+// Rule mapping a domain event to an email, Subject and Body are templates over the event fields
+type EventRuleConfig struct {
+	Name           string
+	Topic          string
+	Field          string // event field the condition applies to, empty matches every event
+	Operator       string // lt, lte, gt, gte, eq, ne
+	Value          string
+	Recipients     []string // fixed recipients, e.g. admins
+	RecipientField string   // event field holding the recipient address
+	Subject        string
+	Body           string
+	Category       string
+	Template       string
+	DedupField     string // event field identifying repeated alerts, empty disables dedup
+	DedupWindow    time.Duration
 }
 
 // Load config file from given path
@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/bridge"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	minBytes               = 10e3            // fetch at least 10KB of messages
+	maxBytes               = 10e6            // fetch at most 10MB of messages
+	queueCapacity          = 100             // internal message buffer size in kafka.Reader
+	heartbeatInterval      = 3 * time.Second // heartbeat interval to the group coordinator
+	commitInterval         = 0               // commit offsets synchronously on every message
+	partitionWatchInterval = 5 * time.Second // how often to watch for partition changes
+	maxAttempts            = 5               // maximum number of attempts for transient errors
+	dialTimeout            = 3 * time.Minute // timeout for connecting to the broker
+
+	defaultWorkers = 4 // workers per topic when the config sets none
+
+	headerContentType = "content-type" // format of the message value, set by the product service
+)
+
+var (
+	incomingMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_bridge_incoming_kafka_messages_total",
+		Help: "The total number of incoming Kafka messages",
+	}, []string{"topic"})
+	successMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_bridge_success_kafka_messages_total",
+		Help: "The total number of successfully bridged Kafka messages",
+	}, []string{"topic"})
+	errorMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_bridge_error_kafka_messages_total",
+		Help: "The total number of failed Kafka messages",
+	}, []string{"topic"})
+)
+
+// Domain events consumer group feeding the email bridge
+type EventsConsumerGroup struct {
+	Brokers  []string
+	GroupID  string
+	logger   logger.Logger
+	cfg      *config.Config
+	bridgeUC bridge.BridgeUseCase
+}
+
+// NewEventsConsumerGroup constructor
+func NewEventsConsumerGroup(cfg *config.Config, bridgeUC bridge.BridgeUseCase, logger logger.Logger) *EventsConsumerGroup {
+	return &EventsConsumerGroup{
+		Brokers:  cfg.Bridge.Brokers,
+		GroupID:  cfg.Bridge.GroupID,
+		logger:   logger,
+		cfg:      cfg,
+		bridgeUC: bridgeUC,
+	}
+}
+
+// Get new kafka.Reader
+func (c *EventsConsumerGroup) getNewReader(topic string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:                c.Brokers,
+		GroupID:                c.GroupID,
+		Topic:                  topic,
+		MinBytes:               minBytes,
+		MaxBytes:               maxBytes,
+		QueueCapacity:          queueCapacity,
+		HeartbeatInterval:      heartbeatInterval,
+		CommitInterval:         commitInterval,
+		PartitionWatchInterval: partitionWatchInterval,
+		MaxAttempts:            maxAttempts,
+		Logger:                 kafka.LoggerFunc(c.logger.Debugf),
+		ErrorLogger:            kafka.LoggerFunc(c.logger.Errorf),
+		Dialer: &kafka.Dialer{
+			Timeout: dialTimeout,
+		},
+	})
+}
+
+func (c *EventsConsumerGroup) consumeTopic(ctx context.Context, topic string, workerNum int) {
+	r := c.getNewReader(topic)
+	defer func() {
+		if err := r.Close(); err != nil {
+			c.logger.Errorf("r.Close: %v", err)
+		}
+	}()
+
+	c.logger.Infof("Starting email bridge consumer for topic: %s", topic)
+
+	wg := &sync.WaitGroup{}
+	for i := range workerNum {
+		wg.Add(1)
+		go c.worker(ctx, r, wg, i)
+	}
+	wg.Wait()
+}
+
+func (c *EventsConsumerGroup) worker(ctx context.Context, r *kafka.Reader, wg *sync.WaitGroup, workerID int) {
+	defer wg.Done()
+
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			c.logger.Errorf("r.FetchMessage: %v", err)
+			return
+		}
+
+		span, msgCtx := opentracing.StartSpanFromContext(ctx, "EventsConsumerGroup.worker")
+		c.logger.Infof("WORKER: %v, message at topic/partition/offset: %v/%v/%v", workerID, m.Topic, m.Partition, m.Offset)
+		incomingMessages.WithLabelValues(m.Topic).Inc()
+
+		// Failed events are logged and committed, an alert should never block the partition
+		if err := c.bridgeUC.HandleEvent(msgCtx, m.Topic, header(m, headerContentType), m.Value); err != nil {
+			errorMessages.WithLabelValues(m.Topic).Inc()
+			c.logger.Errorf("bridgeUC.HandleEvent: %v", err)
+		} else {
+			successMessages.WithLabelValues(m.Topic).Inc()
+		}
+
+		if err := r.CommitMessages(ctx, m); err != nil {
+			c.logger.Errorf("r.CommitMessages: %v", err)
+		}
+		span.Finish()
+	}
+}
+
+// Run one reader per topic used by the bridge rules
+func (c *EventsConsumerGroup) RunConsumers(ctx context.Context) {
+	workers := c.cfg.Bridge.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	for _, topic := range bridge.GetTopics(c.cfg) {
+		go c.consumeTopic(ctx, topic, workers)
+	}
+}
+
+// header value of the message header, empty when the message does not have it
+func header(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
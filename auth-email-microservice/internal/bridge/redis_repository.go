@@ -0,0 +1,12 @@
+package bridge
+
+import (
+	"context"
+	"time"
+)
+
+// Bridge dedup redis repository interface
+type DedupRepository interface {
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/bridge"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	basePrefix = "email_bridge:"
+)
+
+// Bridge dedup repository
+type dedupRepo struct {
+	redisClient *redis.Client
+	basePrefix  string
+}
+
+func NewDedupRepository(redisClient *redis.Client) bridge.DedupRepository {
+	return &dedupRepo{
+		redisClient: redisClient,
+		basePrefix:  basePrefix,
+	}
+}
+
+// Acquire key for the window, false when the same alert was already sent in it
+func (d *dedupRepo) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "dedupRepo.Acquire")
+	defer span.Finish()
+
+	ok, err := d.redisClient.SetNX(ctx, d.createKey(key), 1, ttl).Result()
+	if err != nil {
+		return false, errors.WithMessage(err, "dedupRepo.Acquire.redisClient.SetNX")
+	}
+
+	return ok, nil
+}
+
+// Release key so a failed alert can be sent again
+func (d *dedupRepo) Release(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "dedupRepo.Release")
+	defer span.Finish()
+
+	if err := d.redisClient.Del(ctx, d.createKey(key)).Err(); err != nil {
+		return errors.WithMessage(err, "dedupRepo.Release.redisClient.Del")
+	}
+
+	return nil
+}
+
+func (d *dedupRepo) createKey(key string) string {
+	return fmt.Sprintf("%s%s", d.basePrefix, key)
+}
@@ -0,0 +1,19 @@
+package bridge
+
+import (
+	"github.com/Chuuch/ecom-microservices/config"
+)
+
+// Get distinct topics the bridge rules listen on
+func GetTopics(cfg *config.Config) []string {
+	seen := make(map[string]struct{}, len(cfg.Bridge.Rules))
+	topics := make([]string, 0, len(cfg.Bridge.Rules))
+	for _, rule := range cfg.Bridge.Rules {
+		if _, ok := seen[rule.Topic]; ok {
+			continue
+		}
+		seen[rule.Topic] = struct{}{}
+		topics = append(topics, rule.Topic)
+	}
+	return topics
+}
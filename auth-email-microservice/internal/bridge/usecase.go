@@ -0,0 +1,10 @@
+package bridge
+
+import (
+	"context"
+)
+
+// Kafka to email bridge usecase interface
+type BridgeUseCase interface {
+	HandleEvent(ctx context.Context, topic string, contentType string, value []byte) error
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/bridge"
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	bridgedEmails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_bridge_published_emails_total",
+		Help: "The total number of emails published by bridge rules",
+	}, []string{"rule"})
+	dedupedEmails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_bridge_deduplicated_emails_total",
+		Help: "The total number of bridge emails skipped as duplicates",
+	}, []string{"rule"})
+)
+
+// Bridge UseCase
+type BridgeUseCase struct {
+	emailUC   email.EmailUseCase
+	dedupRepo bridge.DedupRepository
+	rules     []*rule
+	logger    logger.Logger
+	cfg       *config.Config
+}
+
+// NewBridgeUseCase compiles the configured rules and returns a new BridgeUseCase
+func NewBridgeUseCase(emailUC email.EmailUseCase, dedupRepo bridge.DedupRepository, logger logger.Logger, cfg *config.Config) (*BridgeUseCase, error) {
+	rules := make([]*rule, 0, len(cfg.Bridge.Rules))
+	for _, ruleCfg := range cfg.Bridge.Rules {
+		r, err := newRule(ruleCfg)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+
+	return &BridgeUseCase{
+		emailUC:   emailUC,
+		dedupRepo: dedupRepo,
+		rules:     rules,
+		logger:    logger,
+		cfg:       cfg,
+	}, nil
+}
+
+// Run the rules of the topic against the event and publish an email for each match,
+// the content type picks the decoding of JSON events or of protobuf product events
+func (b *BridgeUseCase) HandleEvent(ctx context.Context, topic string, contentType string, value []byte) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "BridgeUseCase.HandleEvent")
+	defer span.Finish()
+
+	event, err := parseEvent(contentType, value)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, r := range b.rules {
+		if r.Topic != topic || !r.matches(event) {
+			continue
+		}
+
+		if err := b.applyRule(ctx, r, event); err != nil {
+			b.logger.Errorf("BridgeUseCase.applyRule, rule: %s: %v", r.Name, err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "rule: %s", r.Name)
+			}
+		}
+	}
+
+	return firstErr
+}
+
+func (b *BridgeUseCase) applyRule(ctx context.Context, r *rule, event map[string]interface{}) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "BridgeUseCase.applyRule")
+	defer span.Finish()
+
+	span.LogFields(log.String("rule", r.Name))
+
+	recipients := r.recipients(event)
+	if len(recipients) == 0 {
+		b.logger.Infof("Bridge rule %s matched without recipients, skipping", r.Name)
+		return nil
+	}
+
+	subject, body, err := r.render(event)
+	if err != nil {
+		return err
+	}
+
+	dedupKey := r.dedupKey(event)
+	if dedupKey != "" {
+		acquired, err := b.dedupRepo.Acquire(ctx, dedupKey, r.DedupWindow*time.Second)
+		if err != nil {
+			return errors.Wrap(err, "dedupRepo.Acquire")
+		}
+		if !acquired {
+			dedupedEmails.WithLabelValues(r.Name).Inc()
+			return nil
+		}
+	}
+
+	mail := &models.Email{
+		From:     "daniel@skyeystudio.com",
+		To:       recipients,
+		Subject:  subject,
+		Body:     body,
+		Category: r.Category,
+		Template: r.Template,
+	}
+
+	if err := mail.PrepareAndValidate(ctx); err != nil {
+		return errors.Wrap(err, "PrepareAndValidate")
+	}
+
+	if err := b.emailUC.PublishEmail(ctx, mail); err != nil {
+		if dedupKey != "" {
+			if err := b.dedupRepo.Release(ctx, dedupKey); err != nil {
+				b.logger.Errorf("dedupRepo.Release: %v", err)
+			}
+		}
+		return errors.Wrap(err, "emailUC.PublishEmail")
+	}
+
+	bridgedEmails.WithLabelValues(r.Name).Inc()
+	b.logger.Infof("Bridge rule %s published email: %v", r.Name, mail.EmailID)
+
+	return nil
+}
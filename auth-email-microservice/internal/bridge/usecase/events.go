@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"encoding/json"
+	"time"
+
+	userService "github.com/Chuuch/ecom-microservices/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	contentTypeProtobuf = "application/x-protobuf" // a ProductEvent envelope of the product service
+	contentTypeJSON     = "application/json"       // a bare JSON event, the legacy format
+
+	productEventVersion = 1 // newest ProductEvent schema version the bridge reads
+)
+
+// ErrUnsupportedEvent the message is of a content type or schema version the bridge cannot read
+var ErrUnsupportedEvent = errors.New("unsupported event")
+
+// parseEvent fields of the event by the content type of the message, messages without one are JSON
+func parseEvent(contentType string, value []byte) (map[string]interface{}, error) {
+	switch contentType {
+	case "", contentTypeJSON:
+		event := make(map[string]interface{})
+		if err := json.Unmarshal(value, &event); err != nil {
+			return nil, errors.Wrap(err, "json.Unmarshal")
+		}
+		return event, nil
+	case contentTypeProtobuf:
+		return decodeProductEvent(value)
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedEvent, "content type %s", contentType)
+	}
+}
+
+// eventProduct the product of a ProductEvent with the json names of the legacy JSON product,
+// rules read the fields of either format the same way
+type eventProduct struct {
+	ProductID     string        `json:"product_id"`
+	CategoryID    string        `json:"category_id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Price         eventMoney    `json:"price"`
+	Prices        []eventMoney  `json:"prices,omitempty"`
+	ImageURL      *string       `json:"image_url"`
+	Photos        []string      `json:"photos"`
+	Quantity      int64         `json:"quantity"`
+	Options       []eventOption `json:"options,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	Version       int64         `json:"version"`
+	RatingAverage float64       `json:"rating_average"`
+	RatingCount   int64         `json:"rating_count"`
+}
+
+type eventMoney struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type eventOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// decodeProductEvent fields of the product of a ProductEvent envelope
+func decodeProductEvent(value []byte) (map[string]interface{}, error) {
+	var envelope userService.ProductEvent
+	if err := proto.Unmarshal(value, &envelope); err != nil {
+		return nil, errors.Wrap(err, "proto.Unmarshal")
+	}
+	if envelope.GetVersion() > productEventVersion {
+		return nil, errors.Wrapf(ErrUnsupportedEvent, "version %d of event %s", envelope.GetVersion(), envelope.GetEventId())
+	}
+	p := envelope.GetProduct()
+	if p == nil {
+		return nil, errors.Wrapf(ErrUnsupportedEvent, "event %s has no product payload", envelope.GetEventId())
+	}
+
+	product := eventProduct{
+		ProductID:     p.GetProductId(),
+		CategoryID:    p.GetCategoryId(),
+		Name:          p.GetName(),
+		Description:   p.GetDescription(),
+		Price:         eventMoney{Amount: p.GetPrice().GetAmount(), Currency: p.GetPrice().GetCurrencyCode()},
+		Photos:        p.GetPhotos(),
+		Quantity:      p.GetQuantity(),
+		CreatedAt:     p.GetCreatedAt().AsTime(),
+		UpdatedAt:     p.GetUpdatedAt().AsTime(),
+		Version:       p.GetVersion(),
+		RatingAverage: p.GetRatingAverage(),
+		RatingCount:   p.GetRatingCount(),
+	}
+	if p.GetImageUrl() != "" {
+		imageURL := p.GetImageUrl()
+		product.ImageURL = &imageURL
+	}
+	for _, price := range p.GetPrices() {
+		product.Prices = append(product.Prices, eventMoney{Amount: price.GetAmount(), Currency: price.GetCurrencyCode()})
+	}
+	for _, option := range p.GetOptions() {
+		product.Options = append(product.Options, eventOption{Name: option.GetName(), Values: option.GetValues()})
+	}
+
+	// A JSON round trip gives the value types of a decoded JSON event, numbers are float64
+	encoded, err := json.Marshal(product)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+	event := make(map[string]interface{})
+	if err := json.Unmarshal(encoded, &event); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	return event, nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	userService "github.com/Chuuch/ecom-microservices/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseEvent_ProductEvent(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	value, err := proto.Marshal(&userService.ProductEvent{
+		EventId: "e1",
+		Type:    "update_product",
+		Version: productEventVersion,
+		Payload: &userService.ProductEvent_Product{Product: &userService.EventProduct{
+			ProductId: "p1",
+			Name:      "shirt",
+			Quantity:  4,
+			Price:     &userService.EventMoney{Amount: 1999, CurrencyCode: "USD"},
+			UpdatedAt: timestamppb.New(updatedAt),
+			Version:   3,
+		}},
+	})
+	require.NoError(t, err)
+
+	event, err := parseEvent(contentTypeProtobuf, value)
+	require.NoError(t, err)
+
+	// The same fields and value types as the legacy JSON product
+	legacy, err := parseEvent(contentTypeJSON, []byte(`{
+		"product_id": "p1", "category_id": "", "name": "shirt", "description": "",
+		"price": {"amount": 1999, "currency": "USD"}, "image_url": null, "photos": null, "quantity": 4,
+		"created_at": "1970-01-01T00:00:00Z", "updated_at": "2024-05-01T10:00:00Z",
+		"version": 3, "rating_average": 0, "rating_count": 0
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, legacy, event)
+
+	lowStock, err := newRule(config.EventRuleConfig{
+		Name:       "low_stock",
+		Topic:      "update_product",
+		Field:      "quantity",
+		Operator:   operatorLt,
+		Value:      "5",
+		Recipients: []string{"admin@shop.com"},
+	})
+	require.NoError(t, err)
+	require.True(t, lowStock.matches(event))
+}
+
+func TestParseEvent_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseEvent("application/avro", []byte(`{}`))
+	require.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	value, err := proto.Marshal(&userService.ProductEvent{EventId: "e1", Version: productEventVersion + 1})
+	require.NoError(t, err)
+	_, err = parseEvent(contentTypeProtobuf, value)
+	require.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	value, err = proto.Marshal(&userService.ProductEvent{EventId: "e1", Version: productEventVersion})
+	require.NoError(t, err)
+	_, err = parseEvent(contentTypeProtobuf, value)
+	require.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	// Events without a content type are JSON
+	event, err := parseEvent("", []byte(`{"quantity": 4}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"quantity": float64(4)}, event)
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	texttemplate "text/template"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	operatorLt  = "lt"
+	operatorLte = "lte"
+	operatorGt  = "gt"
+	operatorGte = "gte"
+	operatorEq  = "eq"
+	operatorNe  = "ne"
+)
+
+// Compiled event rule
+type rule struct {
+	config.EventRuleConfig
+	threshold float64
+	subject   *texttemplate.Template
+	body      *htmltemplate.Template
+}
+
+// Compile rule templates and numeric threshold once at startup
+func newRule(cfg config.EventRuleConfig) (*rule, error) {
+	r := &rule{EventRuleConfig: cfg}
+
+	switch cfg.Operator {
+	case operatorLt, operatorLte, operatorGt, operatorGte:
+		threshold, err := strconv.ParseFloat(cfg.Value, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %s: strconv.ParseFloat", cfg.Name)
+		}
+		r.threshold = threshold
+	case operatorEq, operatorNe, "":
+	default:
+		return nil, errors.Errorf("rule %s: unknown operator: %s", cfg.Name, cfg.Operator)
+	}
+
+	if len(cfg.Recipients) == 0 && cfg.RecipientField == "" {
+		return nil, errors.Errorf("rule %s: no recipients", cfg.Name)
+	}
+
+	subject, err := texttemplate.New(cfg.Name).Parse(cfg.Subject)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rule %s: subject", cfg.Name)
+	}
+	r.subject = subject
+
+	body, err := htmltemplate.New(cfg.Name).Parse(cfg.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rule %s: body", cfg.Name)
+	}
+	r.body = body
+
+	return r, nil
+}
+
+// Check the rule condition against the event fields
+func (r *rule) matches(event map[string]interface{}) bool {
+	if r.Field == "" {
+		return true
+	}
+
+	value, ok := event[r.Field]
+	if !ok || value == nil {
+		return false
+	}
+
+	switch r.Operator {
+	case operatorEq:
+		return fmt.Sprint(value) == r.Value
+	case operatorNe:
+		return fmt.Sprint(value) != r.Value
+	}
+
+	number, ok := value.(float64)
+	if !ok {
+		return false
+	}
+
+	switch r.Operator {
+	case operatorLt:
+		return number < r.threshold
+	case operatorLte:
+		return number <= r.threshold
+	case operatorGt:
+		return number > r.threshold
+	case operatorGte:
+		return number >= r.threshold
+	}
+	return true
+}
+
+// Fixed recipients plus the address carried by the event
+func (r *rule) recipients(event map[string]interface{}) []string {
+	recipients := append([]string{}, r.Recipients...)
+	if r.RecipientField != "" {
+		if recipient, ok := event[r.RecipientField].(string); ok && recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients
+}
+
+// Dedup key of the event, empty when dedup is disabled
+func (r *rule) dedupKey(event map[string]interface{}) string {
+	if r.DedupField == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%v", r.Name, event[r.DedupField])
+}
+
+func (r *rule) render(event map[string]interface{}) (string, string, error) {
+	var subject, body bytes.Buffer
+	if err := r.subject.Execute(&subject, event); err != nil {
+		return "", "", errors.Wrap(err, "subject.Execute")
+	}
+	if err := r.body.Execute(&body, event); err != nil {
+		return "", "", errors.Wrap(err, "body.Execute")
+	}
+	return subject.String(), body.String(), nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeEvent(t *testing.T, value string) map[string]interface{} {
+	event := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(value), &event))
+	return event
+}
+
+func TestRule_Matches(t *testing.T) {
+	t.Parallel()
+
+	lowStock, err := newRule(config.EventRuleConfig{
+		Name:       "low_stock",
+		Topic:      "update_product",
+		Field:      "quantity",
+		Operator:   operatorLt,
+		Value:      "5",
+		Recipients: []string{"admin@shop.com"},
+	})
+	require.NoError(t, err)
+
+	require.True(t, lowStock.matches(decodeEvent(t, `{"quantity": 4}`)))
+	require.False(t, lowStock.matches(decodeEvent(t, `{"quantity": 5}`)))
+	require.False(t, lowStock.matches(decodeEvent(t, `{"quantity": "4"}`)))
+	require.False(t, lowStock.matches(decodeEvent(t, `{"name": "shirt"}`)))
+
+	backInStock, err := newRule(config.EventRuleConfig{
+		Name:           "back_in_stock",
+		Field:          "kind",
+		Operator:       operatorEq,
+		Value:          "back_in_stock",
+		RecipientField: "email",
+	})
+	require.NoError(t, err)
+
+	event := decodeEvent(t, `{"kind": "back_in_stock", "email": "user@shop.com"}`)
+	require.True(t, backInStock.matches(event))
+	require.Equal(t, []string{"user@shop.com"}, backInStock.recipients(event))
+	require.False(t, backInStock.matches(decodeEvent(t, `{"kind": "price_drop"}`)))
+}
+
+func TestRule_Render(t *testing.T) {
+	t.Parallel()
+
+	r, err := newRule(config.EventRuleConfig{
+		Name:       "low_stock",
+		Recipients: []string{"admin@shop.com"},
+		Subject:    "Low stock: {{.name}}",
+		Body:       "<p>{{.name}} has {{.quantity}} left</p>",
+		DedupField: "product_id",
+	})
+	require.NoError(t, err)
+
+	event := decodeEvent(t, `{"product_id": "p1", "name": "<b>shirt</b>", "quantity": 3}`)
+	subject, body, err := r.render(event)
+	require.NoError(t, err)
+	require.Equal(t, "Low stock: <b>shirt</b>", subject)
+	require.Equal(t, "<p>&lt;b&gt;shirt&lt;/b&gt; has 3 left</p>", body)
+	require.Equal(t, "low_stock:p1", r.dedupKey(event))
+
+	_, err = newRule(config.EventRuleConfig{Name: "invalid", Operator: operatorLt, Value: "many", Recipients: []string{"a@b.com"}})
+	require.Error(t, err)
+}
@@ -12,10 +12,16 @@ import (
 	userService "github.com/Chuuch/ecom-microservices/proto"
 	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	emailStatusAccepted = "ACCEPTED" // persisted by the broker, waiting to be sent
+)
+
 // Email gRPC microservice
 type EmailMicroservice struct {
 	userService.UnimplementedEmailServiceServer
@@ -39,10 +45,16 @@ func (e *EmailMicroservice) SendEmail(ctx context.Context, req *userService.Send
 	defer span.Finish()
 
 	mail := &models.Email{
-		From:    "daniel@skyeystudio.com",
-		To:      req.GetTo(),
-		Subject: req.GetSubject(),
-		Body:    req.GetBody(),
+		From:     "daniel@skyeystudio.com",
+		To:       req.GetTo(),
+		Subject:  req.GetSubject(),
+		Body:     req.GetBody(),
+		Category: req.GetCategory(),
+		Template: req.GetTemplate(),
+		Track:    req.GetTrack(),
+	}
+	if keys := metadata.ValueFromIncomingContext(ctx, email.MetadataIdempotencyKey); len(keys) > 0 {
+		mail.IdempotencyKey = keys[0]
 	}
 
 	if err := mail.PrepareAndValidate(ctx); err != nil {
@@ -56,7 +68,8 @@ func (e *EmailMicroservice) SendEmail(ctx context.Context, req *userService.Send
 	}
 
 	return &userService.SendEmailResponse{
-		Status: "OK",
+		Status:  emailStatusAccepted,
+		EmailId: mail.EmailID.String(),
 	}, nil
 }
 
@@ -104,6 +117,57 @@ func (e *EmailMicroservice) FindEmailsByReceiver(ctx context.Context, req *userS
 	}, nil
 }
 
+// Get open and click stats of an email or of a template
+func (e *EmailMicroservice) GetEmailStats(ctx context.Context, req *userService.GetEmailStatsRequest) (*userService.GetEmailStatsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailMicroservice.GetEmailStats")
+	defer span.Finish()
+
+	if req.GetEmailId() == "" {
+		if req.GetTemplate() == "" {
+			return nil, status.Error(codes.InvalidArgument, "email_id or template is required")
+		}
+
+		stats, err := e.emailUC.GetTemplateStats(ctx, req.GetTemplate())
+		if err != nil {
+			e.logger.Errorf("emailUC.GetTemplateStats: %v", err)
+			return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "emailUC.GetTemplateStats: %v", err)
+		}
+
+		return &userService.GetEmailStatsResponse{Stats: e.convertEmailStatsToProto(stats)}, nil
+	}
+
+	emailUUID, err := uuid.Parse(req.GetEmailId())
+	if err != nil {
+		e.logger.Errorf("parseUUID: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "parseUUID: %v", err)
+	}
+
+	stats, err := e.emailUC.GetEmailStats(ctx, emailUUID)
+	if err != nil {
+		e.logger.Errorf("emailUC.GetEmailStats: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "emailUC.GetEmailStats: %v", err)
+	}
+
+	return &userService.GetEmailStatsResponse{Stats: e.convertEmailStatsToProto(stats)}, nil
+}
+
+func (e *EmailMicroservice) convertEmailStatsToProto(stats *models.EmailStats) *userService.EmailStats {
+	protoStats := &userService.EmailStats{
+		Template:     stats.Template,
+		Sent:         stats.Sent,
+		Opens:        stats.Opens,
+		UniqueOpens:  stats.UniqueOpens,
+		Clicks:       stats.Clicks,
+		UniqueClicks: stats.UniqueClicks,
+		OpenRate:     stats.GetOpenRate(),
+		ClickRate:    stats.GetClickRate(),
+	}
+	if stats.EmailID != uuid.Nil {
+		protoStats.EmailId = stats.EmailID.String()
+	}
+	return protoStats
+}
+
 func (e *EmailMicroservice) convertEmailToProto(email *models.Email) *userService.Email {
 	return &userService.Email{
 		EmailId:     email.EmailID.String(),
@@ -112,6 +176,8 @@ func (e *EmailMicroservice) convertEmailToProto(email *models.Email) *userServic
 		Body:        email.Body,
 		Subject:     email.Subject,
 		ContentType: email.ContentType,
+		Category:    email.Category,
+		Template:    email.Template,
 		CreatedAt:   timestamppb.New(email.CreatedAt),
 	}
 }
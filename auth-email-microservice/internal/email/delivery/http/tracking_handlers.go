@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/email/tracking"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Transparent 1x1 gif
+var pixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+var trackedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "email_tracked_events_total",
+	Help: "The total number of recorded email opens and clicks",
+}, []string{"event_type"})
+
+// Email tracking http handlers
+type TrackingHandlers struct {
+	emailUC email.EmailUseCase
+	tracker *tracking.Tracker
+	logger  logger.Logger
+}
+
+// New email tracking http handlers
+func NewTrackingHandlers(emailUC email.EmailUseCase, tracker *tracking.Tracker, logger logger.Logger) *TrackingHandlers {
+	return &TrackingHandlers{
+		emailUC: emailUC,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Register tracking routes
+func (h *TrackingHandlers) MapRoutes(e *echo.Echo) {
+	e.GET(tracking.OpenPath+":email_id", h.Open())
+	e.GET(tracking.ClickPath+":email_id", h.Click())
+}
+
+// Record open and serve the tracking pixel, the pixel is served even if recording fails
+func (h *TrackingHandlers) Open() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "TrackingHandlers.Open")
+		defer span.Finish()
+
+		emailID, err := uuid.Parse(c.Param("email_id"))
+		if err == nil && h.tracker.Verify(emailID, "", c.QueryParam("sig")) {
+			if err := h.emailUC.RecordEmailEvent(ctx, h.newEvent(c, emailID, models.EmailEventOpen, "")); err != nil {
+				h.logger.Errorf("emailUC.RecordEmailEvent: %v", err)
+			} else {
+				trackedEvents.WithLabelValues(models.EmailEventOpen).Inc()
+			}
+		}
+
+		c.Response().Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+		return c.Blob(http.StatusOK, "image/gif", pixel)
+	}
+}
+
+// Record click and redirect to the original link
+func (h *TrackingHandlers) Click() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "TrackingHandlers.Click")
+		defer span.Finish()
+
+		emailID, err := uuid.Parse(c.Param("email_id"))
+		if err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		}
+
+		target := c.QueryParam("url")
+		if !h.tracker.Verify(emailID, target, c.QueryParam("sig")) {
+			return c.NoContent(http.StatusBadRequest)
+		}
+
+		if err := h.emailUC.RecordEmailEvent(ctx, h.newEvent(c, emailID, models.EmailEventClick, target)); err != nil {
+			h.logger.Errorf("emailUC.RecordEmailEvent: %v", err)
+		} else {
+			trackedEvents.WithLabelValues(models.EmailEventClick).Inc()
+		}
+
+		return c.Redirect(http.StatusFound, target)
+	}
+}
+
+func (h *TrackingHandlers) newEvent(c echo.Context, emailID uuid.UUID, eventType, target string) *models.EmailEvent {
+	return &models.EmailEvent{
+		EmailID:   emailID,
+		EventType: eventType,
+		URL:       target,
+		UserAgent: c.Request().UserAgent(),
+		IP:        c.RealIP(),
+	}
+}
@@ -2,8 +2,13 @@ package rabbitmq
 
 import (
 	"context"
+	"time"
 
+	"github.com/Chuuch/ecom-microservices/config"
 	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/internal/notification"
+	grpcerrors "github.com/Chuuch/ecom-microservices/pkg/grpc_errors"
 	"github.com/Chuuch/ecom-microservices/pkg/logger"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
@@ -24,7 +29,7 @@ const (
 	queryExclusive  = false // not tied to a single connection
 	queryNoWait     = false
 
-	prefetchCount  = 1     // one unacked at a time per consumer
+	prefetchCount  = 1     // one unacked at a time per consumer, unless the lane sets its own
 	prefetchSize   = 0     // size-based limit disabled
 	prefetchGlobal = false // apply per-consumer not globally
 
@@ -33,43 +38,56 @@ const (
 	consumeNoLocal    = false
 	consumeNoWait     = false //
 
-	publishNoWait    = false // fail if not routed
-	publishMandatory = false // fail if no consumers
+	throttleBackoff    = time.Second      // wait before requeueing a throttled delivery without a retry after
+	maxThrottleBackoff = 30 * time.Second // longest wait before requeueing, a daily quota is retried every maxThrottleBackoff
+
+	publishMandatory = true  // return message if not routed to any queue
+	publishImmediate = false // not supported by RabbitMQ 3+
 )
 
 var (
-	IncomingMessages = promauto.NewCounter(prometheus.CounterOpts{
+	IncomingMessages = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "email_incoming_rabbitmq_messages_total",
 		Help: "The total number of incoming RabbitMQ messages",
-	})
-	successMessages = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"lane"})
+	successMessages = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "email_success_rabbitmq_messages_total",
 		Help: "The total number of successful RabbitMQ messages",
-	})
-	failureMessages = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"lane"})
+	failureMessages = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "email_failure_rabbitmq_messages_total",
 		Help: "The total number of failed RabbitMQ messages",
-	})
+	}, []string{"lane"})
+	throttledMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_throttled_rabbitmq_messages_total",
+		Help: "The total number of RabbitMQ messages requeued by the rate limiter",
+	}, []string{"lane"})
+	processingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "email_rabbitmq_message_processing_seconds",
+		Help: "Time spent processing a RabbitMQ message",
+	}, []string{"lane"})
 )
 
 // Email consumer
 type EmailConsumer struct {
-	amqpConn *amqp.Connection
-	logger   logger.Logger
-	emailUC  email.EmailUseCase
+	amqpConn       *amqp.Connection
+	logger         logger.Logger
+	emailUC        email.EmailUseCase
+	notificationUC notification.NotificationUseCase
 }
 
 // New Email consumer
-func NewEmailConsumer(amqpConn *amqp.Connection, logger logger.Logger, emailUC email.EmailUseCase) *EmailConsumer {
+func NewEmailConsumer(amqpConn *amqp.Connection, logger logger.Logger, emailUC email.EmailUseCase, notificationUC notification.NotificationUseCase) *EmailConsumer {
 	return &EmailConsumer{
-		amqpConn: amqpConn,
-		logger:   logger,
-		emailUC:  emailUC,
+		amqpConn:       amqpConn,
+		logger:         logger,
+		emailUC:        emailUC,
+		notificationUC: notificationUC,
 	}
 }
 
 // Consumer messages
-func (c *EmailConsumer) CreateChannel(exchangeName, queueName, bindingKey, consumerTag string) (*amqp.Channel, error) {
+func (c *EmailConsumer) CreateChannel(exchangeName, queueName, bindingKey, consumerTag string, prefetch int) (*amqp.Channel, error) {
 	ch, err := c.amqpConn.Channel()
 	if err != nil {
 		return nil, errors.Wrap(err, "amqpConn.Channel")
@@ -127,8 +145,12 @@ func (c *EmailConsumer) CreateChannel(exchangeName, queueName, bindingKey, consu
 
 	c.logger.Infof("Queue bound to exchange, starting to consume from queue, consumerTag: %v", consumerTag)
 
+	if prefetch <= 0 {
+		prefetch = prefetchCount
+	}
+
 	err = ch.Qos(
-		prefetchCount,
+		prefetch,
 		prefetchSize,
 		prefetchGlobal,
 	)
@@ -139,38 +161,79 @@ func (c *EmailConsumer) CreateChannel(exchangeName, queueName, bindingKey, consu
 	return ch, nil
 }
 
-func (c *EmailConsumer) worker(ctx context.Context, messages <-chan amqp.Delivery) {
+func (c *EmailConsumer) worker(ctx context.Context, lane string, messages <-chan amqp.Delivery) {
 
 	for delivery := range messages {
 		span, ctx := opentracing.StartSpanFromContext(ctx, "EmailConsumer.worker")
+		span.SetTag("lane", lane)
+		start := time.Now()
 
-		c.logger.Infof("processDeliveries deliveryTag: %v", delivery.DeliveryTag)
+		c.logger.Infof("processDeliveries lane: %s, deliveryTag: %v", lane, delivery.DeliveryTag)
 
-		IncomingMessages.Inc()
+		IncomingMessages.WithLabelValues(lane).Inc()
 
-		err := c.emailUC.SendEmail(ctx, delivery)
-		if err != nil {
+		err := c.process(ctx, delivery)
+		if errors.Is(err, grpcerrors.ErrSendThrottled) {
+			// Over the provider quota, wait for the limits before putting it back so redelivery does not spin
+			c.backoff(ctx, retryAfter(err))
+			if err := delivery.Nack(false, true); err != nil {
+				c.logger.Errorf("delivery.Nack: %v", err)
+			}
+			c.logger.Infof("c.process, delivery throttled and requeued: %v", err)
+			throttledMessages.WithLabelValues(lane).Inc()
+		} else if err != nil {
 			if err := delivery.Reject(false); err != nil {
 				c.logger.Errorf("delivery.Reject: %v", err)
 			}
-			c.logger.Errorf("emailUC.SendEmail, Failed to process delivery: %v", err)
-			failureMessages.Inc()
-			span.Finish()
+			c.logger.Errorf("c.process, Failed to process delivery: %v", err)
+			failureMessages.WithLabelValues(lane).Inc()
 		} else {
 			err = delivery.Ack(false)
 			if err != nil {
 				c.logger.Errorf("delivery.Ack: %v", err)
 			}
-			successMessages.Inc()
-			span.Finish()
+			successMessages.WithLabelValues(lane).Inc()
 		}
+		processingDuration.WithLabelValues(lane).Observe(time.Since(start).Seconds())
+		span.Finish()
+	}
+	c.logger.Infof("Deliveries channel closed, lane: %s", lane)
+}
+
+// Wait until the throttled send may be retried, at most maxThrottleBackoff so the delivery is requeued
+// long before the broker consumer timeout
+func (c *EmailConsumer) backoff(ctx context.Context, wait time.Duration) {
+	timer := time.NewTimer(min(wait, maxThrottleBackoff))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
 	}
-	c.logger.Infof("Deliveries channel closed")
 }
 
-// Start new rabbitmq consumer
-func (c *EmailConsumer) StartConsumer(workerPoolSize int, exchangeName, queueName, bindingKey, consumerTag string) error {
-	ch, err := c.CreateChannel(exchangeName, queueName, bindingKey, consumerTag)
+// Wait the limiter asked for, throttleBackoff when the error does not carry it
+func retryAfter(err error) time.Duration {
+	var throttled *email.ThrottledError
+	if errors.As(err, &throttled) && throttled.RetryAfter > 0 {
+		return throttled.RetryAfter
+	}
+	return throttleBackoff
+}
+
+// Dispatch delivery by message type, untyped messages are emails
+func (c *EmailConsumer) process(ctx context.Context, delivery amqp.Delivery) error {
+	switch delivery.Type {
+	case models.MessageTypeNotification:
+		return c.notificationUC.DeliverNotification(ctx, delivery)
+	default:
+		return c.emailUC.SendEmail(ctx, delivery)
+	}
+}
+
+// Start new rabbitmq consumer for a single email lane
+func (c *EmailConsumer) StartConsumer(exchangeName string, lane config.EmailLaneConfig) error {
+	ch, err := c.CreateChannel(exchangeName, lane.Queue, lane.RoutingKey, lane.ConsumerTag, lane.PrefetchCount)
 	if err != nil {
 		return err
 	}
@@ -180,8 +243,8 @@ func (c *EmailConsumer) StartConsumer(workerPoolSize int, exchangeName, queueNam
 	defer cancel()
 
 	deliveries, err := ch.Consume(
-		queueName,
-		consumerTag,
+		lane.Queue,
+		lane.ConsumerTag,
 		consumerAutoAck,
 		consumerExclusive,
 		consumeNoLocal,
@@ -192,8 +255,9 @@ func (c *EmailConsumer) StartConsumer(workerPoolSize int, exchangeName, queueNam
 		return errors.Wrap(err, "ch.Consume")
 	}
 
-	for range workerPoolSize {
-		go c.worker(ctx, deliveries)
+	c.logger.Infof("Starting %d workers for lane: %s", lane.WorkerPoolSize, lane.Category)
+	for range lane.WorkerPoolSize {
+		go c.worker(ctx, lane.Category, deliveries)
 	}
 
 	// Shutdown gracefully
@@ -0,0 +1,151 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/email/mailer"
+	"github.com/Chuuch/ecom-microservices/internal/email/ratelimit"
+	"github.com/Chuuch/ecom-microservices/internal/email/usecase"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/internal/notification"
+	grpcerrors "github.com/Chuuch/ecom-microservices/pkg/grpc_errors"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+type emailUCStub struct {
+	email.EmailUseCase
+	sent int
+}
+
+func (s *emailUCStub) SendEmail(ctx context.Context, delivery amqp.Delivery) error {
+	s.sent++
+	return nil
+}
+
+type notificationUCStub struct {
+	notification.NotificationUseCase
+	delivered int
+}
+
+func (s *notificationUCStub) DeliverNotification(ctx context.Context, delivery amqp.Delivery) error {
+	s.delivered++
+	return nil
+}
+
+// delivery as the broker hands the published message to the consumer
+func delivery(p amqp.Publishing) amqp.Delivery {
+	return amqp.Delivery{
+		ContentType: p.ContentType,
+		Type:        p.Type,
+		Body:        p.Body,
+		MessageId:   p.MessageId,
+	}
+}
+
+func TestEmailConsumer_ProcessRoutesByPublishedType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		messageType   string
+		wantEmails    int
+		wantDelivered int
+	}{
+		{name: "email", messageType: models.MessageTypeEmail, wantEmails: 1},
+		{name: "notification", messageType: models.MessageTypeNotification, wantDelivered: 1},
+		{name: "untyped", messageType: "", wantEmails: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			emailUC := &emailUCStub{}
+			notificationUC := &notificationUCStub{}
+			c := &EmailConsumer{emailUC: emailUC, notificationUC: notificationUC}
+
+			p := newPublishing(tt.messageType, []byte(`{}`), "application/json", "message-id")
+			require.Equal(t, tt.messageType, p.Type)
+
+			require.NoError(t, c.process(context.Background(), delivery(p)))
+			require.Equal(t, tt.wantEmails, emailUC.sent)
+			require.Equal(t, tt.wantDelivered, notificationUC.delivered)
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{name: "limiter wait", err: errors.Wrap(&email.ThrottledError{RetryAfter: 5 * time.Second}, "mailer.Send"), want: 5 * time.Second},
+		{name: "no wait", err: &email.ThrottledError{}, want: throttleBackoff},
+		{name: "untyped", err: errors.Wrap(grpcerrors.ErrSendThrottled, "notifier.Send"), want: throttleBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.ErrorIs(t, tt.err, grpcerrors.ErrSendThrottled)
+			require.Equal(t, tt.want, retryAfter(tt.err))
+		})
+	}
+}
+
+type emailRepoStub struct {
+	email.EmailRepository
+	created int
+}
+
+func (s *emailRepoStub) CreateEmail(ctx context.Context, mail *models.Email) (*models.Email, error) {
+	s.created++
+	return mail, nil
+}
+
+func TestEmailConsumer_ProcessThrottlesSends(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Logger:    config.LoggerConfig{Level: "error"},
+		RateLimit: config.RateLimitConfig{Enabled: true, GlobalPerSecond: 0.1, GlobalBurst: 1},
+	}
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	// Sending is disabled as in the default config, the limits still apply
+	throttled := mailer.NewThrottledMailer(mailer.NewDisabledMailer(appLogger), ratelimit.NewMemoryLimiter(), cfg)
+	emailRepo := &emailRepoStub{}
+	emailUC := usecase.NewEmailUseCase(emailRepo, appLogger, throttled, cfg, nil, nil, nil)
+	c := &EmailConsumer{logger: appLogger, emailUC: emailUC, notificationUC: &notificationUCStub{}}
+
+	body, err := json.Marshal(&models.Email{
+		To:          []string{"user@example.com"},
+		Subject:     "Order confirmed",
+		Body:        "<p>Thanks</p>",
+		ContentType: "text/html",
+	})
+	require.NoError(t, err)
+	p := newPublishing(models.MessageTypeEmail, body, "application/json", "message-id")
+
+	require.NoError(t, c.process(context.Background(), delivery(p)))
+	require.Equal(t, 1, emailRepo.created)
+
+	// The burst is used up, the next send is over the limit and goes back to the queue after the limiter's wait
+	err = c.process(context.Background(), delivery(p))
+	require.ErrorIs(t, err, grpcerrors.ErrSendThrottled)
+	require.Greater(t, retryAfter(err), throttleBackoff)
+	require.Equal(t, 1, emailRepo.created)
+}
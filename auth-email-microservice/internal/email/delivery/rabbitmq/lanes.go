@@ -0,0 +1,35 @@
+package rabbitmq
+
+import (
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+)
+
+// Get configured email lanes, without lanes all email goes through the default queue
+func GetEmailLanes(cfg *config.Config) []config.EmailLaneConfig {
+	if len(cfg.RabbitMQ.Lanes) > 0 {
+		return cfg.RabbitMQ.Lanes
+	}
+
+	return []config.EmailLaneConfig{
+		{
+			Category:       models.EmailCategoryTransactional,
+			Queue:          cfg.RabbitMQ.Queue,
+			RoutingKey:     cfg.RabbitMQ.RoutingKey,
+			ConsumerTag:    cfg.RabbitMQ.ConsumerTag,
+			WorkerPoolSize: cfg.RabbitMQ.WorkerPoolSize,
+			PrefetchCount:  prefetchCount,
+		},
+	}
+}
+
+// Get the lane for email category
+func getEmailLane(cfg *config.Config, category string) (config.EmailLaneConfig, bool) {
+	for _, lane := range GetEmailLanes(cfg) {
+		if lane.Category == category {
+			return lane, true
+		}
+	}
+
+	return config.EmailLaneConfig{}, false
+}
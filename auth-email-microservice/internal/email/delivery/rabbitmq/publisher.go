@@ -1,30 +1,58 @@
 package rabbitmq
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	grpcerrors "github.com/Chuuch/ecom-microservices/pkg/grpc_errors"
 	"github.com/Chuuch/ecom-microservices/pkg/logger"
 	"github.com/Chuuch/ecom-microservices/pkg/rabbitmq"
-	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/streadway/amqp"
 )
 
+const (
+	confirmsBufferSize = 100 // buffered broker acks/nacks
+	returnsBufferSize  = 100 // buffered unroutable messages
+)
+
 var (
-	publishedMessages = promauto.NewCounter(prometheus.CounterOpts{
+	publishedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "email_published_rabbitmq_messages_total",
 		Help: "The total number of published RabbitMQ messages",
-	})
+	}, []string{"lane"})
+	confirmedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_confirmed_rabbitmq_messages_total",
+		Help: "The total number of RabbitMQ messages confirmed by the broker",
+	}, []string{"lane"})
+	unconfirmedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "email_unconfirmed_rabbitmq_messages_total",
+		Help: "The total number of RabbitMQ messages nacked, returned or timed out",
+	}, []string{"lane"})
 )
 
+// Publish waiting for broker confirmation
+type pendingPublish struct {
+	messageID string
+	done      chan error
+}
+
 // Emails rabbitmq publisher
 type EmailsPublisher struct {
 	amqpChan *amqp.Channel
 	cfg      *config.Config
 	logger   logger.Logger
+
+	mu          sync.Mutex
+	deliveryTag uint64
+	pending     map[uint64]*pendingPublish
+	returned    map[string]amqp.Return
 }
 
 // Emails rabbitmq publisher constructor
@@ -37,11 +65,24 @@ func NewEmailsPublisher(cfg *config.Config, logger logger.Logger) (*EmailsPublis
 	if err != nil {
 		return nil, errors.Wrap(err, "p.mqConn.Channel")
 	}
-	return &EmailsPublisher{
+
+	if err := amqpChan.Confirm(false); err != nil {
+		return nil, errors.Wrap(err, "amqpChan.Confirm")
+	}
+
+	e := &EmailsPublisher{
 		amqpChan: amqpChan,
 		cfg:      cfg,
 		logger:   logger,
-	}, nil
+		pending:  make(map[uint64]*pendingPublish),
+		returned: make(map[string]amqp.Return),
+	}
+
+	confirms := amqpChan.NotifyPublish(make(chan amqp.Confirmation, confirmsBufferSize))
+	returns := amqpChan.NotifyReturn(make(chan amqp.Return, returnsBufferSize))
+	go e.handleConfirms(confirms, returns)
+
+	return e, nil
 }
 
 func (e *EmailsPublisher) SetupExchangeAndQueue(exchange, queueName, bindingKey, consumerTag string) error {
@@ -90,6 +131,18 @@ func (e *EmailsPublisher) SetupExchangeAndQueue(exchange, queueName, bindingKey,
 	return nil
 }
 
+// newPublishing persistent message, the consumer dispatches on its type
+func newPublishing(messageType string, body []byte, contentType, messageID string) amqp.Publishing {
+	return amqp.Publishing{
+		ContentType:  contentType,
+		Type:         messageType,
+		Body:         body,
+		Timestamp:    time.Now(),
+		MessageId:    messageID,
+		DeliveryMode: amqp.Persistent,
+	}
+}
+
 // Close messages channel
 func (e *EmailsPublisher) CloseMessagesChannel() {
 	if err := e.amqpChan.Close(); err != nil {
@@ -97,29 +150,154 @@ func (e *EmailsPublisher) CloseMessagesChannel() {
 	}
 }
 
-// Publish message
-func (e *EmailsPublisher) Publish(body []byte, contentType string) error {
-	e.logger.Infof("Publishing message to exchange: %s, RoutingKey: %s", e.cfg.RabbitMQ.Exchange, e.cfg.RabbitMQ.RoutingKey)
+// Publish email message to the lane of the email category and wait for the broker to confirm it
+func (e *EmailsPublisher) Publish(ctx context.Context, category string, body []byte, contentType, messageID string) error {
+	return e.PublishMessage(ctx, models.MessageTypeEmail, category, body, contentType, messageID)
+}
+
+// Publish message of the given type to the lane of the category and wait for the broker to confirm it
+func (e *EmailsPublisher) PublishMessage(ctx context.Context, messageType, category string, body []byte, contentType, messageID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailsPublisher.PublishMessage")
+	defer span.Finish()
+
+	lane, ok := getEmailLane(e.cfg, category)
+	if !ok {
+		return errors.Wrapf(grpcerrors.ErrUnknownEmailCategory, "category: %s", category)
+	}
+
+	e.logger.Infof("Publishing %s message to exchange: %s, RoutingKey: %s, MessageId: %s", messageType, e.cfg.RabbitMQ.Exchange, lane.RoutingKey, messageID)
+
+	done := make(chan error, 1)
 
+	// Delivery tags are assigned by the channel in publish order, so publish and register under one lock
+	e.mu.Lock()
 	if err := e.amqpChan.Publish(
 		e.cfg.RabbitMQ.Exchange,
-		e.cfg.RabbitMQ.RoutingKey,
-		publishNoWait,
+		lane.RoutingKey,
 		publishMandatory,
-		amqp.Publishing{
-			ContentType:  contentType,
-			Body:         body,
-			Timestamp:    time.Now(),
-			MessageId:    uuid.New().String(),
-			DeliveryMode: amqp.Persistent,
-		},
+		publishImmediate,
+		newPublishing(messageType, body, contentType, messageID),
 	); err != nil {
+		e.mu.Unlock()
 		return errors.Wrap(err, "e.amqpChan.Publish")
 	}
+	e.deliveryTag++
+	deliveryTag := e.deliveryTag
+	e.pending[deliveryTag] = &pendingPublish{messageID: messageID, done: done}
+	e.mu.Unlock()
+
+	publishedMessages.WithLabelValues(lane.Category).Inc()
+
+	timer := time.NewTimer(e.cfg.RabbitMQ.ConfirmTimeout * time.Second)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			unconfirmedMessages.WithLabelValues(lane.Category).Inc()
+			return err
+		}
+	case <-timer.C:
+		e.forget(deliveryTag)
+		unconfirmedMessages.WithLabelValues(lane.Category).Inc()
+		return errors.Wrapf(grpcerrors.ErrPublishTimeout, "MessageId: %s", messageID)
+	case <-ctx.Done():
+		e.forget(deliveryTag)
+		unconfirmedMessages.WithLabelValues(lane.Category).Inc()
+		return errors.Wrap(ctx.Err(), "EmailsPublisher.Publish")
+	}
 
-	e.logger.Infof("Message published to exchange: %s, RoutingKey: %s", e.cfg.RabbitMQ.Exchange, e.cfg.RabbitMQ.RoutingKey)
+	e.logger.Infof("Message confirmed by broker, exchange: %s, RoutingKey: %s, MessageId: %s", e.cfg.RabbitMQ.Exchange, lane.RoutingKey, messageID)
 
-	publishedMessages.Inc()
+	confirmedMessages.WithLabelValues(lane.Category).Inc()
 
 	return nil
 }
+
+// Dispatch broker acks, nacks and returns to the waiting publishers
+func (e *EmailsPublisher) handleConfirms(confirms <-chan amqp.Confirmation, returns <-chan amqp.Return) {
+	for {
+		select {
+		case r, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			e.storeReturn(r)
+		case c, ok := <-confirms:
+			if !ok {
+				e.failPending(grpcerrors.ErrPublishNotConfirmed)
+				e.logger.Infof("Publisher confirms channel closed")
+				return
+			}
+			// The broker sends basic.return before the ack of the same message
+			e.drainReturns(returns)
+			e.resolve(c)
+		}
+	}
+}
+
+func (e *EmailsPublisher) drainReturns(returns <-chan amqp.Return) {
+	for {
+		select {
+		case r, ok := <-returns:
+			if !ok {
+				return
+			}
+			e.storeReturn(r)
+		default:
+			return
+		}
+	}
+}
+
+func (e *EmailsPublisher) storeReturn(r amqp.Return) {
+	e.logger.Errorf("Message returned by broker, MessageId: %s, ReplyCode: %v, ReplyText: %s", r.MessageId, r.ReplyCode, r.ReplyText)
+
+	e.mu.Lock()
+	e.returned[r.MessageId] = r
+	e.mu.Unlock()
+}
+
+func (e *EmailsPublisher) resolve(c amqp.Confirmation) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p, ok := e.pending[c.DeliveryTag]
+	if !ok {
+		return
+	}
+	delete(e.pending, c.DeliveryTag)
+
+	r, returned := e.returned[p.messageID]
+	delete(e.returned, p.messageID)
+
+	switch {
+	case returned:
+		p.done <- errors.Wrapf(grpcerrors.ErrPublishUnroutable, "MessageId: %s, ReplyText: %s", p.messageID, r.ReplyText)
+	case !c.Ack:
+		p.done <- errors.Wrapf(grpcerrors.ErrPublishNotConfirmed, "MessageId: %s", p.messageID)
+	default:
+		p.done <- nil
+	}
+}
+
+func (e *EmailsPublisher) forget(deliveryTag uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if p, ok := e.pending[deliveryTag]; ok {
+		delete(e.returned, p.messageID)
+		delete(e.pending, deliveryTag)
+	}
+}
+
+func (e *EmailsPublisher) failPending(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for deliveryTag, p := range e.pending {
+		p.done <- errors.Wrapf(err, "MessageId: %s", p.messageID)
+		delete(e.pending, deliveryTag)
+	}
+}
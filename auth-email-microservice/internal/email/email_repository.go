@@ -13,4 +13,7 @@ type EmailRepository interface {
 	CreateEmail(ctx context.Context, email *models.Email) (*models.Email, error)
 	FindEmailById(ctx context.Context, emailID uuid.UUID) (*models.Email, error)
 	FindEmailsByReceiver(ctx context.Context, receiverEmail string, paginationQuery *utils.PaginationQuery) (*models.EmailsList, error)
+	CreateEmailEvent(ctx context.Context, event *models.EmailEvent) (*models.EmailEvent, error)
+	GetEmailStats(ctx context.Context, emailID uuid.UUID) (*models.EmailStats, error)
+	GetTemplateStats(ctx context.Context, template string) (*models.EmailStats, error)
 }
@@ -15,4 +15,7 @@ type EmailUseCase interface {
 	PublishEmail(ctx context.Context, email *models.Email) error
 	FindEmailById(ctx context.Context, emailID uuid.UUID) (*models.Email, error)
 	FindEmailsByReceiver(ctx context.Context, receiverEmail string, paginationQuery *utils.PaginationQuery) (*models.EmailsList, error)
+	RecordEmailEvent(ctx context.Context, event *models.EmailEvent) error
+	GetEmailStats(ctx context.Context, emailID uuid.UUID) (*models.EmailStats, error)
+	GetTemplateStats(ctx context.Context, template string) (*models.EmailStats, error)
 }
@@ -0,0 +1,15 @@
+package email
+
+import (
+	"context"
+	"time"
+)
+
+// gRPC metadata of a client chosen idempotency key
+const MetadataIdempotencyKey = "idempotency-key"
+
+// Email idempotency redis repository interface
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, key string, value string, ttl time.Duration) (string, bool, error)
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,28 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	"github.com/opentracing/opentracing-go"
+)
+
+// Mailer used while sending is disabled, it accepts every email without handing it to the provider
+type DisabledMailer struct {
+	logger logger.Logger
+}
+
+// New disabled mailer
+func NewDisabledMailer(logger logger.Logger) *DisabledMailer {
+	return &DisabledMailer{logger: logger}
+}
+
+// Send only logs the email
+func (m *DisabledMailer) Send(ctx context.Context, email *models.Email) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "DisabledMailer.Send")
+	defer span.Finish()
+
+	m.logger.Infof("Sending disabled, skipping email: %v", email.EmailID)
+	return nil
+}
@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+)
+
+var (
+	throttledSends = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "email_throttled_sends_total",
+		Help: "The total number of sends delayed past the max delay by the rate limiter",
+	})
+	throttleWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "email_throttle_wait_seconds",
+		Help: "Time spent waiting for the rate limiter before sending",
+	})
+)
+
+// Mailer that waits for the provider rate limits before sending
+type ThrottledMailer struct {
+	mailer  email.Mailer
+	limiter email.RateLimiter
+	cfg     *config.Config
+}
+
+// New throttled mailer
+func NewThrottledMailer(mailer email.Mailer, limiter email.RateLimiter, cfg *config.Config) *ThrottledMailer {
+	return &ThrottledMailer{
+		mailer:  mailer,
+		limiter: limiter,
+		cfg:     cfg,
+	}
+}
+
+// Send email once all limits allow it, waits at most MaxDelay
+func (m *ThrottledMailer) Send(ctx context.Context, mail *models.Email) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ThrottledMailer.Send")
+	defer span.Finish()
+
+	limits := m.getLimits(mail)
+	maxDelay := m.cfg.RateLimit.MaxDelay * time.Second
+	start := time.Now()
+
+	for {
+		wait, err := m.limiter.Take(ctx, limits...)
+		if err != nil {
+			return errors.Wrap(err, "limiter.Take")
+		}
+		if wait == 0 {
+			break
+		}
+
+		if time.Since(start)+wait > maxDelay {
+			throttledSends.Inc()
+			span.LogFields(log.String("throttled", wait.String()))
+			return &email.ThrottledError{RetryAfter: wait}
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "ThrottledMailer.Send")
+		}
+	}
+
+	throttleWait.Observe(time.Since(start).Seconds())
+
+	return m.mailer.Send(ctx, mail)
+}
+
+func (m *ThrottledMailer) getLimits(mail *models.Email) []email.RateLimit {
+	cfg := m.cfg.RateLimit
+
+	limits := make([]email.RateLimit, 0, len(mail.To)+2)
+	if cfg.GlobalPerSecond > 0 {
+		limits = append(limits, email.RateLimit{Key: "global:second", Rate: cfg.GlobalPerSecond, Burst: max(cfg.GlobalBurst, 1)})
+	}
+	if cfg.GlobalPerDay > 0 {
+		limits = append(limits, email.RateLimit{Key: "global:day", Rate: float64(cfg.GlobalPerDay) / secondsPerDay, Burst: cfg.GlobalPerDay})
+	}
+
+	if cfg.DomainPerSecond > 0 {
+		seen := make(map[string]bool, len(mail.To))
+		for _, to := range mail.To {
+			domain := getDomain(to)
+			if domain == "" || seen[domain] {
+				continue
+			}
+			seen[domain] = true
+			limits = append(limits, email.RateLimit{Key: "domain:" + domain, Rate: cfg.DomainPerSecond, Burst: max(cfg.DomainBurst, 1)})
+		}
+	}
+
+	return limits
+}
+
+func getDomain(address string) string {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(address[at+1:]))
+}
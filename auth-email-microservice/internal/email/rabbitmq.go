@@ -1,11 +1,18 @@
 package email
 
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/config"
+)
+
 // Emails publisher interface
 type EmailsPublisher interface {
-	Publish(body []byte, contentType string) error
+	Publish(ctx context.Context, category string, body []byte, contentType, messageID string) error
+	PublishMessage(ctx context.Context, messageType, category string, body []byte, contentType, messageID string) error
 }
 
 // Emails consumer interface
 type EmailsConsumer interface {
-	StartConsumer(workerPoolSize int, exchange, queueName, bindingKey, consumerTag string) error
+	StartConsumer(exchange string, lane config.EmailLaneConfig) error
 }
@@ -0,0 +1,36 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	grpcerrors "github.com/Chuuch/ecom-microservices/pkg/grpc_errors"
+)
+
+// Token bucket limit, Rate tokens per second up to Burst tokens
+type RateLimit struct {
+	Key   string
+	Rate  float64
+	Burst int
+}
+
+// Send rate limiter interface
+type RateLimiter interface {
+	// Take one token from every limit, if any bucket is empty nothing is taken and the wait until it refills is returned
+	Take(ctx context.Context, limits ...RateLimit) (time.Duration, error)
+}
+
+// Send over the rate limits, the limits allow it again after RetryAfter
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%v, retry after: %v", grpcerrors.ErrSendThrottled, e.RetryAfter)
+}
+
+// Unwrap so errors.Is matches grpcerrors.ErrSendThrottled
+func (e *ThrottledError) Unwrap() error {
+	return grpcerrors.ErrSendThrottled
+}
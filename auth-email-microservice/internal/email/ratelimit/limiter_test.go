@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupRedis() email.RateLimiter {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	return NewRedisLimiter(client)
+}
+
+func TestMemoryLimiter_Take(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limit := email.RateLimit{Key: "global:second", Rate: 2, Burst: 2}
+
+	t.Run("Burst", func(t *testing.T) {
+		for range 2 {
+			wait, err := limiter.Take(context.Background(), limit)
+			require.NoError(t, err)
+			require.Zero(t, wait)
+		}
+
+		wait, err := limiter.Take(context.Background(), limit)
+		require.NoError(t, err)
+		require.Equal(t, 500*time.Millisecond, wait)
+	})
+
+	t.Run("Refill", func(t *testing.T) {
+		now = now.Add(500 * time.Millisecond)
+
+		wait, err := limiter.Take(context.Background(), limit)
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	})
+}
+
+func TestMemoryLimiter_TakeAllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewMemoryLimiter()
+	global := email.RateLimit{Key: "global:second", Rate: 1, Burst: 2}
+	domain := email.RateLimit{Key: "domain:example.com", Rate: 1, Burst: 1}
+
+	wait, err := limiter.Take(context.Background(), global, domain)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	wait, err = limiter.Take(context.Background(), global, domain)
+	require.NoError(t, err)
+	require.Positive(t, wait)
+
+	// The throttled domain must not have used up the global token
+	wait, err = limiter.Take(context.Background(), global)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestRedisLimiter_Take(t *testing.T) {
+	t.Parallel()
+
+	limiter := SetupRedis()
+	limit := email.RateLimit{Key: "domain:example.com", Rate: 1, Burst: 1}
+
+	wait, err := limiter.Take(context.Background(), limit)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	wait, err = limiter.Take(context.Background(), limit)
+	require.NoError(t, err)
+	require.Positive(t, wait)
+	require.LessOrEqual(t, wait, time.Second)
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// In-process token bucket limiter, shared by the workers of a single replica
+type memoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// New in-process rate limiter
+func NewMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (l *memoryLimiter) Take(ctx context.Context, limits ...email.RateLimit) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	var wait time.Duration
+	for _, limit := range limits {
+		b := l.refill(limit, now)
+		if b.tokens < 1 {
+			wait = max(wait, refillWait(b.tokens, limit.Rate))
+		}
+	}
+
+	if wait > 0 {
+		return wait, nil
+	}
+
+	for _, limit := range limits {
+		l.buckets[limit.Key].tokens--
+	}
+
+	return 0, nil
+}
+
+func (l *memoryLimiter) refill(limit email.RateLimit, now time.Time) *bucket {
+	b, ok := l.buckets[limit.Key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[limit.Key] = b
+	}
+
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	return b
+}
+
+// Time until the bucket holds a whole token again
+func refillWait(tokens, rate float64) time.Duration {
+	return time.Duration(math.Ceil((1 - tokens) / rate * float64(time.Second)))
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	basePrefix = "email_ratelimit"
+)
+
+// Checks every bucket first and only takes tokens when all of them have one,
+// returns the wait in milliseconds or 0 when the tokens were taken.
+// KEYS: bucket keys, ARGV: now in ms, then rate and burst per key
+var takeScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local wait = 0
+local tokens = {}
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[i * 2])
+	local burst = tonumber(ARGV[i * 2 + 1])
+	local state = redis.call('HMGET', key, 'tokens', 'ts')
+	local available = tonumber(state[1]) or burst
+	local ts = tonumber(state[2]) or now
+	available = math.min(burst, available + math.max(0, now - ts) / 1000 * rate)
+	tokens[i] = available
+	if available < 1 then
+		wait = math.max(wait, math.ceil((1 - available) / rate * 1000))
+	end
+end
+if wait > 0 then
+	return wait
+end
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[i * 2])
+	local burst = tonumber(ARGV[i * 2 + 1])
+	redis.call('HMSET', key, 'tokens', tostring(tokens[i] - 1), 'ts', tostring(now))
+	redis.call('PEXPIRE', key, math.ceil(burst / rate * 1000) + 1000)
+end
+return 0
+`)
+
+// Redis token bucket limiter, shared by all replicas
+type redisLimiter struct {
+	redisClient *redis.Client
+	basePrefix  string
+}
+
+// New Redis rate limiter
+func NewRedisLimiter(redisClient *redis.Client) *redisLimiter {
+	return &redisLimiter{
+		redisClient: redisClient,
+		basePrefix:  basePrefix,
+	}
+}
+
+func (l *redisLimiter) Take(ctx context.Context, limits ...email.RateLimit) (time.Duration, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "redisLimiter.Take")
+	defer span.Finish()
+
+	keys := make([]string, 0, len(limits))
+	args := make([]interface{}, 0, len(limits)*2+1)
+	args = append(args, time.Now().UnixMilli())
+	for _, limit := range limits {
+		keys = append(keys, l.createKey(limit.Key))
+		args = append(args, limit.Rate, limit.Burst)
+	}
+
+	waitMs, err := takeScript.Run(ctx, l.redisClient, keys, args...).Int64()
+	if err != nil {
+		return 0, errors.Wrap(err, "redisLimiter.Take.takeScript.Run")
+	}
+
+	return time.Duration(waitMs) * time.Millisecond, nil
+}
+
+func (l *redisLimiter) createKey(key string) string {
+	return fmt.Sprintf("%s:%s", l.basePrefix, key)
+}
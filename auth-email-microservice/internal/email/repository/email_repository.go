@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/Chuuch/ecom-microservices/internal/models"
 	"github.com/Chuuch/ecom-microservices/pkg/utils"
@@ -25,7 +26,7 @@ func (e *EmailRepository) CreateEmail(ctx context.Context, email *models.Email)
 	defer span.Finish()
 
 	var id uuid.UUID
-	if err := e.db.QueryRowContext(ctx, createEmailQuery, email.To, email.From, email.Subject, email.Body, email.ContentType).Scan(&id); err != nil {
+	if err := e.db.QueryRowContext(ctx, createEmailQuery, email.EmailID, email.To, email.From, email.Subject, email.Body, email.ContentType, email.Category, email.Template).Scan(&id); err != nil {
 		return nil, errors.Wrap(err, "CreateEmail.QueryRowContext")
 	}
 
@@ -41,7 +42,7 @@ func (e *EmailRepository) FindEmailById(ctx context.Context, emailID uuid.UUID)
 	var to string
 	email := &models.Email{}
 
-	if err := e.db.QueryRowContext(ctx, findEmailByIdQuery, emailID).Scan(&email.EmailID, &to, &email.From, &email.Subject, &email.Body, &email.ContentType, &email.CreatedAt); err != nil {
+	if err := e.db.QueryRowContext(ctx, findEmailByIdQuery, emailID).Scan(&email.EmailID, &to, &email.From, &email.Subject, &email.Body, &email.ContentType, &email.Category, &email.Template, &email.CreatedAt); err != nil {
 		return nil, errors.Wrap(err, "FindEmailById.QueryRowContext")
 	}
 
@@ -85,6 +86,8 @@ func (e *EmailRepository) FindEmailsByReceiver(ctx context.Context, receiverEmai
 			&email.Subject,
 			&email.Body,
 			&email.ContentType,
+			&email.Category,
+			&email.Template,
 			&email.CreatedAt,
 		); err != nil {
 			return nil, errors.Wrap(err, "FindEmailsByReceiver.QueryxContext.Scan")
@@ -103,3 +106,63 @@ func (e *EmailRepository) FindEmailsByReceiver(ctx context.Context, receiverEmai
 		Emails:     emails,
 	}, nil
 }
+
+// Record open or click event
+func (e *EmailRepository) CreateEmailEvent(ctx context.Context, event *models.EmailEvent) (*models.EmailEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailRepository.CreateEmailEvent")
+	defer span.Finish()
+
+	if err := e.db.QueryRowContext(
+		ctx,
+		createEmailEventQuery,
+		event.EmailID,
+		event.EventType,
+		event.URL,
+		event.UserAgent,
+		event.IP,
+	).Scan(&event.EventID, &event.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "CreateEmailEvent.QueryRowContext")
+	}
+
+	return event, nil
+}
+
+// Get engagement stats of a single email
+func (e *EmailRepository) GetEmailStats(ctx context.Context, emailID uuid.UUID) (*models.EmailStats, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailRepository.GetEmailStats")
+	defer span.Finish()
+
+	stats := &models.EmailStats{EmailID: emailID}
+	if err := e.scanEmailStats(e.db.QueryRowContext(ctx, emailStatsByIdQuery, emailID), stats); err != nil {
+		return nil, errors.Wrap(err, "GetEmailStats.QueryRowContext")
+	}
+
+	if stats.Sent == 0 {
+		return nil, errors.Wrap(sql.ErrNoRows, "GetEmailStats")
+	}
+
+	return stats, nil
+}
+
+// Get engagement stats of all emails sent with a template
+func (e *EmailRepository) GetTemplateStats(ctx context.Context, template string) (*models.EmailStats, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailRepository.GetTemplateStats")
+	defer span.Finish()
+
+	stats := &models.EmailStats{Template: template}
+	if err := e.scanEmailStats(e.db.QueryRowContext(ctx, emailStatsByTemplateQuery, template), stats); err != nil {
+		return nil, errors.Wrap(err, "GetTemplateStats.QueryRowContext")
+	}
+
+	return stats, nil
+}
+
+func (e *EmailRepository) scanEmailStats(row *sql.Row, stats *models.EmailStats) error {
+	return row.Scan(
+		&stats.Sent,
+		&stats.Opens,
+		&stats.UniqueOpens,
+		&stats.Clicks,
+		&stats.UniqueClicks,
+	)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	idempotencyPrefix = "email_idempotency:"
+	reserveRetries    = 2 // the stored value can expire between SETNX and GET
+)
+
+// Email idempotency repository
+type idempotencyRepo struct {
+	redisClient *redis.Client
+	basePrefix  string
+}
+
+func NewIdempotencyRepository(redisClient *redis.Client) email.IdempotencyRepository {
+	return &idempotencyRepo{
+		redisClient: redisClient,
+		basePrefix:  idempotencyPrefix,
+	}
+}
+
+// Reserve key with the value for ttl, a replay gets the stored value and false
+func (r *idempotencyRepo) Reserve(ctx context.Context, key string, value string, ttl time.Duration) (string, bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRepo.Reserve")
+	defer span.Finish()
+
+	for range reserveRetries {
+		reserved, err := r.redisClient.SetNX(ctx, r.createKey(key), value, ttl).Result()
+		if err != nil {
+			return "", false, errors.WithMessage(err, "idempotencyRepo.Reserve.redisClient.SetNX")
+		}
+		if reserved {
+			return value, true, nil
+		}
+
+		stored, err := r.redisClient.Get(ctx, r.createKey(key)).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return "", false, errors.WithMessage(err, "idempotencyRepo.Reserve.redisClient.Get")
+		}
+		return stored, false, nil
+	}
+
+	return "", false, errors.Errorf("idempotency key %s expired while reserving", key)
+}
+
+// Release key so a failed request can be retried
+func (r *idempotencyRepo) Release(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRepo.Release")
+	defer span.Finish()
+
+	if err := r.redisClient.Del(ctx, r.createKey(key)).Err(); err != nil {
+		return errors.WithMessage(err, "idempotencyRepo.Release.redisClient.Del")
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepo) createKey(key string) string {
+	return fmt.Sprintf("%s%s", r.basePrefix, key)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func setupIdempotencyRepo() email.IdempotencyRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	return NewIdempotencyRepository(client)
+}
+
+func TestIdempotencyReserve(t *testing.T) {
+	t.Parallel()
+
+	repo := setupIdempotencyRepo()
+	ctx := context.Background()
+
+	t.Run("First reserve stores the value", func(t *testing.T) {
+		stored, reserved, err := repo.Reserve(ctx, "first", "email-1", time.Minute)
+		require.NoError(t, err)
+		require.True(t, reserved)
+		require.Equal(t, "email-1", stored)
+	})
+
+	t.Run("Replay returns the stored value", func(t *testing.T) {
+		_, _, err := repo.Reserve(ctx, "replay", "email-1", time.Minute)
+		require.NoError(t, err)
+
+		stored, reserved, err := repo.Reserve(ctx, "replay", "email-2", time.Minute)
+		require.NoError(t, err)
+		require.False(t, reserved)
+		require.Equal(t, "email-1", stored)
+	})
+
+	t.Run("Released key can be reserved again", func(t *testing.T) {
+		_, _, err := repo.Reserve(ctx, "released", "email-1", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, repo.Release(ctx, "released"))
+
+		stored, reserved, err := repo.Reserve(ctx, "released", "email-2", time.Minute)
+		require.NoError(t, err)
+		require.True(t, reserved)
+		require.Equal(t, "email-2", stored)
+	})
+}
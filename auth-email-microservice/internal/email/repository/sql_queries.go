@@ -2,16 +2,29 @@ package repository
 
 const (
 	createEmailQuery = `
-		INSERT INTO emails ("to", "from", subject, body, content_type) VALUES ($1, $2, $3, $4, $5) RETURNING email_id
+		INSERT INTO emails (email_id, "to", "from", subject, body, content_type, category, template) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING email_id
 	`
 	findEmailByIdQuery = `
-		SELECT email_id, "to", "from", subject, body, content_type, created_at FROM emails WHERE email_id = $1
+		SELECT email_id, "to", "from", subject, body, content_type, category, template, created_at FROM emails WHERE email_id = $1
 	`
 	findEmailsByReceiverQuery = `
-		SELECT email_id, "to", "from", subject, body, content_type, created_at 
+		SELECT email_id, "to", "from", subject, body, content_type, category, template, created_at 
 		FROM emails WHERE "to" ILIKE '%' || $1 || '%' ORDER BY created_at DESC LIMIT $2 OFFSET $3
 	`
 	totalCountQuery = `
 		SELECT COUNT (email_id) as totalCount FROM emails WHERE "to" ILIKE '%' || $1 || '%'
 	`
+	createEmailEventQuery = `
+		INSERT INTO email_events (email_id, event_type, url, user_agent, ip) VALUES ($1, $2, $3, $4, $5) RETURNING event_id, created_at
+	`
+	emailStatsQuery = `
+		SELECT COUNT(DISTINCT e.email_id) AS sent,
+			COUNT(ev.event_id) FILTER (WHERE ev.event_type = 'open') AS opens,
+			COUNT(DISTINCT ev.email_id) FILTER (WHERE ev.event_type = 'open') AS unique_opens,
+			COUNT(ev.event_id) FILTER (WHERE ev.event_type = 'click') AS clicks,
+			COUNT(DISTINCT ev.email_id) FILTER (WHERE ev.event_type = 'click') AS unique_clicks
+		FROM emails e LEFT JOIN email_events ev ON ev.email_id = e.email_id
+	`
+	emailStatsByIdQuery       = emailStatsQuery + `WHERE e.email_id = $1`
+	emailStatsByTemplateQuery = emailStatsQuery + `WHERE e.template = $1`
 )
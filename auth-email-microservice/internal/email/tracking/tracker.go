@@ -0,0 +1,80 @@
+package tracking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/google/uuid"
+)
+
+const (
+	OpenPath  = "/track/open/"
+	ClickPath = "/track/click/"
+)
+
+// Absolute http(s) links in href attributes, mailto and anchors are left as is
+var hrefRegexp = regexp.MustCompile(`(?i)(<a\s[^>]*?href\s*=\s*)(["'])(https?://[^"']+)(["'])`)
+
+// Email tracker, rewrites html bodies to signed tracking urls and verifies them on the way back
+type Tracker struct {
+	baseURL string
+	secret  []byte
+}
+
+// New email tracker
+func NewTracker(cfg *config.Config) *Tracker {
+	return &Tracker{
+		baseURL: strings.TrimRight(cfg.Tracking.BaseURL, "/"),
+		secret:  []byte(cfg.Tracking.SecretKey),
+	}
+}
+
+// Rewrite links to signed click redirects and append an open pixel
+func (t *Tracker) Rewrite(emailID uuid.UUID, body string) string {
+	body = hrefRegexp.ReplaceAllStringFunc(body, func(match string) string {
+		parts := hrefRegexp.FindStringSubmatch(match)
+		// The attribute value is html, &amp; separates the query parameters of the link
+		target := html.UnescapeString(parts[3])
+		return parts[1] + parts[2] + t.ClickURL(emailID, target) + parts[4]
+	})
+
+	pixel := fmt.Sprintf(`<img src="%s" width="1" height="1" alt="" style="display:none" />`, t.OpenURL(emailID))
+	if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+		return body[:i] + pixel + body[i:]
+	}
+	return body + pixel
+}
+
+// Signed open pixel url
+func (t *Tracker) OpenURL(emailID uuid.UUID) string {
+	return fmt.Sprintf("%s%s%s?sig=%s", t.baseURL, OpenPath, emailID, t.Sign(emailID, ""))
+}
+
+// Signed click redirect url
+func (t *Tracker) ClickURL(emailID uuid.UUID, target string) string {
+	query := url.Values{}
+	query.Set("url", target)
+	query.Set("sig", t.Sign(emailID, target))
+	return fmt.Sprintf("%s%s%s?%s", t.baseURL, ClickPath, emailID, query.Encode())
+}
+
+// Sign email id and target url, the target is empty for opens
+func (t *Tracker) Sign(emailID uuid.UUID, target string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(emailID.String()))
+	mac.Write([]byte{0})
+	mac.Write([]byte(target))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify signature, so the click endpoint can't be used as an open redirect
+func (t *Tracker) Verify(emailID uuid.UUID, target, signature string) bool {
+	return hmac.Equal([]byte(t.Sign(emailID, target)), []byte(signature))
+}
@@ -0,0 +1,75 @@
+package tracking
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker() *Tracker {
+	return NewTracker(&config.Config{Tracking: config.TrackingConfig{
+		BaseURL:   "http://localhost:5080/",
+		SecretKey: "secret",
+	}})
+}
+
+func TestTracker_Rewrite(t *testing.T) {
+	t.Parallel()
+
+	tracker := newTestTracker()
+	emailID := uuid.New()
+
+	body := `<html><body><a href="https://shop.com/p/1?a=1&b=2">Buy</a> <a href="mailto:help@shop.com">Help</a></body></html>`
+	rewritten := tracker.Rewrite(emailID, body)
+
+	require.NotContains(t, rewritten, `href="https://shop.com`)
+	require.Contains(t, rewritten, `href="mailto:help@shop.com"`)
+	require.Contains(t, rewritten, "http://localhost:5080"+ClickPath+emailID.String())
+	require.True(t, strings.HasSuffix(rewritten, `/></body></html>`))
+	require.Contains(t, rewritten, tracker.OpenURL(emailID))
+
+	start := strings.Index(rewritten, "http://localhost:5080"+ClickPath)
+	end := strings.Index(rewritten[start:], `"`)
+	clickURL, err := url.Parse(rewritten[start : start+end])
+	require.NoError(t, err)
+
+	target := clickURL.Query().Get("url")
+	require.Equal(t, "https://shop.com/p/1?a=1&b=2", target)
+	require.True(t, tracker.Verify(emailID, target, clickURL.Query().Get("sig")))
+}
+
+func TestTracker_RewriteEscapedLink(t *testing.T) {
+	t.Parallel()
+
+	tracker := newTestTracker()
+	emailID := uuid.New()
+
+	body := `<a href="https://shop.com/p/1?utm_source=email&amp;utm_medium=alert&amp;ref=a%26b">Buy</a>`
+	rewritten := tracker.Rewrite(emailID, body)
+
+	start := strings.Index(rewritten, "http://localhost:5080"+ClickPath)
+	end := strings.Index(rewritten[start:], `"`)
+	clickURL, err := url.Parse(rewritten[start : start+end])
+	require.NoError(t, err)
+
+	target := clickURL.Query().Get("url")
+	require.Equal(t, "https://shop.com/p/1?utm_source=email&utm_medium=alert&ref=a%26b", target)
+	require.True(t, tracker.Verify(emailID, target, clickURL.Query().Get("sig")))
+}
+
+func TestTracker_Verify(t *testing.T) {
+	t.Parallel()
+
+	tracker := newTestTracker()
+	emailID := uuid.New()
+
+	sig := tracker.Sign(emailID, "https://shop.com")
+	require.True(t, tracker.Verify(emailID, "https://shop.com", sig))
+	require.False(t, tracker.Verify(emailID, "https://evil.com", sig))
+	require.False(t, tracker.Verify(uuid.New(), "https://shop.com", sig))
+	require.False(t, tracker.Verify(emailID, "", sig))
+}
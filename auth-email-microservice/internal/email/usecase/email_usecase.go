@@ -2,10 +2,13 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/Chuuch/ecom-microservices/config"
 	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/email/tracking"
 	"github.com/Chuuch/ecom-microservices/internal/models"
 	"github.com/Chuuch/ecom-microservices/pkg/logger"
 	"github.com/Chuuch/ecom-microservices/pkg/utils"
@@ -23,16 +26,28 @@ type EmailUseCase struct {
 	mailer          email.Mailer
 	cfg             *config.Config
 	emailsPublisher email.EmailsPublisher
+	tracker         *tracking.Tracker
+	idempotencyRepo email.IdempotencyRepository
 }
 
-// NewEmailUseCase returns a new EmailUseCase
-func NewEmailUseCase(emailRepo email.EmailRepository, logger logger.Logger, mailer email.Mailer, cfg *config.Config, emailsPublisher email.EmailsPublisher) *EmailUseCase {
+// NewEmailUseCase returns a new EmailUseCase, tracker is nil when tracking is disabled
+func NewEmailUseCase(
+	emailRepo email.EmailRepository,
+	logger logger.Logger,
+	mailer email.Mailer,
+	cfg *config.Config,
+	emailsPublisher email.EmailsPublisher,
+	tracker *tracking.Tracker,
+	idempotencyRepo email.IdempotencyRepository,
+) *EmailUseCase {
 	return &EmailUseCase{
 		emailRepo:       emailRepo,
 		logger:          logger,
 		mailer:          mailer,
 		cfg:             cfg,
 		emailsPublisher: emailsPublisher,
+		tracker:         tracker,
+		idempotencyRepo: idempotencyRepo,
 	}
 }
 
@@ -48,14 +63,43 @@ func (e *EmailUseCase) SendEmail(ctx context.Context, delivery amqp.Delivery) er
 
 	mail.From = "daniel@skyeystudio.com"
 
+	// Messages published before confirms carried no email_id in the body
+	if mail.EmailID == uuid.Nil {
+		emailID, err := uuid.Parse(delivery.MessageId)
+		if err != nil {
+			emailID = uuid.New()
+		}
+		mail.EmailID = emailID
+	}
+
 	if err := utils.ValidateStruct(ctx, mail); err != nil {
 		return errors.Wrap(err, "utils.ValidateStruct")
 	}
 
-	// e.logger.Infof("Sending email: %+v", mail)
-	// if err := e.mailer.Send(ctx, mail); err != nil {
-	// 	return errors.Wrap(err, "mailer.Send")
-	// }
+	// A redelivered message with a key is sent once, the key is kept once the mailer accepted the email
+	if mail.IdempotencyKey != "" {
+		sentID, reserved, err := e.idempotencyRepo.Reserve(ctx, sendKey(mail.IdempotencyKey), mail.EmailID.String(), e.idempotencyTTL())
+		if err != nil {
+			return errors.Wrap(err, "idempotencyRepo.Reserve")
+		}
+		if !reserved {
+			// The delivery that sent it may have stopped before storing it
+			if err := e.storeSent(ctx, mail, sentID); err != nil {
+				return err
+			}
+			e.logger.Infof("Email %v already sent as %s, skipping", mail.EmailID, sentID)
+			return nil
+		}
+	}
+
+	if err := e.send(ctx, mail); err != nil {
+		if mail.IdempotencyKey != "" {
+			if err := e.idempotencyRepo.Release(ctx, sendKey(mail.IdempotencyKey)); err != nil {
+				e.logger.Errorf("idempotencyRepo.Release: %v", err)
+			}
+		}
+		return err
+	}
 
 	createdEmail, err := e.emailRepo.CreateEmail(ctx, mail)
 	if err != nil {
@@ -71,17 +115,107 @@ func (e *EmailUseCase) SendEmail(ctx context.Context, delivery amqp.Delivery) er
 	return nil
 }
 
-// Publish email
+// Store the email sent as sentID unless it is stored already, without sending it again
+func (e *EmailUseCase) storeSent(ctx context.Context, mail *models.Email, sentID string) error {
+	emailID, err := uuid.Parse(sentID)
+	if err != nil {
+		return errors.Wrap(err, "uuid.Parse")
+	}
+
+	_, err = e.emailRepo.FindEmailById(ctx, emailID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "emailRepo.FindEmailById")
+	}
+
+	e.logger.Infof("Storing email %v sent by an earlier delivery", emailID)
+	mail.EmailID = emailID
+	if _, err := e.emailRepo.CreateEmail(ctx, mail); err != nil {
+		return errors.Wrap(err, "emailRepo.CreateEmail")
+	}
+	return nil
+}
+
+// Send the email through the mailer, with tracking when enabled
+func (e *EmailUseCase) send(ctx context.Context, mail *models.Email) error {
+	// Only the sent copy is rewritten, the stored body keeps the original links
+	sent := mail
+	if e.tracker != nil && mail.Track && mail.ContentType == "text/html" {
+		tracked := *mail
+		tracked.Body = e.tracker.Rewrite(mail.EmailID, mail.Body)
+		sent = &tracked
+	}
+
+	e.logger.Infof("Sending email: %v", mail.EmailID)
+	if err := e.mailer.Send(ctx, sent); err != nil {
+		return errors.Wrap(err, "mailer.Send")
+	}
+
+	return nil
+}
+
+// Publish email, the email id is assigned here and used as the message id.
+// A replayed idempotency key gets the email id of the first publish and nothing is published again.
 func (e *EmailUseCase) PublishEmail(ctx context.Context, email *models.Email) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailUseCase.PublishEmail")
 	defer span.Finish()
 
+	if email.EmailID == uuid.Nil {
+		email.EmailID = uuid.New()
+	}
+
+	if email.IdempotencyKey != "" {
+		storedID, reserved, err := e.idempotencyRepo.Reserve(ctx, publishKey(email.IdempotencyKey), email.EmailID.String(), e.idempotencyTTL())
+		if err != nil {
+			return errors.Wrap(err, "idempotencyRepo.Reserve")
+		}
+		if !reserved {
+			emailID, err := uuid.Parse(storedID)
+			if err != nil {
+				return errors.Wrap(err, "uuid.Parse")
+			}
+			email.EmailID = emailID
+			return nil
+		}
+	}
+
+	if err := e.publish(ctx, email); err != nil {
+		if email.IdempotencyKey != "" {
+			if err := e.idempotencyRepo.Release(ctx, publishKey(email.IdempotencyKey)); err != nil {
+				e.logger.Errorf("idempotencyRepo.Release: %v", err)
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (e *EmailUseCase) publish(ctx context.Context, email *models.Email) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailUseCase.publish")
+	defer span.Finish()
+
+	span.LogFields(
+		log.String("email_id", email.EmailID.String()),
+	)
+
+	// The consumer stores the payload, it must carry the category it is routed by
+	if email.Category == "" {
+		email.Category = models.EmailCategoryTransactional
+	}
+
 	mailBytes, err := json.Marshal(email)
 	if err != nil {
 		return errors.Wrap(err, "json.Marshal")
 	}
 
-	return e.emailsPublisher.Publish(mailBytes, email.ContentType)
+	if err := e.emailsPublisher.Publish(ctx, email.Category, mailBytes, email.ContentType, email.EmailID.String()); err != nil {
+		return errors.Wrap(err, "emailsPublisher.Publish")
+	}
+
+	return nil
 }
 
 // FInd email by id
@@ -99,3 +233,49 @@ func (e *EmailUseCase) FindEmailsByReceiver(ctx context.Context, receiverEmail s
 
 	return e.emailRepo.FindEmailsByReceiver(ctx, receiverEmail, paginationQuery)
 }
+
+// Record open or click event
+func (e *EmailUseCase) RecordEmailEvent(ctx context.Context, event *models.EmailEvent) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailUseCase.RecordEmailEvent")
+	defer span.Finish()
+
+	if err := utils.ValidateStruct(ctx, event); err != nil {
+		return errors.Wrap(err, "utils.ValidateStruct")
+	}
+
+	if _, err := e.emailRepo.CreateEmailEvent(ctx, event); err != nil {
+		return errors.Wrap(err, "emailRepo.CreateEmailEvent")
+	}
+
+	return nil
+}
+
+// Get engagement stats of a single email
+func (e *EmailUseCase) GetEmailStats(ctx context.Context, emailID uuid.UUID) (*models.EmailStats, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailUseCase.GetEmailStats")
+	defer span.Finish()
+
+	return e.emailRepo.GetEmailStats(ctx, emailID)
+}
+
+// Get engagement stats of all emails sent with a template
+func (e *EmailUseCase) GetTemplateStats(ctx context.Context, template string) (*models.EmailStats, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailUseCase.GetTemplateStats")
+	defer span.Finish()
+
+	return e.emailRepo.GetTemplateStats(ctx, template)
+}
+
+func (e *EmailUseCase) idempotencyTTL() time.Duration {
+	return e.cfg.Idempotency.TTL * time.Second
+}
+
+// Idempotency key of a published email, the value is the email id
+func publishKey(idempotencyKey string) string {
+	return "publish:" + idempotencyKey
+}
+
+// Idempotency key of a sent email, the value is the email id
+func sendKey(idempotencyKey string) string {
+	return "send:" + idempotencyKey
+}
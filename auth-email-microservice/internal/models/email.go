@@ -10,15 +10,24 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	EmailCategoryTransactional = "transactional" // password resets, order confirmations
+	EmailCategoryBulk          = "bulk"          // marketing and newsletters
+)
+
 // Email model
 type Email struct {
-	EmailID     uuid.UUID `json:"email_id" db:"email_id" validate:"omitempty"`
-	To          []string  `json:"to" db:"to" validate:"required"`
-	From        string    `json:"from" db:"from" validate:"required,email"`
-	Body        string    `json:"body" db:"body" validate:"required"`
-	Subject     string    `json:"subject" db:"subject" validate:"required"`
-	ContentType string    `json:"content_type" db:"content_type" validate:"required"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at" validate:"omitempty"`
+	EmailID        uuid.UUID `json:"email_id" db:"email_id" validate:"omitempty"`
+	To             []string  `json:"to" db:"to" validate:"required"`
+	From           string    `json:"from" db:"from" validate:"required,email"`
+	Body           string    `json:"body" db:"body" validate:"required"`
+	Subject        string    `json:"subject" db:"subject" validate:"required"`
+	ContentType    string    `json:"content_type" db:"content_type" validate:"required"`
+	Category       string    `json:"category" db:"category" validate:"omitempty,oneof=transactional bulk"`
+	Template       string    `json:"template" db:"template" validate:"omitempty,max=64"`
+	Track          bool      `json:"track" db:"-"`
+	IdempotencyKey string    `json:"idempotency_key,omitempty" db:"-" validate:"omitempty,max=255"` // repeated sends with the same key send once
+	CreatedAt      time.Time `json:"created_at" db:"created_at" validate:"omitempty"`
 }
 
 // Get string from addresses
@@ -41,6 +50,9 @@ func (e *Email) PrepareAndValidate(ctx context.Context) error {
 		mail = strings.TrimSpace(strings.ToLower(mail))
 	}
 	e.ContentType = "text/html"
+	if e.Category == "" {
+		e.Category = EmailCategoryTransactional
+	}
 
 	return utils.ValidateStruct(ctx, e)
 }
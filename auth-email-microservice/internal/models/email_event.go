@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EmailEventOpen  = "open"
+	EmailEventClick = "click"
+)
+
+// Email engagement event recorded by the tracking endpoint
+type EmailEvent struct {
+	EventID   uuid.UUID `json:"event_id" db:"event_id"`
+	EmailID   uuid.UUID `json:"email_id" db:"email_id" validate:"required"`
+	EventType string    `json:"event_type" db:"event_type" validate:"required,oneof=open click"`
+	URL       string    `json:"url" db:"url"`
+	UserAgent string    `json:"user_agent" db:"user_agent"`
+	IP        string    `json:"ip" db:"ip"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Aggregate engagement stats of a single email or of all emails of a template
+type EmailStats struct {
+	EmailID      uuid.UUID `json:"email_id,omitempty"`
+	Template     string    `json:"template,omitempty"`
+	Sent         uint64    `json:"sent"`
+	Opens        uint64    `json:"opens"`
+	UniqueOpens  uint64    `json:"unique_opens"`
+	Clicks       uint64    `json:"clicks"`
+	UniqueClicks uint64    `json:"unique_clicks"`
+}
+
+// Share of sent emails opened at least once
+func (s *EmailStats) GetOpenRate() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+	return float64(s.UniqueOpens) / float64(s.Sent)
+}
+
+// Share of sent emails clicked at least once
+func (s *EmailStats) GetClickRate() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+	return float64(s.UniqueClicks) / float64(s.Sent)
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+	NotificationChannelPush  = "push"
+
+	NotificationStatusQueued = "queued"
+	NotificationStatusSent   = "sent"
+	NotificationStatusFailed = "failed"
+
+	MessageTypeEmail        = "email"        // amqp message type of models.Email bodies
+	MessageTypeNotification = "notification" // amqp message type of models.Notification bodies
+)
+
+// Notification model, a single message to a user over one channel
+type Notification struct {
+	NotificationID uuid.UUID `json:"notification_id" db:"notification_id" validate:"omitempty"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id" validate:"required"`
+	Channel        string    `json:"channel" db:"channel" validate:"required,oneof=email sms push"`
+	Recipient      string    `json:"recipient" db:"recipient" validate:"required"`
+	Subject        string    `json:"subject" db:"subject"`
+	Body           string    `json:"body" db:"body" validate:"required"`
+	Category       string    `json:"category" db:"category" validate:"omitempty,oneof=transactional bulk"`
+	Status         string    `json:"status" db:"status"`
+	Error          string    `json:"error" db:"error"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Notification preferences of a user, channels are tried in order
+type NotificationPreferences struct {
+	UserID      uuid.UUID `json:"user_id" db:"user_id" validate:"required"`
+	Channels    []string  `json:"channels" db:"channels" validate:"dive,oneof=email sms push"`
+	PhoneNumber string    `json:"phone_number" db:"phone_number" validate:"omitempty,e164"`
+	DeviceToken string    `json:"device_token" db:"device_token"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Default preferences for users that never set any
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:   userID,
+		Channels: []string{NotificationChannelEmail},
+	}
+}
+
+// Get string from channels
+func (p *NotificationPreferences) GetChannelsString() string {
+	return strings.Join(p.Channels, ", ")
+}
+
+// Set channels from string value
+func (p *NotificationPreferences) SetChannelsFromString(channels string) {
+	p.Channels = strings.Split(channels, ", ")
+}
+
+// Get recipient address of the channel, empty when the user has none
+func (p *NotificationPreferences) GetRecipient(channel string, user *User) string {
+	switch channel {
+	case NotificationChannelEmail:
+		return user.Email
+	case NotificationChannelSMS:
+		return p.PhoneNumber
+	case NotificationChannelPush:
+		return p.DeviceToken
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/internal/notification"
+	grpcerrors "github.com/Chuuch/ecom-microservices/pkg/grpc_errors"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	userService "github.com/Chuuch/ecom-microservices/proto"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Notification gRPC microservice
+type NotificationMicroservice struct {
+	userService.UnimplementedNotificationServiceServer
+	notificationUC notification.NotificationUseCase
+	cfg            *config.Config
+	logger         logger.Logger
+}
+
+// Notification gRPC microservice constructor
+func NewNotificationMicroservice(notificationUC notification.NotificationUseCase, cfg *config.Config, logger logger.Logger) *NotificationMicroservice {
+	return &NotificationMicroservice{
+		notificationUC: notificationUC,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+// Send notification
+func (n *NotificationMicroservice) SendNotification(ctx context.Context, req *userService.SendNotificationRequest) (*userService.SendNotificationResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationMicroservice.SendNotification")
+	defer span.Finish()
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		n.logger.Errorf("uuid.Parse: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "uuid.Parse: %v", err)
+	}
+
+	created, err := n.notificationUC.SendNotification(ctx, &models.Notification{
+		UserID:   userID,
+		Channel:  req.GetChannel(),
+		Subject:  req.GetSubject(),
+		Body:     req.GetBody(),
+		Category: req.GetCategory(),
+	})
+	if err != nil {
+		n.logger.Errorf("notificationUC.SendNotification: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "notificationUC.SendNotification: %v", err)
+	}
+
+	return &userService.SendNotificationResponse{Notification: n.convertNotificationToProto(created)}, nil
+}
+
+// Find notification by id
+func (n *NotificationMicroservice) FindNotificationById(ctx context.Context, req *userService.FindNotificationByIdRequest) (*userService.FindNotificationByIdResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationMicroservice.FindNotificationById")
+	defer span.Finish()
+
+	notificationID, err := uuid.Parse(req.GetNotificationId())
+	if err != nil {
+		n.logger.Errorf("uuid.Parse: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "uuid.Parse: %v", err)
+	}
+
+	found, err := n.notificationUC.FindNotificationByID(ctx, notificationID)
+	if err != nil {
+		n.logger.Errorf("notificationUC.FindNotificationByID: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "notificationUC.FindNotificationByID: %v", err)
+	}
+
+	return &userService.FindNotificationByIdResponse{Notification: n.convertNotificationToProto(found)}, nil
+}
+
+// Get notification preferences
+func (n *NotificationMicroservice) GetNotificationPreferences(ctx context.Context, req *userService.GetNotificationPreferencesRequest) (*userService.GetNotificationPreferencesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationMicroservice.GetNotificationPreferences")
+	defer span.Finish()
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		n.logger.Errorf("uuid.Parse: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "uuid.Parse: %v", err)
+	}
+
+	preferences, err := n.notificationUC.GetPreferences(ctx, userID)
+	if err != nil {
+		n.logger.Errorf("notificationUC.GetPreferences: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "notificationUC.GetPreferences: %v", err)
+	}
+
+	return &userService.GetNotificationPreferencesResponse{Preferences: n.convertPreferencesToProto(preferences)}, nil
+}
+
+// Update notification preferences
+func (n *NotificationMicroservice) UpdateNotificationPreferences(ctx context.Context, req *userService.UpdateNotificationPreferencesRequest) (*userService.UpdateNotificationPreferencesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationMicroservice.UpdateNotificationPreferences")
+	defer span.Finish()
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		n.logger.Errorf("uuid.Parse: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "uuid.Parse: %v", err)
+	}
+
+	preferences, err := n.notificationUC.UpdatePreferences(ctx, &models.NotificationPreferences{
+		UserID:      userID,
+		Channels:    req.GetChannels(),
+		PhoneNumber: req.GetPhoneNumber(),
+		DeviceToken: req.GetDeviceToken(),
+	})
+	if err != nil {
+		n.logger.Errorf("notificationUC.UpdatePreferences: %v", err)
+		return nil, status.Errorf(grpcerrors.ParseGRPCError(err), "notificationUC.UpdatePreferences: %v", err)
+	}
+
+	return &userService.UpdateNotificationPreferencesResponse{Preferences: n.convertPreferencesToProto(preferences)}, nil
+}
+
+func (n *NotificationMicroservice) convertNotificationToProto(notification *models.Notification) *userService.Notification {
+	return &userService.Notification{
+		NotificationId: notification.NotificationID.String(),
+		UserId:         notification.UserID.String(),
+		Channel:        notification.Channel,
+		Recipient:      notification.Recipient,
+		Subject:        notification.Subject,
+		Body:           notification.Body,
+		Category:       notification.Category,
+		Status:         notification.Status,
+		Error:          notification.Error,
+		CreatedAt:      timestamppb.New(notification.CreatedAt),
+		UpdatedAt:      timestamppb.New(notification.UpdatedAt),
+	}
+}
+
+func (n *NotificationMicroservice) convertPreferencesToProto(preferences *models.NotificationPreferences) *userService.NotificationPreferences {
+	return &userService.NotificationPreferences{
+		UserId:      preferences.UserID.String(),
+		Channels:    preferences.Channels,
+		PhoneNumber: preferences.PhoneNumber,
+		DeviceToken: preferences.DeviceToken,
+		UpdatedAt:   timestamppb.New(preferences.UpdatedAt),
+	}
+}
@@ -0,0 +1,13 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+)
+
+// Notifier interface, one provider per channel
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, notification *models.Notification) error
+}
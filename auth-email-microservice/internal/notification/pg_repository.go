@@ -0,0 +1,17 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/google/uuid"
+)
+
+// Notification pg repository interface
+type NotificationPGRepository interface {
+	CreateNotification(ctx context.Context, notification *models.Notification) (*models.Notification, error)
+	UpdateStatus(ctx context.Context, notificationID uuid.UUID, status, errMsg string) error
+	FindNotificationByID(ctx context.Context, notificationID uuid.UUID) (*models.Notification, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/opentracing/opentracing-go"
+)
+
+// Email notifier, sends notifications through the email mailer
+type EmailNotifier struct {
+	mailer email.Mailer
+}
+
+// New email notifier
+func NewEmailNotifier(mailer email.Mailer) *EmailNotifier {
+	return &EmailNotifier{mailer: mailer}
+}
+
+func (n *EmailNotifier) Channel() string {
+	return models.NotificationChannelEmail
+}
+
+func (n *EmailNotifier) Send(ctx context.Context, notification *models.Notification) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EmailNotifier.Send")
+	defer span.Finish()
+
+	return n.mailer.Send(ctx, &models.Email{
+		EmailID:     notification.NotificationID,
+		From:        "daniel@skyeystudio.com",
+		To:          []string{notification.Recipient},
+		Subject:     notification.Subject,
+		Body:        notification.Body,
+		ContentType: "text/html",
+		Category:    notification.Category,
+	})
+}
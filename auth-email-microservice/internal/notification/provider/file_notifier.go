@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/pkg/errors"
+)
+
+// File notifier, local stand-in that appends notifications to <dir>/<channel>.jsonl
+type FileNotifier struct {
+	mu      sync.Mutex
+	channel string
+	path    string
+}
+
+// New file notifier
+func NewFileNotifier(channel, dir string) (*FileNotifier, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "os.MkdirAll")
+	}
+
+	return &FileNotifier{
+		channel: channel,
+		path:    filepath.Join(dir, channel+".jsonl"),
+	}, nil
+}
+
+func (n *FileNotifier) Channel() string {
+	return n.channel
+}
+
+func (n *FileNotifier) Send(ctx context.Context, notification *models.Notification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "os.OpenFile")
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "f.Write")
+	}
+
+	return nil
+}
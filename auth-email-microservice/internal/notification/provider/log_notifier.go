@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+)
+
+// Log notifier, local stand-in that only logs the notification
+type LogNotifier struct {
+	channel string
+	logger  logger.Logger
+}
+
+// New log notifier
+func NewLogNotifier(channel string, logger logger.Logger) *LogNotifier {
+	return &LogNotifier{
+		channel: channel,
+		logger:  logger,
+	}
+}
+
+func (n *LogNotifier) Channel() string {
+	return n.channel
+}
+
+func (n *LogNotifier) Send(ctx context.Context, notification *models.Notification) error {
+	n.logger.Infof(
+		"Notification %s, channel: %s, recipient: %s, subject: %s, body: %s",
+		notification.NotificationID,
+		n.channel,
+		notification.Recipient,
+		notification.Subject,
+		notification.Body,
+	)
+	return nil
+}
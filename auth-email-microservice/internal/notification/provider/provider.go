@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/internal/notification"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+)
+
+const (
+	providerLog  = "log"
+	providerFile = "file"
+)
+
+// Create notifiers for all channels, email always goes through the mailer
+func NewNotifiers(cfg *config.Config, logger logger.Logger, mailer email.Mailer) (map[string]notification.Notifier, error) {
+	sms, err := newStandInNotifier(models.NotificationChannelSMS, cfg.Notification.SMSProvider, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	push, err := newStandInNotifier(models.NotificationChannelPush, cfg.Notification.PushProvider, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]notification.Notifier{
+		models.NotificationChannelEmail: NewEmailNotifier(mailer),
+		models.NotificationChannelSMS:   sms,
+		models.NotificationChannelPush:  push,
+	}, nil
+}
+
+func newStandInNotifier(channel, provider string, cfg *config.Config, logger logger.Logger) (notification.Notifier, error) {
+	switch provider {
+	case providerLog, "":
+		return NewLogNotifier(channel, logger), nil
+	case providerFile:
+		return NewFileNotifier(channel, cfg.Notification.OutboxDir)
+	}
+	return nil, fmt.Errorf("unknown %s provider: %s", channel, provider)
+}
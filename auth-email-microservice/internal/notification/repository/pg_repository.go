@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// Notification repository
+type NotificationPGRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPGRepository(db *sqlx.DB) *NotificationPGRepository {
+	return &NotificationPGRepository{db: db}
+}
+
+func (n *NotificationPGRepository) CreateNotification(ctx context.Context, notification *models.Notification) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationPGRepository.CreateNotification")
+	defer span.Finish()
+
+	if err := n.db.QueryRowContext(
+		ctx,
+		createNotificationQuery,
+		notification.NotificationID,
+		notification.UserID,
+		notification.Channel,
+		notification.Recipient,
+		notification.Subject,
+		notification.Body,
+		notification.Category,
+		notification.Status,
+	).Scan(&notification.CreatedAt, &notification.UpdatedAt); err != nil {
+		return nil, errors.Wrap(err, "CreateNotification.QueryRowContext")
+	}
+
+	return notification, nil
+}
+
+func (n *NotificationPGRepository) UpdateStatus(ctx context.Context, notificationID uuid.UUID, status, errMsg string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationPGRepository.UpdateStatus")
+	defer span.Finish()
+
+	if _, err := n.db.ExecContext(ctx, updateNotificationStatusQuery, notificationID, status, errMsg); err != nil {
+		return errors.Wrap(err, "UpdateStatus.ExecContext")
+	}
+
+	return nil
+}
+
+func (n *NotificationPGRepository) FindNotificationByID(ctx context.Context, notificationID uuid.UUID) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationPGRepository.FindNotificationByID")
+	defer span.Finish()
+
+	notification := &models.Notification{}
+	if err := n.db.GetContext(ctx, notification, findNotificationByIdQuery, notificationID); err != nil {
+		return nil, errors.Wrap(err, "FindNotificationByID.GetContext")
+	}
+
+	return notification, nil
+}
+
+func (n *NotificationPGRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationPGRepository.GetPreferences")
+	defer span.Finish()
+
+	var channels string
+	preferences := &models.NotificationPreferences{}
+	if err := n.db.QueryRowContext(ctx, getPreferencesQuery, userID).Scan(
+		&preferences.UserID,
+		&channels,
+		&preferences.PhoneNumber,
+		&preferences.DeviceToken,
+		&preferences.UpdatedAt,
+	); err != nil {
+		return nil, errors.Wrap(err, "GetPreferences.QueryRowContext")
+	}
+
+	preferences.SetChannelsFromString(channels)
+	return preferences, nil
+}
+
+func (n *NotificationPGRepository) UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationPGRepository.UpsertPreferences")
+	defer span.Finish()
+
+	if err := n.db.QueryRowContext(
+		ctx,
+		upsertPreferencesQuery,
+		preferences.UserID,
+		preferences.GetChannelsString(),
+		preferences.PhoneNumber,
+		preferences.DeviceToken,
+	).Scan(&preferences.UpdatedAt); err != nil {
+		return nil, errors.Wrap(err, "UpsertPreferences.QueryRowContext")
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPGRepository_CreateNotification(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	notificationPGRepository := NewNotificationPGRepository(sqlxDB)
+
+	mockNotification := &models.Notification{
+		NotificationID: uuid.New(),
+		UserID:         uuid.New(),
+		Channel:        models.NotificationChannelSMS,
+		Recipient:      "+359888123456",
+		Body:           "Your order has shipped",
+		Category:       models.EmailCategoryTransactional,
+		Status:         models.NotificationStatusQueued,
+	}
+	now := time.Now()
+
+	mock.ExpectQuery(createNotificationQuery).WithArgs(
+		mockNotification.NotificationID,
+		mockNotification.UserID,
+		mockNotification.Channel,
+		mockNotification.Recipient,
+		mockNotification.Subject,
+		mockNotification.Body,
+		mockNotification.Category,
+		mockNotification.Status,
+	).WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+
+	created, err := notificationPGRepository.CreateNotification(context.Background(), mockNotification)
+	require.NoError(t, err)
+	require.Equal(t, now, created.CreatedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationPGRepository_GetPreferences(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	notificationPGRepository := NewNotificationPGRepository(sqlxDB)
+
+	userID := uuid.New()
+	columns := []string{"user_id", "channels", "phone_number", "device_token", "updated_at"}
+	mock.ExpectQuery(getPreferencesQuery).WithArgs(userID).WillReturnRows(
+		sqlmock.NewRows(columns).AddRow(userID, "push, sms, email", "+359888123456", "token", time.Now()),
+	)
+
+	preferences, err := notificationPGRepository.GetPreferences(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"push", "sms", "email"}, preferences.Channels)
+
+	missingID := uuid.New()
+	mock.ExpectQuery(getPreferencesQuery).WithArgs(missingID).WillReturnError(sql.ErrNoRows)
+
+	_, err = notificationPGRepository.GetPreferences(context.Background(), missingID)
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,23 @@
+package repository
+
+const (
+	createNotificationQuery = `
+		INSERT INTO notifications (notification_id, user_id, channel, recipient, subject, body, category, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING created_at, updated_at
+	`
+	updateNotificationStatusQuery = `
+		UPDATE notifications SET status = $2, error = $3, updated_at = NOW() WHERE notification_id = $1
+	`
+	findNotificationByIdQuery = `
+		SELECT notification_id, user_id, channel, recipient, subject, body, category, status, error, created_at, updated_at
+		FROM notifications WHERE notification_id = $1
+	`
+	getPreferencesQuery = `
+		SELECT user_id, channels, phone_number, device_token, updated_at FROM notification_preferences WHERE user_id = $1
+	`
+	upsertPreferencesQuery = `
+		INSERT INTO notification_preferences (user_id, channels, phone_number, device_token) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET channels = $2, phone_number = $3, device_token = $4, updated_at = NOW()
+		RETURNING updated_at
+	`
+)
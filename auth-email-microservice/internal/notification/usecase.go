@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+)
+
+// Notification usecase interface
+type NotificationUseCase interface {
+	SendNotification(ctx context.Context, notification *models.Notification) (*models.Notification, error)
+	DeliverNotification(ctx context.Context, delivery amqp.Delivery) error
+	FindNotificationByID(ctx context.Context, notificationID uuid.UUID) (*models.Notification, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/Chuuch/ecom-microservices/config"
+	"github.com/Chuuch/ecom-microservices/internal/email"
+	"github.com/Chuuch/ecom-microservices/internal/models"
+	"github.com/Chuuch/ecom-microservices/internal/notification"
+	"github.com/Chuuch/ecom-microservices/internal/user"
+	grpcerrors "github.com/Chuuch/ecom-microservices/pkg/grpc_errors"
+	"github.com/Chuuch/ecom-microservices/pkg/logger"
+	"github.com/Chuuch/ecom-microservices/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/streadway/amqp"
+)
+
+// Notification UseCase
+type NotificationUseCase struct {
+	notificationRepo notification.NotificationPGRepository
+	userUC           user.UserUseCase
+	notifiers        map[string]notification.Notifier
+	publisher        email.EmailsPublisher
+	logger           logger.Logger
+	cfg              *config.Config
+}
+
+// NewNotificationUseCase returns a new NotificationUseCase
+func NewNotificationUseCase(
+	notificationRepo notification.NotificationPGRepository,
+	userUC user.UserUseCase,
+	notifiers map[string]notification.Notifier,
+	publisher email.EmailsPublisher,
+	logger logger.Logger,
+	cfg *config.Config,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+		userUC:           userUC,
+		notifiers:        notifiers,
+		publisher:        publisher,
+		logger:           logger,
+		cfg:              cfg,
+	}
+}
+
+// Resolve the channel from the user preferences, persist the notification and queue it for delivery
+func (n *NotificationUseCase) SendNotification(ctx context.Context, notification *models.Notification) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.SendNotification")
+	defer span.Finish()
+
+	foundUser, err := n.userUC.FindByID(ctx, notification.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "userUC.FindByID")
+	}
+
+	preferences, err := n.GetPreferences(ctx, notification.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, recipient, err := n.resolveChannel(notification.Channel, preferences, foundUser)
+	if err != nil {
+		return nil, errors.Wrapf(err, "user_id: %s", notification.UserID)
+	}
+
+	notification.NotificationID = uuid.New()
+	notification.Channel = channel
+	notification.Recipient = recipient
+	notification.Status = models.NotificationStatusQueued
+	if notification.Category == "" {
+		notification.Category = models.EmailCategoryTransactional
+	}
+
+	if err := utils.ValidateStruct(ctx, notification); err != nil {
+		return nil, errors.Wrap(err, "utils.ValidateStruct")
+	}
+
+	created, err := n.notificationRepo.CreateNotification(ctx, notification)
+	if err != nil {
+		return nil, errors.Wrap(err, "notificationRepo.CreateNotification")
+	}
+
+	span.LogFields(
+		log.String("notification_id", created.NotificationID.String()),
+		log.String("channel", created.Channel),
+	)
+
+	notificationBytes, err := json.Marshal(created)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	if err := n.publisher.PublishMessage(
+		ctx,
+		models.MessageTypeNotification,
+		created.Category,
+		notificationBytes,
+		"application/json",
+		created.NotificationID.String(),
+	); err != nil {
+		if err := n.notificationRepo.UpdateStatus(ctx, created.NotificationID, models.NotificationStatusFailed, err.Error()); err != nil {
+			n.logger.Errorf("notificationRepo.UpdateStatus: %v", err)
+		}
+		return nil, errors.Wrap(err, "publisher.PublishMessage")
+	}
+
+	return created, nil
+}
+
+// Deliver queued notification through the provider of its channel
+func (n *NotificationUseCase) DeliverNotification(ctx context.Context, delivery amqp.Delivery) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.DeliverNotification")
+	defer span.Finish()
+
+	notification := &models.Notification{}
+	if err := json.Unmarshal(delivery.Body, notification); err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+
+	notifier, ok := n.notifiers[notification.Channel]
+	if !ok {
+		return errors.Errorf("no notifier for channel: %s", notification.Channel)
+	}
+
+	n.logger.Infof("Delivering notification: %v, channel: %s", notification.NotificationID, notification.Channel)
+	if err := notifier.Send(ctx, notification); err != nil {
+		// Throttled notifications are requeued and stay queued
+		if errors.Is(err, grpcerrors.ErrSendThrottled) {
+			return err
+		}
+		if err := n.notificationRepo.UpdateStatus(ctx, notification.NotificationID, models.NotificationStatusFailed, err.Error()); err != nil {
+			n.logger.Errorf("notificationRepo.UpdateStatus: %v", err)
+		}
+		return errors.Wrap(err, "notifier.Send")
+	}
+
+	if err := n.notificationRepo.UpdateStatus(ctx, notification.NotificationID, models.NotificationStatusSent, ""); err != nil {
+		return errors.Wrap(err, "notificationRepo.UpdateStatus")
+	}
+
+	n.logger.Infof("Notification sent successfully: %v", notification.NotificationID)
+
+	return nil
+}
+
+// Find notification by id
+func (n *NotificationUseCase) FindNotificationByID(ctx context.Context, notificationID uuid.UUID) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.FindNotificationByID")
+	defer span.Finish()
+
+	return n.notificationRepo.FindNotificationByID(ctx, notificationID)
+}
+
+// Get user preferences, users that never set any get the defaults
+func (n *NotificationUseCase) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.GetPreferences")
+	defer span.Finish()
+
+	preferences, err := n.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, errors.Wrap(err, "notificationRepo.GetPreferences")
+	}
+
+	return preferences, nil
+}
+
+// Update user preferences
+func (n *NotificationUseCase) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.UpdatePreferences")
+	defer span.Finish()
+
+	if len(preferences.Channels) == 0 {
+		preferences.Channels = models.DefaultNotificationPreferences(preferences.UserID).Channels
+	}
+
+	if err := utils.ValidateStruct(ctx, preferences); err != nil {
+		return nil, errors.Wrap(err, "utils.ValidateStruct")
+	}
+
+	if _, err := n.userUC.FindByID(ctx, preferences.UserID); err != nil {
+		return nil, errors.Wrap(err, "userUC.FindByID")
+	}
+
+	return n.notificationRepo.UpsertPreferences(ctx, preferences)
+}
+
+// Pick the requested channel, else the first preferred channel the user can be reached on, else email.
+// A requested channel without a notifier is rejected instead of falling back.
+func (n *NotificationUseCase) resolveChannel(requested string, preferences *models.NotificationPreferences, user *models.User) (string, string, error) {
+	candidates := append([]string{}, preferences.Channels...)
+	if requested != "" {
+		if _, ok := n.notifiers[requested]; !ok {
+			return "", "", errors.Wrapf(grpcerrors.ErrUnknownNotificationChannel, "channel: %s", requested)
+		}
+		candidates = []string{requested}
+	}
+	candidates = append(candidates, models.NotificationChannelEmail)
+
+	for _, channel := range candidates {
+		if _, ok := n.notifiers[channel]; !ok {
+			continue
+		}
+		if recipient := preferences.GetRecipient(channel, user); recipient != "" {
+			return channel, recipient, nil
+		}
+	}
+
+	return "", "", grpcerrors.ErrNoNotificationChannel
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/Chuuch/ecom-microservices/pkg/logger"
 	"github.com/Chuuch/ecom-microservices/pkg/metric"
 	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
 	"github.com/resend/resend-go/v2"
@@ -31,12 +32,26 @@ import (
 	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcPrometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 
+	"github.com/Chuuch/ecom-microservices/internal/bridge"
+	bridgeKafka "github.com/Chuuch/ecom-microservices/internal/bridge/delivery/kafka"
+	bridgeRepository "github.com/Chuuch/ecom-microservices/internal/bridge/repository"
+	bridgeUseCase "github.com/Chuuch/ecom-microservices/internal/bridge/usecase"
+
+	"github.com/Chuuch/ecom-microservices/internal/email"
 	emailServerGRPC "github.com/Chuuch/ecom-microservices/internal/email/delivery/grpc"
+	emailHttp "github.com/Chuuch/ecom-microservices/internal/email/delivery/http"
 
 	"github.com/Chuuch/ecom-microservices/internal/email/delivery/rabbitmq"
 	"github.com/Chuuch/ecom-microservices/internal/email/mailer"
+	"github.com/Chuuch/ecom-microservices/internal/email/ratelimit"
 	emailRepository "github.com/Chuuch/ecom-microservices/internal/email/repository"
+	"github.com/Chuuch/ecom-microservices/internal/email/tracking"
 	emailUseCase "github.com/Chuuch/ecom-microservices/internal/email/usecase"
+
+	notificationServerGRPC "github.com/Chuuch/ecom-microservices/internal/notification/delivery/grpc"
+	"github.com/Chuuch/ecom-microservices/internal/notification/provider"
+	notificationRepository "github.com/Chuuch/ecom-microservices/internal/notification/repository"
+	notificationUseCase "github.com/Chuuch/ecom-microservices/internal/notification/usecase"
 )
 
 // GRPC Auth Server
@@ -82,33 +97,86 @@ func (s *Server) Start() error {
 
 	// Email
 	emailRepo := emailRepository.NewEmailRepository(s.db)
-	mailDialer := mailer.NewMailer(s.resendClient)
+	// Emails and email notifications share the mailer, the switch and the limits apply to both
+	var mailDialer email.Mailer = mailer.NewMailer(s.resendClient)
+	if !s.cfg.Resend.SendEnabled {
+		mailDialer = mailer.NewDisabledMailer(s.logger)
+		s.logger.Info("Sending disabled, emails are not handed to the provider")
+	}
+	if s.cfg.RateLimit.Enabled {
+		mailDialer = mailer.NewThrottledMailer(mailDialer, s.newRateLimiter(), s.cfg)
+		s.logger.Infof("Mailer rate limit enabled, backend: %s", s.cfg.RateLimit.Backend)
+	}
 
 	emailsPublisher, err := rabbitmq.NewEmailsPublisher(s.cfg, s.logger)
 	if err != nil {
 		return err
 	}
 	defer emailsPublisher.CloseMessagesChannel()
+
+	// Mandatory publishing needs the lane queues bound before the first message
+	emailLanes := rabbitmq.GetEmailLanes(s.cfg)
+	for _, lane := range emailLanes {
+		if err := emailsPublisher.SetupExchangeAndQueue(
+			s.cfg.RabbitMQ.Exchange,
+			lane.Queue,
+			lane.RoutingKey,
+			lane.ConsumerTag,
+		); err != nil {
+			return err
+		}
+	}
 	s.logger.Info("Emails publisher initialized")
 
-	emailUC := emailUseCase.NewEmailUseCase(emailRepo, s.logger, mailDialer, s.cfg, emailsPublisher)
-	emailAmqpConsumer := rabbitmq.NewEmailConsumer(s.amqpConn, s.logger, emailUC)
+	var tracker *tracking.Tracker
+	if s.cfg.Tracking.Enabled {
+		tracker = tracking.NewTracker(s.cfg)
+	}
+
+	emailUC := emailUseCase.NewEmailUseCase(emailRepo, s.logger, mailDialer, s.cfg, emailsPublisher, tracker, emailRepository.NewIdempotencyRepository(s.redis))
+
+	// Notification
+	notifiers, err := provider.NewNotifiers(s.cfg, s.logger, mailDialer)
+	if err != nil {
+		return err
+	}
+	notificationRepo := notificationRepository.NewNotificationPGRepository(s.db)
+	notificationUC := notificationUseCase.NewNotificationUseCase(notificationRepo, userUC, notifiers, emailsPublisher, s.logger, s.cfg)
+
+	emailAmqpConsumer := rabbitmq.NewEmailConsumer(s.amqpConn, s.logger, emailUC, notificationUC)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go func() {
-		err := emailAmqpConsumer.StartConsumer(
-			s.cfg.RabbitMQ.WorkerPoolSize,
-			s.cfg.RabbitMQ.Exchange,
-			s.cfg.RabbitMQ.Queue,
-			s.cfg.RabbitMQ.RoutingKey,
-			s.cfg.RabbitMQ.ConsumerTag,
-		)
+	for _, lane := range emailLanes {
+		go func() {
+			if err := emailAmqpConsumer.StartConsumer(s.cfg.RabbitMQ.Exchange, lane); err != nil {
+				s.logger.Errorf("emailAmqpConsumer.StartConsumer, lane: %s: %v", lane.Category, err)
+			}
+		}()
+	}
+
+	// Kafka to email bridge
+	if s.cfg.Bridge.Enabled {
+		bridgeUC, err := bridgeUseCase.NewBridgeUseCase(emailUC, bridgeRepository.NewDedupRepository(s.redis), s.logger, s.cfg)
 		if err != nil {
-			s.logger.Errorf("emailAmqpConsumer.StartConsumer: %v", err)
+			return err
 		}
-	}()
+		bridgeKafka.NewEventsConsumerGroup(s.cfg, bridgeUC, s.logger).RunConsumers(ctx)
+		s.logger.Infof("Email bridge consuming topics: %v", bridge.GetTopics(s.cfg))
+	}
+
+	if tracker != nil {
+		go func() {
+			router := echo.New()
+			router.HideBanner = true
+			emailHttp.NewTrackingHandlers(emailUC, tracker, s.logger).MapRoutes(router)
+			s.logger.Infof("Email tracking is listening on port: %v", s.cfg.Tracking.Port)
+			if err := router.Start(s.cfg.Tracking.Port); err != nil && err != http.ErrServerClosed {
+				s.logger.Errorf("router.Start: %v", err)
+			}
+		}()
+	}
 
 	l, err := net.Listen("tcp", s.cfg.Server.Port)
 	if err != nil {
@@ -132,9 +200,11 @@ func (s *Server) Start() error {
 
 	authGRPCServer := authServerGRPC.NewAuthServerGRPC(s.logger, s.cfg, userUC, sessionUC, metrics, emailUC)
 	emailGRPCServer := emailServerGRPC.NewEmailMicroservice(emailUC, s.cfg, s.logger)
+	notificationGRPCServer := notificationServerGRPC.NewNotificationMicroservice(notificationUC, s.cfg, s.logger)
 
 	userService.RegisterUserServiceServer(server, authGRPCServer)
 	userService.RegisterEmailServiceServer(server, emailGRPCServer)
+	userService.RegisterNotificationServiceServer(server, notificationGRPCServer)
 
 	grpcPrometheus.Register(server)
 	http.Handle("/metrics", promhttp.Handler())
@@ -157,3 +227,11 @@ func (s *Server) Start() error {
 
 	return nil
 }
+
+// Rate limiter shared by all email workers, redis shares it across replicas
+func (s *Server) newRateLimiter() email.RateLimiter {
+	if s.cfg.RateLimit.Backend == "redis" {
+		return ratelimit.NewRedisLimiter(s.redis)
+	}
+	return ratelimit.NewMemoryLimiter()
+}
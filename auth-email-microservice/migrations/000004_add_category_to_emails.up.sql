@@ -0,0 +1 @@
+ALTER TABLE emails ADD COLUMN category VARCHAR(32) NOT NULL DEFAULT 'transactional';
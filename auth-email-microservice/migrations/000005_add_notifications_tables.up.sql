@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_preferences;
+
+CREATE TABLE notifications (
+    notification_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    channel VARCHAR(16) NOT NULL,
+    recipient VARCHAR(250) NOT NULL,
+    subject VARCHAR(250) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    category VARCHAR(32) NOT NULL DEFAULT 'transactional',
+    status VARCHAR(16) NOT NULL DEFAULT 'queued',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX notifications_user_id_idx ON notifications (user_id);
+
+CREATE TABLE notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    channels VARCHAR(64) NOT NULL DEFAULT 'email',
+    phone_number VARCHAR(32) NOT NULL DEFAULT '',
+    device_token VARCHAR(250) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_events;
+DROP INDEX IF EXISTS emails_template_idx;
+ALTER TABLE emails DROP COLUMN template;
@@ -0,0 +1,17 @@
+ALTER TABLE emails ADD COLUMN template VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX emails_template_idx ON emails (template);
+
+DROP TABLE IF EXISTS email_events;
+
+CREATE TABLE email_events (
+    event_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    email_id UUID NOT NULL REFERENCES emails (email_id) ON DELETE CASCADE,
+    event_type VARCHAR(16) NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX email_events_email_id_idx ON email_events (email_id, event_type);
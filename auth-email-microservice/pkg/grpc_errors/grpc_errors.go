@@ -15,6 +15,16 @@ var (
 	ErrNoCtxMetadata      = errors.New("No ctx metadata")
 	ErrInvalidSessionId   = errors.New("Invalid session id")
 	ErrEmailAlreadyExists = errors.New("Email already exists")
+
+	ErrPublishNotConfirmed = errors.New("Message not confirmed by broker")
+	ErrPublishUnroutable   = errors.New("Message unroutable")
+	ErrPublishTimeout      = errors.New("Timed out waiting for broker confirmation")
+
+	ErrUnknownEmailCategory = errors.New("Unknown email category")
+	ErrSendThrottled        = errors.New("Send throttled by rate limit")
+
+	ErrNoNotificationChannel      = errors.New("No notification channel available for user")
+	ErrUnknownNotificationChannel = errors.New("Unknown notification channel")
 )
 
 // Parse error and get code
@@ -22,6 +32,16 @@ func ParseGRPCError(err error) codes.Code {
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return codes.NotFound
+	case errors.Is(err, ErrPublishNotConfirmed) || errors.Is(err, ErrPublishUnroutable):
+		return codes.Unavailable
+	case errors.Is(err, ErrPublishTimeout):
+		return codes.DeadlineExceeded
+	case errors.Is(err, ErrUnknownEmailCategory) || errors.Is(err, ErrUnknownNotificationChannel):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrSendThrottled):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrNoNotificationChannel):
+		return codes.FailedPrecondition
 	case strings.Contains(err.Error(), "email") || strings.Contains(err.Error(), "password"):
 		return codes.InvalidArgument
 	case strings.Contains(err.Error(), "redis"):
@@ -59,6 +79,12 @@ func MapGRPCErrStatusCodeToHttpStatus(code codes.Code) int {
 		return http.StatusGatewayTimeout
 	case codes.InvalidArgument:
 		return http.StatusBadRequest
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusUnprocessableEntity
 	}
 
 	return http.StatusInternalServerError
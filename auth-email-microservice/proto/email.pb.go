@@ -32,6 +32,8 @@ type Email struct {
 	Subject       string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
 	ContentType   string                 `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Category      string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	Template      string                 `protobuf:"bytes,9,opt,name=template,proto3" json:"template,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -115,12 +117,32 @@ func (x *Email) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Email) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Email) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
 // SendEmailRequest is the request for the SendEmail method
 type SendEmailRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	To            []string               `protobuf:"bytes,1,rep,name=to,proto3" json:"to,omitempty"`
-	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
-	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	To      []string               `protobuf:"bytes,1,rep,name=to,proto3" json:"to,omitempty"`
+	Subject string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body    string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	// transactional (default) or bulk
+	Category string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// template name, used to aggregate engagement stats
+	Template string `protobuf:"bytes,5,opt,name=template,proto3" json:"template,omitempty"`
+	// rewrite links and add an open pixel
+	Track         bool `protobuf:"varint,6,opt,name=track,proto3" json:"track,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -176,10 +198,32 @@ func (x *SendEmailRequest) GetBody() string {
 	return ""
 }
 
+func (x *SendEmailRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SendEmailRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *SendEmailRequest) GetTrack() bool {
+	if x != nil {
+		return x.Track
+	}
+	return false
+}
+
 // SendEmailResponse is the response for the SendEmail method
 type SendEmailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	EmailId       string                 `protobuf:"bytes,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,6 +265,13 @@ func (x *SendEmailResponse) GetStatus() string {
 	return ""
 }
 
+func (x *SendEmailResponse) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
 // Find email by id request
 type FindEmailByIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -457,11 +508,218 @@ func (x *FindEmailsByReceiverResponse) GetSize() uint64 {
 	return 0
 }
 
+// Email engagement stats message
+type EmailStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailId       string                 `protobuf:"bytes,1,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	Sent          uint64                 `protobuf:"varint,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	Opens         uint64                 `protobuf:"varint,4,opt,name=opens,proto3" json:"opens,omitempty"`
+	UniqueOpens   uint64                 `protobuf:"varint,5,opt,name=unique_opens,json=uniqueOpens,proto3" json:"unique_opens,omitempty"`
+	Clicks        uint64                 `protobuf:"varint,6,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	UniqueClicks  uint64                 `protobuf:"varint,7,opt,name=unique_clicks,json=uniqueClicks,proto3" json:"unique_clicks,omitempty"`
+	OpenRate      float64                `protobuf:"fixed64,8,opt,name=open_rate,json=openRate,proto3" json:"open_rate,omitempty"`
+	ClickRate     float64                `protobuf:"fixed64,9,opt,name=click_rate,json=clickRate,proto3" json:"click_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailStats) Reset() {
+	*x = EmailStats{}
+	mi := &file_email_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailStats) ProtoMessage() {}
+
+func (x *EmailStats) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailStats.ProtoReflect.Descriptor instead.
+func (*EmailStats) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EmailStats) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *EmailStats) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *EmailStats) GetSent() uint64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *EmailStats) GetOpens() uint64 {
+	if x != nil {
+		return x.Opens
+	}
+	return 0
+}
+
+func (x *EmailStats) GetUniqueOpens() uint64 {
+	if x != nil {
+		return x.UniqueOpens
+	}
+	return 0
+}
+
+func (x *EmailStats) GetClicks() uint64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *EmailStats) GetUniqueClicks() uint64 {
+	if x != nil {
+		return x.UniqueClicks
+	}
+	return 0
+}
+
+func (x *EmailStats) GetOpenRate() float64 {
+	if x != nil {
+		return x.OpenRate
+	}
+	return 0
+}
+
+func (x *EmailStats) GetClickRate() float64 {
+	if x != nil {
+		return x.ClickRate
+	}
+	return 0
+}
+
+// Get email stats request, set either email_id or template
+type GetEmailStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailId       string                 `protobuf:"bytes,1,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmailStatsRequest) Reset() {
+	*x = GetEmailStatsRequest{}
+	mi := &file_email_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmailStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmailStatsRequest) ProtoMessage() {}
+
+func (x *GetEmailStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmailStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetEmailStatsRequest) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetEmailStatsRequest) GetEmailId() string {
+	if x != nil {
+		return x.EmailId
+	}
+	return ""
+}
+
+func (x *GetEmailStatsRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+// Get email stats response
+type GetEmailStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *EmailStats            `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmailStatsResponse) Reset() {
+	*x = GetEmailStatsResponse{}
+	mi := &file_email_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmailStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmailStatsResponse) ProtoMessage() {}
+
+func (x *GetEmailStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmailStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetEmailStatsResponse) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetEmailStatsResponse) GetStats() *EmailStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
 var File_email_proto protoreflect.FileDescriptor
 
 const file_email_proto_rawDesc = "" +
 	"\n" +
-	"\vemail.proto\x12\vuserService\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd2\x01\n" +
+	"\vemail.proto\x12\vuserService\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x02\n" +
 	"\x05Email\x12\x19\n" +
 	"\bemail_id\x18\x01 \x01(\tR\aemailId\x12\x0e\n" +
 	"\x02to\x18\x02 \x03(\tR\x02to\x12\x12\n" +
@@ -470,13 +728,19 @@ const file_email_proto_rawDesc = "" +
 	"\asubject\x18\x05 \x01(\tR\asubject\x12!\n" +
 	"\fcontent_type\x18\x06 \x01(\tR\vcontentType\x129\n" +
 	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"P\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\x12\x1a\n" +
+	"\btemplate\x18\t \x01(\tR\btemplate\"\x9e\x01\n" +
 	"\x10SendEmailRequest\x12\x0e\n" +
 	"\x02to\x18\x01 \x03(\tR\x02to\x12\x18\n" +
 	"\asubject\x18\x02 \x01(\tR\asubject\x12\x12\n" +
-	"\x04body\x18\x03 \x01(\tR\x04body\"+\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x1a\n" +
+	"\btemplate\x18\x05 \x01(\tR\btemplate\x12\x14\n" +
+	"\x05track\x18\x06 \x01(\bR\x05track\"F\n" +
 	"\x11SendEmailResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"5\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
+	"\bemail_id\x18\x02 \x01(\tR\aemailId\"5\n" +
 	"\x14FindEmailByIdRequest\x12\x1d\n" +
 	"\n" +
 	"email_uuid\x18\x01 \x01(\tR\temailUuid\"A\n" +
@@ -494,11 +758,29 @@ const file_email_proto_rawDesc = "" +
 	"totalCount\x12\x19\n" +
 	"\bhas_more\x18\x04 \x01(\bR\ahasMore\x12\x12\n" +
 	"\x04page\x18\x05 \x01(\x04R\x04page\x12\x12\n" +
-	"\x04size\x18\x06 \x01(\x04R\x04size2\x9f\x02\n" +
+	"\x04size\x18\x06 \x01(\x04R\x04size\"\x89\x02\n" +
+	"\n" +
+	"EmailStats\x12\x19\n" +
+	"\bemail_id\x18\x01 \x01(\tR\aemailId\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x12\x12\n" +
+	"\x04sent\x18\x03 \x01(\x04R\x04sent\x12\x14\n" +
+	"\x05opens\x18\x04 \x01(\x04R\x05opens\x12!\n" +
+	"\funique_opens\x18\x05 \x01(\x04R\vuniqueOpens\x12\x16\n" +
+	"\x06clicks\x18\x06 \x01(\x04R\x06clicks\x12#\n" +
+	"\runique_clicks\x18\a \x01(\x04R\funiqueClicks\x12\x1b\n" +
+	"\topen_rate\x18\b \x01(\x01R\bopenRate\x12\x1d\n" +
+	"\n" +
+	"click_rate\x18\t \x01(\x01R\tclickRate\"M\n" +
+	"\x14GetEmailStatsRequest\x12\x19\n" +
+	"\bemail_id\x18\x01 \x01(\tR\aemailId\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\"F\n" +
+	"\x15GetEmailStatsResponse\x12-\n" +
+	"\x05stats\x18\x01 \x01(\v2\x17.userService.EmailStatsR\x05stats2\xf7\x02\n" +
 	"\fEmailService\x12J\n" +
 	"\tSendEmail\x12\x1d.userService.SendEmailRequest\x1a\x1e.userService.SendEmailResponse\x12V\n" +
 	"\rFindEmailById\x12!.userService.FindEmailByIdRequest\x1a\".userService.FindEmailByIdResponse\x12k\n" +
-	"\x14FindEmailsByReceiver\x12(.userService.FindEmailsByReceiverRequest\x1a).userService.FindEmailsByReceiverResponseB\x0fZ\r.;userServiceb\x06proto3"
+	"\x14FindEmailsByReceiver\x12(.userService.FindEmailsByReceiverRequest\x1a).userService.FindEmailsByReceiverResponse\x12V\n" +
+	"\rGetEmailStats\x12!.userService.GetEmailStatsRequest\x1a\".userService.GetEmailStatsResponseB\x0fZ\r.;userServiceb\x06proto3"
 
 var (
 	file_email_proto_rawDescOnce sync.Once
@@ -512,7 +794,7 @@ func file_email_proto_rawDescGZIP() []byte {
 	return file_email_proto_rawDescData
 }
 
-var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_email_proto_goTypes = []any{
 	(*Email)(nil),                        // 0: userService.Email
 	(*SendEmailRequest)(nil),             // 1: userService.SendEmailRequest
@@ -521,23 +803,29 @@ var file_email_proto_goTypes = []any{
 	(*FindEmailByIdResponse)(nil),        // 4: userService.FindEmailByIdResponse
 	(*FindEmailsByReceiverRequest)(nil),  // 5: userService.FindEmailsByReceiverRequest
 	(*FindEmailsByReceiverResponse)(nil), // 6: userService.FindEmailsByReceiverResponse
-	(*timestamppb.Timestamp)(nil),        // 7: google.protobuf.Timestamp
+	(*EmailStats)(nil),                   // 7: userService.EmailStats
+	(*GetEmailStatsRequest)(nil),         // 8: userService.GetEmailStatsRequest
+	(*GetEmailStatsResponse)(nil),        // 9: userService.GetEmailStatsResponse
+	(*timestamppb.Timestamp)(nil),        // 10: google.protobuf.Timestamp
 }
 var file_email_proto_depIdxs = []int32{
-	7, // 0: userService.Email.created_at:type_name -> google.protobuf.Timestamp
-	0, // 1: userService.FindEmailByIdResponse.email:type_name -> userService.Email
-	0, // 2: userService.FindEmailsByReceiverResponse.email:type_name -> userService.Email
-	1, // 3: userService.EmailService.SendEmail:input_type -> userService.SendEmailRequest
-	3, // 4: userService.EmailService.FindEmailById:input_type -> userService.FindEmailByIdRequest
-	5, // 5: userService.EmailService.FindEmailsByReceiver:input_type -> userService.FindEmailsByReceiverRequest
-	2, // 6: userService.EmailService.SendEmail:output_type -> userService.SendEmailResponse
-	4, // 7: userService.EmailService.FindEmailById:output_type -> userService.FindEmailByIdResponse
-	6, // 8: userService.EmailService.FindEmailsByReceiver:output_type -> userService.FindEmailsByReceiverResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	10, // 0: userService.Email.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: userService.FindEmailByIdResponse.email:type_name -> userService.Email
+	0,  // 2: userService.FindEmailsByReceiverResponse.email:type_name -> userService.Email
+	7,  // 3: userService.GetEmailStatsResponse.stats:type_name -> userService.EmailStats
+	1,  // 4: userService.EmailService.SendEmail:input_type -> userService.SendEmailRequest
+	3,  // 5: userService.EmailService.FindEmailById:input_type -> userService.FindEmailByIdRequest
+	5,  // 6: userService.EmailService.FindEmailsByReceiver:input_type -> userService.FindEmailsByReceiverRequest
+	8,  // 7: userService.EmailService.GetEmailStats:input_type -> userService.GetEmailStatsRequest
+	2,  // 8: userService.EmailService.SendEmail:output_type -> userService.SendEmailResponse
+	4,  // 9: userService.EmailService.FindEmailById:output_type -> userService.FindEmailByIdResponse
+	6,  // 10: userService.EmailService.FindEmailsByReceiver:output_type -> userService.FindEmailsByReceiverResponse
+	9,  // 11: userService.EmailService.GetEmailStats:output_type -> userService.GetEmailStatsResponse
+	8,  // [8:12] is the sub-list for method output_type
+	4,  // [4:8] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_email_proto_init() }
@@ -551,7 +839,7 @@ func file_email_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_email_proto_rawDesc), len(file_email_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
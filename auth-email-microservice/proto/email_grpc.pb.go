@@ -22,6 +22,7 @@ const (
 	EmailService_SendEmail_FullMethodName            = "/userService.EmailService/SendEmail"
 	EmailService_FindEmailById_FullMethodName        = "/userService.EmailService/FindEmailById"
 	EmailService_FindEmailsByReceiver_FullMethodName = "/userService.EmailService/FindEmailsByReceiver"
+	EmailService_GetEmailStats_FullMethodName        = "/userService.EmailService/GetEmailStats"
 )
 
 // EmailServiceClient is the client API for EmailService service.
@@ -36,6 +37,8 @@ type EmailServiceClient interface {
 	FindEmailById(ctx context.Context, in *FindEmailByIdRequest, opts ...grpc.CallOption) (*FindEmailByIdResponse, error)
 	// Find emails by receiver is the method to find emails by receiver
 	FindEmailsByReceiver(ctx context.Context, in *FindEmailsByReceiverRequest, opts ...grpc.CallOption) (*FindEmailsByReceiverResponse, error)
+	// Get email stats returns open and click stats of an email or of a template
+	GetEmailStats(ctx context.Context, in *GetEmailStatsRequest, opts ...grpc.CallOption) (*GetEmailStatsResponse, error)
 }
 
 type emailServiceClient struct {
@@ -76,6 +79,16 @@ func (c *emailServiceClient) FindEmailsByReceiver(ctx context.Context, in *FindE
 	return out, nil
 }
 
+func (c *emailServiceClient) GetEmailStats(ctx context.Context, in *GetEmailStatsRequest, opts ...grpc.CallOption) (*GetEmailStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmailStatsResponse)
+	err := c.cc.Invoke(ctx, EmailService_GetEmailStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmailServiceServer is the server API for EmailService service.
 // All implementations must embed UnimplementedEmailServiceServer
 // for forward compatibility.
@@ -88,6 +101,8 @@ type EmailServiceServer interface {
 	FindEmailById(context.Context, *FindEmailByIdRequest) (*FindEmailByIdResponse, error)
 	// Find emails by receiver is the method to find emails by receiver
 	FindEmailsByReceiver(context.Context, *FindEmailsByReceiverRequest) (*FindEmailsByReceiverResponse, error)
+	// Get email stats returns open and click stats of an email or of a template
+	GetEmailStats(context.Context, *GetEmailStatsRequest) (*GetEmailStatsResponse, error)
 	mustEmbedUnimplementedEmailServiceServer()
 }
 
@@ -107,6 +122,9 @@ func (UnimplementedEmailServiceServer) FindEmailById(context.Context, *FindEmail
 func (UnimplementedEmailServiceServer) FindEmailsByReceiver(context.Context, *FindEmailsByReceiverRequest) (*FindEmailsByReceiverResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method FindEmailsByReceiver not implemented")
 }
+func (UnimplementedEmailServiceServer) GetEmailStats(context.Context, *GetEmailStatsRequest) (*GetEmailStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEmailStats not implemented")
+}
 func (UnimplementedEmailServiceServer) mustEmbedUnimplementedEmailServiceServer() {}
 func (UnimplementedEmailServiceServer) testEmbeddedByValue()                      {}
 
@@ -182,6 +200,24 @@ func _EmailService_FindEmailsByReceiver_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EmailService_GetEmailStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmailStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).GetEmailStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailService_GetEmailStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).GetEmailStats(ctx, req.(*GetEmailStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EmailService_ServiceDesc is the grpc.ServiceDesc for EmailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,6 +237,10 @@ var EmailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindEmailsByReceiver",
 			Handler:    _EmailService_FindEmailsByReceiver_Handler,
 		},
+		{
+			MethodName: "GetEmailStats",
+			Handler:    _EmailService_GetEmailStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "email.proto",
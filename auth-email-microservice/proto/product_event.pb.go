@@ -0,0 +1,512 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: product_event.proto
+
+package userService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProductEvent is the envelope of the messages of the product topics
+type ProductEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// create_product or update_product
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// schema version of the payload, consumers reject versions newer than they know
+	Version    int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// id of the product the event is about
+	AggregateId string `protobuf:"bytes,5,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	// service and host that published the event
+	Producer string `protobuf:"bytes,6,opt,name=producer,proto3" json:"producer,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ProductEvent_Product
+	Payload       isProductEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductEvent) Reset() {
+	*x = ProductEvent{}
+	mi := &file_product_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductEvent) ProtoMessage() {}
+
+func (x *ProductEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_product_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductEvent.ProtoReflect.Descriptor instead.
+func (*ProductEvent) Descriptor() ([]byte, []int) {
+	return file_product_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProductEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProductEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ProductEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetPayload() isProductEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ProductEvent) GetProduct() *EventProduct {
+	if x != nil {
+		if x, ok := x.Payload.(*ProductEvent_Product); ok {
+			return x.Product
+		}
+	}
+	return nil
+}
+
+type isProductEvent_Payload interface {
+	isProductEvent_Payload()
+}
+
+type ProductEvent_Product struct {
+	Product *EventProduct `protobuf:"bytes,7,opt,name=product,proto3,oneof"`
+}
+
+func (*ProductEvent_Product) isProductEvent_Payload() {}
+
+// EventProduct is the product payload of a ProductEvent
+type EventProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Photos        []string               `protobuf:"bytes,7,rep,name=photos,proto3" json:"photos,omitempty"`
+	Quantity      int64                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Options       []*EventVariantOption  `protobuf:"bytes,12,rep,name=options,proto3" json:"options,omitempty"`
+	Price         *EventMoney            `protobuf:"bytes,14,opt,name=price,proto3" json:"price,omitempty"`
+	Prices        []*EventMoney          `protobuf:"bytes,15,rep,name=prices,proto3" json:"prices,omitempty"`
+	RatingAverage float64                `protobuf:"fixed64,16,opt,name=rating_average,json=ratingAverage,proto3" json:"rating_average,omitempty"`
+	RatingCount   int64                  `protobuf:"varint,17,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	Version       int64                  `protobuf:"varint,18,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventProduct) Reset() {
+	*x = EventProduct{}
+	mi := &file_product_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventProduct) ProtoMessage() {}
+
+func (x *EventProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_product_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventProduct.ProtoReflect.Descriptor instead.
+func (*EventProduct) Descriptor() ([]byte, []int) {
+	return file_product_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EventProduct) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *EventProduct) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *EventProduct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventProduct) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EventProduct) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *EventProduct) GetPhotos() []string {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+func (x *EventProduct) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *EventProduct) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *EventProduct) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *EventProduct) GetOptions() []*EventVariantOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *EventProduct) GetPrice() *EventMoney {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *EventProduct) GetPrices() []*EventMoney {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *EventProduct) GetRatingAverage() float64 {
+	if x != nil {
+		return x.RatingAverage
+	}
+	return 0
+}
+
+func (x *EventProduct) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *EventProduct) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// EventVariantOption is an option axis of a product with its allowed values
+type EventVariantOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventVariantOption) Reset() {
+	*x = EventVariantOption{}
+	mi := &file_product_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventVariantOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventVariantOption) ProtoMessage() {}
+
+func (x *EventVariantOption) ProtoReflect() protoreflect.Message {
+	mi := &file_product_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventVariantOption.ProtoReflect.Descriptor instead.
+func (*EventVariantOption) Descriptor() ([]byte, []int) {
+	return file_product_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EventVariantOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventVariantOption) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// EventMoney is an amount in the minor unit of the currency
+type EventMoney struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 4217 code
+	CurrencyCode  string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventMoney) Reset() {
+	*x = EventMoney{}
+	mi := &file_product_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventMoney) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventMoney) ProtoMessage() {}
+
+func (x *EventMoney) ProtoReflect() protoreflect.Message {
+	mi := &file_product_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventMoney.ProtoReflect.Descriptor instead.
+func (*EventMoney) Descriptor() ([]byte, []int) {
+	return file_product_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EventMoney) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *EventMoney) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+var File_product_event_proto protoreflect.FileDescriptor
+
+const file_product_event_proto_rawDesc = "" +
+	"\n" +
+	"\x13product_event.proto\x12\vuserService\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x02\n" +
+	"\fProductEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12!\n" +
+	"\faggregate_id\x18\x05 \x01(\tR\vaggregateId\x12\x1a\n" +
+	"\bproducer\x18\x06 \x01(\tR\bproducer\x125\n" +
+	"\aproduct\x18\a \x01(\v2\x19.userService.EventProductH\x00R\aproductB\t\n" +
+	"\apayload\"\xca\x04\n" +
+	"\fEventProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06photos\x18\a \x03(\tR\x06photos\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x03R\bquantity\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\aoptions\x18\f \x03(\v2\x1f.userService.EventVariantOptionR\aoptions\x12-\n" +
+	"\x05price\x18\x0e \x01(\v2\x17.userService.EventMoneyR\x05price\x12/\n" +
+	"\x06prices\x18\x0f \x03(\v2\x17.userService.EventMoneyR\x06prices\x12%\n" +
+	"\x0erating_average\x18\x10 \x01(\x01R\rratingAverage\x12!\n" +
+	"\frating_count\x18\x11 \x01(\x03R\vratingCount\x12\x18\n" +
+	"\aversion\x18\x12 \x01(\x03R\aversion\"@\n" +
+	"\x12EventVariantOption\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"I\n" +
+	"\n" +
+	"EventMoney\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amountB\x0fZ\r.;userServiceb\x06proto3"
+
+var (
+	file_product_event_proto_rawDescOnce sync.Once
+	file_product_event_proto_rawDescData []byte
+)
+
+func file_product_event_proto_rawDescGZIP() []byte {
+	file_product_event_proto_rawDescOnce.Do(func() {
+		file_product_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_product_event_proto_rawDesc), len(file_product_event_proto_rawDesc)))
+	})
+	return file_product_event_proto_rawDescData
+}
+
+var file_product_event_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_product_event_proto_goTypes = []any{
+	(*ProductEvent)(nil),          // 0: userService.ProductEvent
+	(*EventProduct)(nil),          // 1: userService.EventProduct
+	(*EventVariantOption)(nil),    // 2: userService.EventVariantOption
+	(*EventMoney)(nil),            // 3: userService.EventMoney
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_product_event_proto_depIdxs = []int32{
+	4, // 0: userService.ProductEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	1, // 1: userService.ProductEvent.product:type_name -> userService.EventProduct
+	4, // 2: userService.EventProduct.created_at:type_name -> google.protobuf.Timestamp
+	4, // 3: userService.EventProduct.updated_at:type_name -> google.protobuf.Timestamp
+	2, // 4: userService.EventProduct.options:type_name -> userService.EventVariantOption
+	3, // 5: userService.EventProduct.price:type_name -> userService.EventMoney
+	3, // 6: userService.EventProduct.prices:type_name -> userService.EventMoney
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_product_event_proto_init() }
+func file_product_event_proto_init() {
+	if File_product_event_proto != nil {
+		return
+	}
+	file_product_event_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProductEvent_Product)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_event_proto_rawDesc), len(file_product_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_product_event_proto_goTypes,
+		DependencyIndexes: file_product_event_proto_depIdxs,
+		MessageInfos:      file_product_event_proto_msgTypes,
+	}.Build()
+	File_product_event_proto = out.File
+	file_product_event_proto_goTypes = nil
+	file_product_event_proto_depIdxs = nil
+}
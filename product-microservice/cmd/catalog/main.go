@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Size of the file chunks sent to ImportCatalog
+const importChunkSize = 32 * 1024
+
+const usage = `usage:
+  catalog import -file products.csv [-format csv|jsonl] [-map name=Title,price=Cost] [-dry-run] [-progress import.progress] [-report errors.jsonl]
+  catalog export [-out products.csv] [-format csv|jsonl] [-map name=Title] [-category id]`
+
+// Bulk catalog import and export through the CatalogService of a running product service
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	switch os.Args[1] {
+	case "import":
+		runImport(os.Args[2:])
+	case "export":
+		runExport(os.Args[2:])
+	default:
+		log.Fatal(usage)
+	}
+}
+
+// importProgress saved after every committed chunk, a rerun with the same progress file resumes after CommittedRows
+type importProgress struct {
+	File          string `json:"file"`
+	CommittedRows int64  `json:"committed_rows"`
+	ImportedCount int64  `json:"imported_count"`
+	FailedCount   int64  `json:"failed_count"`
+	Done          bool   `json:"done"`
+}
+
+func runImport(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:5555", "product service gRPC address")
+	file := flags.String("file", "", "CSV or JSONL file to import")
+	format := flags.String("format", "", "csv or jsonl, defaults to the file extension")
+	mapping := flags.String("map", "", "column mapping as column=file_column pairs separated by commas")
+	dryRun := flags.Bool("dry-run", false, "validate every row without importing")
+	progressFile := flags.String("progress", "", "file the progress is saved to and resumed from")
+	reportFile := flags.String("report", "", "file the row errors are appended to as JSON lines, defaults to stderr")
+	flags.Parse(args)
+
+	if *file == "" {
+		log.Fatal(usage)
+	}
+	if *format == "" {
+		*format = formatFromPath(*file)
+	}
+	columnMapping, err := parseMapping(*mapping)
+	if err != nil {
+		log.Fatalf("parseMapping: %v", err)
+	}
+
+	progress := importProgress{File: *file}
+	if *progressFile != "" && !*dryRun {
+		if progress, err = readProgress(*progressFile, *file); err != nil {
+			log.Fatalf("readProgress: %v", err)
+		}
+		if progress.Done {
+			log.Printf("%s already imported: %d imported, %d failed", *file, progress.ImportedCount, progress.FailedCount)
+			return
+		}
+		if progress.CommittedRows > 0 {
+			log.Printf("resuming %s after row %d", *file, progress.CommittedRows)
+		}
+	}
+
+	in, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("os.Open: %v", err)
+	}
+	defer in.Close()
+
+	// Every run of the same file imports a row under the same product id
+	importID, err := checksum(in)
+	if err != nil {
+		log.Fatalf("checksum: %v", err)
+	}
+
+	report := os.Stderr
+	if *reportFile != "" {
+		if report, err = os.OpenFile(*reportFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644); err != nil {
+			log.Fatalf("os.OpenFile: %v", err)
+		}
+		defer report.Close()
+	}
+	reportEncoder := json.NewEncoder(report)
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("grpc.NewClient: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := productService.NewCatalogServiceClient(conn).ImportCatalog(ctx)
+	if err != nil {
+		log.Fatalf("ImportCatalog: %v", err)
+	}
+
+	if err := stream.Send(&productService.ImportCatalogRequest{
+		Payload: &productService.ImportCatalogRequest_Options{Options: &productService.CatalogImportOptions{
+			Format:        *format,
+			ColumnMapping: columnMapping,
+			DryRun:        *dryRun,
+			SkipRows:      progress.CommittedRows,
+			ImportId:      importID,
+		}},
+	}); err != nil {
+		log.Fatalf("stream.Send: %v", err)
+	}
+
+	go func() {
+		buf := make([]byte, importChunkSize)
+		for {
+			n, err := in.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&productService.ImportCatalogRequest{
+					Payload: &productService.ImportCatalogRequest_Data{Data: buf[:n]},
+				}); err != nil {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				stream.CloseSend()
+				return
+			}
+			if err != nil {
+				log.Printf("file read: %v", err)
+				cancel()
+				return
+			}
+		}
+	}()
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("stream.Recv: %v", err)
+		}
+
+		for _, rowError := range res.GetErrors() {
+			if err := reportEncoder.Encode(models.CatalogRowError{Row: rowError.GetRow(), Error: rowError.GetError()}); err != nil {
+				log.Fatalf("report: %v", err)
+			}
+		}
+
+		progress.CommittedRows = res.GetCommittedRows()
+		progress.ImportedCount = res.GetImportedCount()
+		progress.FailedCount = res.GetFailedCount()
+		progress.Done = res.GetDone()
+		if *progressFile != "" && !*dryRun {
+			if err := writeProgress(*progressFile, progress); err != nil {
+				log.Fatalf("writeProgress: %v", err)
+			}
+		}
+		log.Printf("row %d: %d imported, %d failed", progress.CommittedRows, progress.ImportedCount, progress.FailedCount)
+	}
+
+	if *dryRun {
+		log.Printf("dry run of %s: %d valid, %d invalid", *file, progress.ImportedCount, progress.FailedCount)
+		return
+	}
+	log.Printf("imported %s: %d imported, %d failed", *file, progress.ImportedCount, progress.FailedCount)
+}
+
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:5555", "product service gRPC address")
+	outFile := flags.String("out", "", "file to export to, defaults to stdout")
+	format := flags.String("format", "", "csv or jsonl, defaults to the file extension or csv")
+	mapping := flags.String("map", "", "column mapping as column=file_column pairs separated by commas")
+	categoryID := flags.String("category", "", "only export the products of the category")
+	flags.Parse(args)
+
+	if *format == "" {
+		*format = formatFromPath(*outFile)
+	}
+	columnMapping, err := parseMapping(*mapping)
+	if err != nil {
+		log.Fatalf("parseMapping: %v", err)
+	}
+
+	out := os.Stdout
+	if *outFile != "" {
+		if out, err = os.Create(*outFile); err != nil {
+			log.Fatalf("os.Create: %v", err)
+		}
+		defer out.Close()
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("grpc.NewClient: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := productService.NewCatalogServiceClient(conn).ExportCatalog(context.Background(), &productService.ExportCatalogRequest{
+		Format:        *format,
+		CategoryId:    *categoryID,
+		ColumnMapping: columnMapping,
+	})
+	if err != nil {
+		log.Fatalf("ExportCatalog: %v", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Fatalf("stream.Recv: %v", err)
+		}
+		if _, err := out.Write(chunk.GetData()); err != nil {
+			log.Fatalf("write: %v", err)
+		}
+	}
+}
+
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return models.CatalogFormatJSONL
+	}
+	return models.CatalogFormatCSV
+}
+
+// parseMapping parses "name=Title,price=Cost" into catalog column to file column
+func parseMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if value == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		column, name, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(column) == "" || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%q is not column=file_column", pair)
+		}
+		mapping[strings.TrimSpace(column)] = strings.TrimSpace(name)
+	}
+	return mapping, nil
+}
+
+// readProgress progress of an earlier run of the file, a missing progress file starts from the first row
+func readProgress(path string, file string) (importProgress, error) {
+	progress := importProgress{File: file}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, err
+	}
+	if progress.File != file {
+		return progress, fmt.Errorf("progress file %s belongs to %s", path, progress.File)
+	}
+	return progress, nil
+}
+
+// checksum sha256 of the file, read from the start and rewound
+func checksum(f *os.File) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeProgress replaces the progress file through a rename, so an interrupted run never leaves it half written
+func writeProgress(path string, progress importProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
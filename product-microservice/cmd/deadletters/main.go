@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/auth"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const usage = `usage:
+  deadletters list [-topic update_product] [-class malformed|invalid|rejected|transient] [-status pending|replaying|replayed] [-page 1] [-size 20]
+  deadletters replay [-id message_id,...] [-topic update_product] [-class transient]
+the session of an admin is read from -session or SESSION_ID`
+
+// Inspects and replays the dead letter queue through the DeadLetterService of a running product service
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	switch os.Args[1] {
+	case "list":
+		runList(os.Args[2:])
+	case "replay":
+		runReplay(os.Args[2:])
+	default:
+		log.Fatal(usage)
+	}
+}
+
+// deadLetter printed as one JSON line, the payload as text
+type deadLetter struct {
+	MessageID   string `json:"message_id"`
+	Topic       string `json:"topic"`
+	Partition   int64  `json:"partition"`
+	Offset      int64  `json:"offset"`
+	Key         string `json:"key,omitempty"`
+	Value       string `json:"value"`
+	Error       string `json:"error"`
+	ErrorClass  string `json:"error_class"`
+	Status      string `json:"status"`
+	ReplayCount int64  `json:"replay_count"`
+	FailedAt    string `json:"failed_at"`
+}
+
+func runList(args []string) {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:5555", "product service gRPC address")
+	session := flags.String("session", os.Getenv("SESSION_ID"), "session id of an admin")
+	topic := flags.String("topic", "", "source topic of the dead letters")
+	errorClass := flags.String("class", "", "error class of the dead letters")
+	status := flags.String("status", "", "pending, replaying or replayed")
+	page := flags.Int64("page", 1, "page number")
+	size := flags.Int64("size", 20, "page size")
+	flags.Parse(args)
+
+	client, ctx, closeConn := newClient(*addr, *session)
+	defer closeConn()
+
+	res, err := client.ListDeadLetters(ctx, &productService.ListDeadLettersRequest{
+		Topic:      *topic,
+		ErrorClass: *errorClass,
+		Status:     *status,
+		Page:       *page,
+		Size:       *size,
+	})
+	if err != nil {
+		log.Fatalf("ListDeadLetters: %v", err)
+	}
+
+	printDeadLetters(res.GetDeadLetters())
+	log.Printf("page %d of %d, %d dead letters", res.GetPage(), res.GetTotalPages(), res.GetTotalCount())
+}
+
+func runReplay(args []string) {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:5555", "product service gRPC address")
+	session := flags.String("session", os.Getenv("SESSION_ID"), "session id of an admin")
+	ids := flags.String("id", "", "message ids separated by commas, defaults to the pending dead letters of the filter")
+	topic := flags.String("topic", "", "source topic of the pending dead letters")
+	errorClass := flags.String("class", "", "error class of the pending dead letters")
+	flags.Parse(args)
+
+	var messageIDs []string
+	if *ids != "" {
+		for _, id := range strings.Split(*ids, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				messageIDs = append(messageIDs, id)
+			}
+		}
+	}
+
+	client, ctx, closeConn := newClient(*addr, *session)
+	defer closeConn()
+
+	res, err := client.ReplayDeadLetters(ctx, &productService.ReplayDeadLettersRequest{
+		MessageIds: messageIDs,
+		Topic:      *topic,
+		ErrorClass: *errorClass,
+	})
+	if err != nil {
+		log.Fatalf("ReplayDeadLetters: %v", err)
+	}
+
+	printDeadLetters(res.GetDeadLetters())
+	log.Printf("replayed %d dead letters", len(res.GetDeadLetters()))
+}
+
+// newClient DeadLetterService client and a context carrying the session
+func newClient(addr string, session string) (productService.DeadLetterServiceClient, context.Context, func()) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("grpc.NewClient: %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), auth.SessionIDKey, session)
+	return productService.NewDeadLetterServiceClient(conn), ctx, func() { conn.Close() }
+}
+
+func printDeadLetters(deadLetters []*productService.DeadLetter) {
+	encoder := json.NewEncoder(os.Stdout)
+	for _, d := range deadLetters {
+		if err := encoder.Encode(deadLetter{
+			MessageID:   d.GetMessageId(),
+			Topic:       d.GetTopic(),
+			Partition:   d.GetPartition(),
+			Offset:      d.GetOffset(),
+			Key:         string(d.GetKey()),
+			Value:       string(d.GetValue()),
+			Error:       d.GetError(),
+			ErrorClass:  d.GetErrorClass(),
+			Status:      d.GetStatus(),
+			ReplayCount: d.GetReplayCount(),
+			FailedAt:    d.GetFailedAt().AsTime().Format(time.RFC3339),
+		}); err != nil {
+			log.Fatalf("encode: %v", err)
+		}
+	}
+}
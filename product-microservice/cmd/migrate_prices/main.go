@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/chuuch/product-microservice/config"
+	"github.com/chuuch/product-microservice/internal/migrations"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/mongodb"
+)
+
+// One-time job rewriting float prices as Money, run from the service root so ./config is found
+func main() {
+	currency := flag.String("currency", "", "ISO 4217 currency of the existing float prices, defaults to Currency.Default")
+	dryRun := flag.Bool("dry-run", false, "log the conversions without writing them")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := config.ParseConfig()
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	if *currency == "" {
+		*currency = cfg.Currency.Default
+	}
+	if *currency == "" {
+		appLogger.Fatal("no currency given and Currency.Default is not set")
+	}
+
+	mongoDBConn, err := mongodb.NewMongoDBConn(ctx, cfg)
+	if err != nil {
+		appLogger.Fatal("cannot initialize MongoDB connection", err)
+	}
+	defer func() {
+		if err := mongoDBConn.Disconnect(ctx); err != nil {
+			appLogger.Errorf("mongoDBConn.Disconnect: %v", err)
+		}
+	}()
+
+	reports, err := migrations.NewPriceMigration(mongoDBConn, appLogger, *currency, *dryRun).Run(ctx)
+	for _, report := range reports {
+		appLogger.Infof(
+			"%s.%s: found %d, migrated %d, skipped %d",
+			report.Collection,
+			report.Field,
+			report.Found,
+			report.Migrated,
+			report.Skipped,
+		)
+	}
+	if err != nil {
+		appLogger.Fatalf("PriceMigration.Run: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -9,15 +10,21 @@ import (
 
 // App config
 type Config struct {
-	AppVersion string
-	Server     ServerConfig
-	Logger     LoggerConfig
-	Jaeger     JaegerConfig
-	Metrics    MetricsConfig
-	MongoDB    MongoDBConfig
-	Kafka      KafkaConfig
-	Http       HttpConfig
-	Redis      RedisConfig
+	AppVersion  string
+	Server      ServerConfig
+	Logger      LoggerConfig
+	Jaeger      JaegerConfig
+	Metrics     MetricsConfig
+	MongoDB     MongoDBConfig
+	Kafka       KafkaConfig
+	Http        HttpConfig
+	Redis       RedisConfig
+	Currency    CurrencyConfig
+	Pricing     PricingConfig
+	Auth        AuthConfig
+	Idempotency IdempotencyConfig
+	Cache       CacheConfig
+	Search      SearchConfig
 }
 
 // Server config
@@ -61,9 +68,31 @@ type MongoDBConfig struct {
 	DB       string
 }
 
+// Kafka settings of a config file without them, the names the consumers used before they were configurable
+const (
+	defaultKafkaGroupID       = "products_group"
+	defaultCreateProductTopic = "create_product"
+	defaultUpdateProductTopic = "update_product"
+	defaultNotificationsTopic = "product_notifications"
+	defaultDeadLetterTopic    = "dead_letter_queue"
+	defaultKafkaWorkers       = 16
+	MaxKafkaWorkers           = 256 // most workers of one topic
+)
+
 // Kafka config
 type KafkaConfig struct {
-	Brokers []string
+	Brokers              []string
+	GroupID              string // consumer group of the product consumers
+	CreateProductTopic   string
+	CreateProductWorkers int
+	UpdateProductTopic   string
+	UpdateProductWorkers int
+	NotificationsTopic   string // fired back in stock and price drop subscriptions
+	DeadLetterTopic      string // messages the product consumers failed
+	EventFormat          string // json (default) or protobuf, json keeps consumers that only read the legacy format working
+	BatchEnabled         bool   // consume product writes in batches applied with one bulk write instead of one by one
+	BatchSize            int    // most messages of a batch
+	BatchTimeout         int    // milliseconds to wait for a batch to fill after its first message
 }
 
 // Http config
@@ -90,6 +119,42 @@ type RedisConfig struct {
 	DB             int
 }
 
+// Currency config
+type CurrencyConfig struct {
+	Default string
+}
+
+// Pricing config
+type PricingConfig struct {
+	SchedulerEnabled  bool
+	SchedulerInterval time.Duration
+}
+
+// Auth config
+type AuthConfig struct {
+	GRPCAddr string
+}
+
+// Idempotency config
+type IdempotencyConfig struct {
+	TTL time.Duration // seconds a key and its result are kept
+}
+
+// Product cache config
+type CacheConfig struct {
+	TTL         time.Duration // seconds a product is kept in redis
+	TTLJitter   time.Duration // most seconds added to TTL at random
+	NegativeTTL time.Duration // seconds an unknown product id is kept
+	LocalSize   int           // most products in the in-process cache
+	LocalTTL    time.Duration // seconds a product is kept in the in-process cache
+}
+
+// Search config
+type SearchConfig struct {
+	CacheEnabled bool
+	CacheTTL     time.Duration // seconds a result page is kept, bounds how long it misses new products of categories it does not show
+}
+
 // Load config file from given path
 func exportConfig() error {
 	viper.SetConfigType("yaml")
@@ -116,5 +181,38 @@ func ParseConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err := c.Kafka.setDefaults(); err != nil {
+		return nil, err
+	}
+
 	return &c, nil
 }
+
+// setDefaults fills the unset consumer group, topics and workers and rejects workers the consumers cannot run with
+func (k *KafkaConfig) setDefaults() error {
+	for _, setting := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&k.GroupID, defaultKafkaGroupID},
+		{&k.CreateProductTopic, defaultCreateProductTopic},
+		{&k.UpdateProductTopic, defaultUpdateProductTopic},
+		{&k.NotificationsTopic, defaultNotificationsTopic},
+		{&k.DeadLetterTopic, defaultDeadLetterTopic},
+	} {
+		if *setting.value == "" {
+			*setting.value = setting.fallback
+		}
+	}
+
+	for _, workers := range []*int{&k.CreateProductWorkers, &k.UpdateProductWorkers} {
+		if *workers == 0 {
+			*workers = defaultKafkaWorkers
+		}
+		if *workers < 1 || *workers > MaxKafkaWorkers {
+			return fmt.Errorf("kafka workers %d, 1 to %d", *workers, MaxKafkaWorkers)
+		}
+	}
+
+	return nil
+}
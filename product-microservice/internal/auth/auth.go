@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/pkg/errors"
+)
+
+// SessionIDKey metadata key and header of the auth service session
+const SessionIDKey = "session_id"
+
+// Authenticator resolves auth service sessions to users
+type Authenticator interface {
+	GetUserBySession(ctx context.Context, sessionID string) (*models.User, error)
+}
+
+type userCtxKey struct{}
+
+// ContextWithUser stores the authenticated user in the context
+func ContextWithUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, user)
+}
+
+// UserFromContext authenticated user of the request, nil for anonymous requests
+func UserFromContext(ctx context.Context) *models.User {
+	user, _ := ctx.Value(userCtxKey{}).(*models.User)
+	return user
+}
+
+// RequireAdmin ErrUnauthenticated for anonymous requests, ErrForbidden for users that are not admins
+func RequireAdmin(ctx context.Context) error {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return errors.Wrap(productErrors.ErrUnauthenticated, "auth.UserFromContext")
+	}
+	if !user.IsAdmin() {
+		return errors.Wrapf(productErrors.ErrForbidden, "role %s", user.Role)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/auth"
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	userService "github.com/chuuch/product-microservice/proto/user"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type userClient struct {
+	conn   *grpc.ClientConn
+	client userService.UserServiceClient
+}
+
+// NewUserClient auth service client constructor
+func NewUserClient(addr string) (*userClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrap(err, "grpc.NewClient")
+	}
+
+	return &userClient{
+		conn:   conn,
+		client: userService.NewUserServiceClient(conn),
+	}, nil
+}
+
+// GetUserBySession asks the auth service for the owner of the session
+func (c *userClient) GetUserBySession(ctx context.Context, sessionID string) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "userClient.GetUserBySession")
+	defer span.Finish()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, auth.SessionIDKey, sessionID)
+	res, err := c.client.GetMe(ctx, &userService.GetMeRequest{})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.Unauthenticated, codes.PermissionDenied:
+			return nil, errors.Wrap(productErrors.ErrUnauthenticated, err.Error())
+		}
+		return nil, errors.Wrap(err, "UserService.GetMe")
+	}
+
+	return &models.User{
+		UserID: res.GetUser().GetUuid(),
+		Email:  res.GetUser().GetEmail(),
+		Role:   res.GetUser().GetRole(),
+	}, nil
+}
+
+// Close the connection to the auth service
+func (c *userClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,114 @@
+package codec
+
+import (
+	"io"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/pkg/errors"
+)
+
+// Catalog columns, the json names of the product fields
+const (
+	ColumnProductID   = "product_id"
+	ColumnCategoryID  = "category_id"
+	ColumnName        = "name"
+	ColumnDescription = "description"
+	ColumnPrice       = "price"
+	ColumnCurrency    = "currency"
+	ColumnPrices      = "prices"
+	ColumnImageURL    = "image_url"
+	ColumnPhotos      = "photos"
+	ColumnQuantity    = "quantity"
+	ColumnOptions     = "options"
+)
+
+// Columns every catalog column in export order
+var Columns = []string{
+	ColumnProductID,
+	ColumnCategoryID,
+	ColumnName,
+	ColumnDescription,
+	ColumnPrice,
+	ColumnCurrency,
+	ColumnPrices,
+	ColumnImageURL,
+	ColumnPhotos,
+	ColumnQuantity,
+	ColumnOptions,
+}
+
+// Reader reads the products of a catalog file one row at a time
+type Reader interface {
+	// Read returns io.EOF after the last row, errors wrapping ErrInvalidCatalogRow only reject the current row
+	Read() (*models.Product, error)
+	// Row number of the last read row from 1, the CSV header is not counted
+	Row() int64
+}
+
+// Writer writes the products of a catalog file
+type Writer interface {
+	Write(product *models.Product) error
+	Flush() error
+}
+
+// NewReader reader of the format, mapping is catalog column to file column or key
+func NewReader(r io.Reader, format string, mapping map[string]string) (Reader, error) {
+	if err := validateMapping(mapping); err != nil {
+		return nil, err
+	}
+	switch format {
+	case models.CatalogFormatCSV:
+		return newCSVReader(r, mapping)
+	case models.CatalogFormatJSONL:
+		return newJSONLReader(r, mapping), nil
+	}
+	return nil, errors.Wrapf(productErrors.ErrInvalidCatalogFile, "unknown format %q", format)
+}
+
+// NewWriter writer of the format, mapping is catalog column to file column or key
+func NewWriter(w io.Writer, format string, mapping map[string]string) (Writer, error) {
+	if err := validateMapping(mapping); err != nil {
+		return nil, err
+	}
+	switch format {
+	case models.CatalogFormatCSV:
+		return newCSVWriter(w, mapping)
+	case models.CatalogFormatJSONL:
+		return newJSONLWriter(w, mapping), nil
+	}
+	return nil, errors.Wrapf(productErrors.ErrInvalidCatalogFile, "unknown format %q", format)
+}
+
+func validateMapping(mapping map[string]string) error {
+	for column, name := range mapping {
+		if !isColumn(column) {
+			return errors.Wrapf(productErrors.ErrInvalidCatalogFile, "unknown column %q in mapping", column)
+		}
+		if name == "" {
+			return errors.Wrapf(productErrors.ErrInvalidCatalogFile, "empty name for column %q in mapping", column)
+		}
+	}
+	return nil
+}
+
+func isColumn(column string) bool {
+	for _, c := range Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// fileName name of the catalog column in the file
+func fileName(mapping map[string]string, column string) string {
+	if name, ok := mapping[column]; ok {
+		return name
+	}
+	return column
+}
+
+func rowError(err error) error {
+	return errors.Wrap(productErrors.ErrInvalidCatalogRow, err.Error())
+}
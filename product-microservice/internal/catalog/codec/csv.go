@@ -0,0 +1,205 @@
+package codec
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Separators of the list columns, prices are "12.50 EUR;10 GBP" and photos "a.jpg|b.jpg"
+const (
+	pricesSeparator = ";"
+	photosSeparator = "|"
+)
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int // catalog column to record index
+	row     int64
+}
+
+func newCSVReader(r io.Reader, mapping map[string]string) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrapf(productErrors.ErrInvalidCatalogFile, "csv header: %v", err)
+	}
+
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		indexes[strings.TrimSpace(name)] = i
+	}
+	columns := make(map[string]int, len(Columns))
+	for _, column := range Columns {
+		if i, ok := indexes[fileName(mapping, column)]; ok {
+			columns[column] = i
+		}
+	}
+	if len(columns) == 0 {
+		return nil, errors.Wrap(productErrors.ErrInvalidCatalogFile, "csv header has no catalog column")
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (c *csvReader) Row() int64 {
+	return c.row
+}
+
+func (c *csvReader) Read() (*models.Product, error) {
+	record, err := c.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	c.row++
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, rowError(err)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "csv.Read")
+	}
+
+	value := func(column string) string {
+		if i, ok := c.columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	product, err := productFromRecord(value)
+	if err != nil {
+		return nil, rowError(err)
+	}
+	return product, nil
+}
+
+func productFromRecord(value func(column string) string) (*models.Product, error) {
+	product := &models.Product{
+		Name:        value(ColumnName),
+		Description: value(ColumnDescription),
+	}
+
+	var err error
+	if id := value(ColumnProductID); id != "" {
+		if product.ProductID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, errors.Wrap(err, ColumnProductID)
+		}
+	}
+	if id := value(ColumnCategoryID); id != "" {
+		if product.CategoryID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, errors.Wrap(err, ColumnCategoryID)
+		}
+	}
+
+	currency := value(ColumnCurrency)
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if price := value(ColumnPrice); price != "" {
+		if product.Price, err = models.ParseMoney(price, currency); err != nil {
+			return nil, errors.Wrap(err, ColumnPrice)
+		}
+	}
+	if prices := value(ColumnPrices); prices != "" {
+		for _, price := range strings.Split(prices, pricesSeparator) {
+			fields := strings.Fields(price)
+			if len(fields) != 2 {
+				return nil, errors.Errorf("%s: %q is not an amount and a currency", ColumnPrices, price)
+			}
+			money, err := models.ParseMoney(fields[0], fields[1])
+			if err != nil {
+				return nil, errors.Wrap(err, ColumnPrices)
+			}
+			product.Prices = append(product.Prices, money)
+		}
+	}
+
+	if imageURL := value(ColumnImageURL); imageURL != "" {
+		product.ImageURL = &imageURL
+	}
+	if photos := value(ColumnPhotos); photos != "" {
+		product.Photos = strings.Split(photos, photosSeparator)
+	}
+	if quantity := value(ColumnQuantity); quantity != "" {
+		if product.Quantity, err = strconv.ParseInt(quantity, 10, 64); err != nil {
+			return nil, errors.Wrap(err, ColumnQuantity)
+		}
+	}
+	if options := value(ColumnOptions); options != "" {
+		if err := json.Unmarshal([]byte(options), &product.Options); err != nil {
+			return nil, errors.Wrap(err, ColumnOptions)
+		}
+	}
+
+	return product, nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newCSVWriter(w io.Writer, mapping map[string]string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	header := make([]string, len(Columns))
+	for i, column := range Columns {
+		header[i] = fileName(mapping, column)
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, errors.Wrap(err, "csv.Write")
+	}
+	return &csvWriter{writer: writer, record: make([]string, len(Columns))}, nil
+}
+
+func (c *csvWriter) Write(product *models.Product) error {
+	prices := make([]string, len(product.Prices))
+	for i, price := range product.Prices {
+		prices[i] = price.String()
+	}
+	var options string
+	if len(product.Options) > 0 {
+		optionsBytes, err := json.Marshal(product.Options)
+		if err != nil {
+			return errors.Wrap(err, "json.Marshal")
+		}
+		options = string(optionsBytes)
+	}
+	var categoryID string
+	if !product.CategoryID.IsZero() {
+		categoryID = product.CategoryID.Hex()
+	}
+
+	values := map[string]string{
+		ColumnProductID:   product.ProductID.Hex(),
+		ColumnCategoryID:  categoryID,
+		ColumnName:        product.Name,
+		ColumnDescription: product.Description,
+		ColumnPrice:       product.Price.Decimal(),
+		ColumnCurrency:    product.Price.Currency,
+		ColumnPrices:      strings.Join(prices, pricesSeparator),
+		ColumnImageURL:    product.GetImageURL(),
+		ColumnPhotos:      strings.Join(product.Photos, photosSeparator),
+		ColumnQuantity:    strconv.FormatInt(product.Quantity, 10),
+		ColumnOptions:     options,
+	}
+	for i, column := range Columns {
+		c.record[i] = values[column]
+	}
+	return errors.Wrap(c.writer.Write(c.record), "csv.Write")
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return errors.Wrap(c.writer.Error(), "csv.Flush")
+}
@@ -0,0 +1,180 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testProduct() *models.Product {
+	imageURL := "https://example.com/a.jpg"
+	return &models.Product{
+		ProductID:   primitive.NewObjectID(),
+		CategoryID:  primitive.NewObjectID(),
+		Name:        "Desk lamp",
+		Description: "Lamp, with a comma",
+		Price:       models.Money{Amount: 1999, Currency: "EUR"},
+		Prices:      []models.Money{{Amount: 1750, Currency: "GBP"}, {Amount: 2999, Currency: "JPY"}},
+		ImageURL:    &imageURL,
+		Photos:      []string{"a.jpg", "b.jpg"},
+		Quantity:    7,
+		Options:     []models.VariantOption{{Name: "color", Values: []string{"red", "blue"}}},
+	}
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[string]string
+	}{
+		{name: "catalog columns"},
+		{name: "mapped columns", mapping: map[string]string{ColumnName: "Title", ColumnPrice: "Cost"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := testProduct()
+
+			var buf bytes.Buffer
+			writer, err := NewWriter(&buf, models.CatalogFormatCSV, tt.mapping)
+			if err != nil {
+				t.Fatalf("NewWriter: %v", err)
+			}
+			if err := writer.Write(want); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if err := writer.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			reader, err := NewReader(&buf, models.CatalogFormatCSV, tt.mapping)
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			got, err := reader.Read()
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Read() = %+v, want %+v", got, want)
+			}
+			if reader.Row() != 1 {
+				t.Fatalf("Row() = %d, want 1", reader.Row())
+			}
+			if _, err := reader.Read(); !errors.Is(err, io.EOF) {
+				t.Fatalf("Read after the last row: %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestCSV_Read(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *models.Product
+	}{
+		{
+			name:  "default currency",
+			input: "name,price\nLamp,12.5\n",
+			want:  &models.Product{Name: "Lamp", Price: models.Money{Amount: 1250, Currency: models.DefaultCurrency}},
+		},
+		{
+			name:  "zero decimal currency",
+			input: "name,price,currency\nLamp,1200,JPY\n",
+			want:  &models.Product{Name: "Lamp", Price: models.Money{Amount: 1200, Currency: "JPY"}},
+		},
+		{
+			name:  "unknown and missing columns",
+			input: "sku,name , quantity\nX-1, Lamp ,3\n",
+			want:  &models.Product{Name: "Lamp", Quantity: 3},
+		},
+		{
+			name:  "short record",
+			input: "name,description,quantity\nLamp\n",
+			want:  &models.Product{Name: "Lamp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), models.CatalogFormatCSV, nil)
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			got, err := reader.Read()
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Read() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSV_InvalidRows(t *testing.T) {
+	input := strings.Join([]string{
+		"product_id,name,price,currency,prices,quantity,options",
+		"not-an-id,Lamp,1,EUR,,,",
+		",Lamp,1.999,EUR,,,",
+		",Lamp,1,EUR,10 GBP;11,,",
+		",Lamp,1,EUR,,many,",
+		",Lamp,1,EUR,,,{",
+		",Lamp,1,EUR,,2,",
+	}, "\n")
+
+	reader, err := NewReader(strings.NewReader(input), models.CatalogFormatCSV, nil)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	// Every invalid row only rejects itself, the valid last row is still read
+	for row := int64(1); row <= 5; row++ {
+		if _, err := reader.Read(); !errors.Is(err, productErrors.ErrInvalidCatalogRow) {
+			t.Fatalf("row %d: Read() error = %v, want ErrInvalidCatalogRow", row, err)
+		}
+		if reader.Row() != row {
+			t.Fatalf("Row() = %d, want %d", reader.Row(), row)
+		}
+	}
+	product, err := reader.Read()
+	if err != nil {
+		t.Fatalf("row 6: Read: %v", err)
+	}
+	if product.Quantity != 2 || reader.Row() != 6 {
+		t.Fatalf("row %d: quantity %d, want row 6 quantity 2", reader.Row(), product.Quantity)
+	}
+}
+
+func TestCSV_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		mapping map[string]string
+	}{
+		{name: "empty", format: models.CatalogFormatCSV, input: ""},
+		{name: "no catalog column", format: models.CatalogFormatCSV, input: "sku,title\nX-1,Lamp\n"},
+		{name: "mapped away", format: models.CatalogFormatCSV, input: "name\nLamp\n", mapping: map[string]string{ColumnName: "Title"}},
+		{name: "unknown mapped column", format: models.CatalogFormatCSV, input: "name\nLamp\n", mapping: map[string]string{"sku": "SKU"}},
+		{name: "empty mapped name", format: models.CatalogFormatCSV, input: "name\nLamp\n", mapping: map[string]string{ColumnName: ""}},
+		{name: "unknown format", format: "xml", input: "name\nLamp\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(strings.NewReader(tt.input), tt.format, tt.mapping)
+			if !errors.Is(err, productErrors.ErrInvalidCatalogFile) {
+				t.Fatalf("NewReader() error = %v, want ErrInvalidCatalogFile", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/pkg/errors"
+)
+
+// Longest JSONL line read, a product with many photos and options fits easily
+const maxJSONLLine = 1 << 20
+
+// Lines are the product json, only the keys of catalog columns are read and written
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	mapping map[string]string
+	row     int64
+}
+
+func newJSONLReader(r io.Reader, mapping map[string]string) *jsonlReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLine)
+	return &jsonlReader{scanner: scanner, mapping: mapping}
+}
+
+func (j *jsonlReader) Row() int64 {
+	return j.row
+}
+
+// Read skips blank lines but counts them, so rows are line numbers
+func (j *jsonlReader) Read() (*models.Product, error) {
+	for j.scanner.Scan() {
+		j.row++
+		line := bytes.TrimSpace(j.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		product, err := j.decode(line)
+		if err != nil {
+			return nil, rowError(err)
+		}
+		return product, nil
+	}
+	if err := j.scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "scanner.Scan")
+	}
+	return nil, io.EOF
+}
+
+func (j *jsonlReader) decode(line []byte) (*models.Product, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	catalogFields := make(map[string]json.RawMessage, len(Columns))
+	for _, column := range Columns {
+		value, ok := fields[fileName(j.mapping, column)]
+		if !ok || string(value) == `""` || string(value) == "null" {
+			continue
+		}
+		catalogFields[column] = value
+	}
+
+	productBytes, err := json.Marshal(catalogFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+	var product models.Product
+	if err := json.Unmarshal(productBytes, &product); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	return &product, nil
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+	mapping map[string]string
+}
+
+func newJSONLWriter(w io.Writer, mapping map[string]string) *jsonlWriter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonlWriter{encoder: encoder, mapping: mapping}
+}
+
+func (j *jsonlWriter) Write(product *models.Product) error {
+	productBytes, err := json.Marshal(product)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(productBytes, &fields); err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+
+	line := make(map[string]json.RawMessage, len(Columns))
+	for _, column := range Columns {
+		if value, ok := fields[column]; ok {
+			line[fileName(j.mapping, column)] = value
+		}
+	}
+	return errors.Wrap(j.encoder.Encode(line), "json.Encode")
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,153 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+)
+
+func TestJSONL_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[string]string
+	}{
+		{name: "catalog keys"},
+		{name: "mapped keys", mapping: map[string]string{ColumnName: "title", ColumnQuantity: "stock"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := testProduct()
+			// Only catalog columns are written, the rest of the product is not part of the file
+			want.Version = 3
+			want.RatingCount = 2
+
+			var buf bytes.Buffer
+			writer, err := NewWriter(&buf, models.CatalogFormatJSONL, tt.mapping)
+			if err != nil {
+				t.Fatalf("NewWriter: %v", err)
+			}
+			if err := writer.Write(want); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if err := writer.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+			if strings.Contains(buf.String(), "version") || strings.Contains(buf.String(), "rating_count") {
+				t.Fatalf("line %s has keys of non catalog columns", buf.String())
+			}
+
+			reader, err := NewReader(&buf, models.CatalogFormatJSONL, tt.mapping)
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			got, err := reader.Read()
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			want.Version = 0
+			want.RatingCount = 0
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Read() = %+v, want %+v", got, want)
+			}
+			if _, err := reader.Read(); !errors.Is(err, io.EOF) {
+				t.Fatalf("Read after the last line: %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestJSONL_Read(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *models.Product
+	}{
+		{
+			name:  "legacy float price",
+			input: `{"name":"Lamp","price":12.5}`,
+			want:  &models.Product{Name: "Lamp", Price: models.Money{Amount: 1250, Currency: models.DefaultCurrency}},
+		},
+		{
+			name:  "empty and null values",
+			input: `{"name":"Lamp","description":"","image_url":null,"quantity":2}`,
+			want:  &models.Product{Name: "Lamp", Quantity: 2},
+		},
+		{
+			name:  "unknown keys",
+			input: `{"name":"Lamp","sku":"X-1","version":9}`,
+			want:  &models.Product{Name: "Lamp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), models.CatalogFormatJSONL, nil)
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			got, err := reader.Read()
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Read() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONL_RowsAreLineNumbers(t *testing.T) {
+	input := strings.Join([]string{
+		`{"name":"Lamp","quantity":1}`,
+		``,
+		`   `,
+		`{"name":`,
+		`{"name":"Lamp","quantity":"many"}`,
+		`{"name":"Lamp","quantity":5}`,
+	}, "\n")
+
+	reader, err := NewReader(strings.NewReader(input), models.CatalogFormatJSONL, nil)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	tests := []struct {
+		row      int64
+		invalid  bool
+		quantity int64
+	}{
+		{row: 1, quantity: 1},
+		{row: 4, invalid: true},
+		{row: 5, invalid: true},
+		{row: 6, quantity: 5},
+	}
+	for _, tt := range tests {
+		product, err := reader.Read()
+		if reader.Row() != tt.row {
+			t.Fatalf("Row() = %d, want %d", reader.Row(), tt.row)
+		}
+		if tt.invalid {
+			if !errors.Is(err, productErrors.ErrInvalidCatalogRow) {
+				t.Fatalf("row %d: Read() error = %v, want ErrInvalidCatalogRow", tt.row, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("row %d: Read: %v", tt.row, err)
+		}
+		if product.Quantity != tt.quantity {
+			t.Fatalf("row %d: quantity %d, want %d", tt.row, product.Quantity, tt.quantity)
+		}
+	}
+
+	if _, err := reader.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Read after the last line: %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/chuuch/product-microservice/internal/catalog"
+	"github.com/chuuch/product-microservice/internal/models"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+// Size of the ExportCatalog chunks
+const exportChunkSize = 32 * 1024
+
+// CatalogGRPCService gRPC service
+type CatalogGRPCService struct {
+	productService.UnimplementedCatalogServiceServer
+	catalogUC catalog.UseCase
+	log       logger.Logger
+}
+
+// CatalogGRPCService constructor
+func NewCatalogGRPCService(catalogUC catalog.UseCase, log logger.Logger) *CatalogGRPCService {
+	return &CatalogGRPCService{
+		catalogUC: catalogUC,
+		log:       log,
+	}
+}
+
+// ImportCatalog reads the options from the first message and the file from the following ones
+func (s *CatalogGRPCService) ImportCatalog(stream grpc.BidiStreamingServer[productService.ImportCatalogRequest, productService.ImportCatalogResponse]) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "CatalogGRPCService.ImportCatalog")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	req, err := stream.Recv()
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("stream.Recv: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+	if req.GetOptions() == nil {
+		err := errors.Wrap(productErrors.ErrInvalidCatalogFile, "first message must be the import options")
+		errorMessages.Inc()
+		s.log.Errorf("stream.Recv: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := pw.Write(req.GetData()); err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := s.catalogUC.Import(ctx, pr, models.CatalogImportOptionsFromProto(req.GetOptions()), func(progress *models.CatalogImportProgress) error {
+		return stream.Send(progress.ToProto())
+	}); err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("catalogUC.Import: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return nil
+}
+
+func (s *CatalogGRPCService) ExportCatalog(req *productService.ExportCatalogRequest, stream grpc.ServerStreamingServer[productService.ExportCatalogChunk]) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "CatalogGRPCService.ExportCatalog")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	options := &models.CatalogExportOptions{
+		Format:        req.GetFormat(),
+		ColumnMapping: req.GetColumnMapping(),
+	}
+	if req.GetCategoryId() != "" {
+		categoryID, err := primitive.ObjectIDFromHex(req.GetCategoryId())
+		if err != nil {
+			errorMessages.Inc()
+			s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return grpcerrors.ErrorResponse(err, err.Error())
+		}
+		options.Filter.CategoryID = &categoryID
+	}
+
+	w := bufio.NewWriterSize(chunkWriter{stream: stream}, exportChunkSize)
+	if err := s.catalogUC.Export(ctx, w, options); err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("catalogUC.Export: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+	if err := w.Flush(); err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("bufio.Flush: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return nil
+}
+
+// chunkWriter sends every write as one ExportCatalogChunk
+type chunkWriter struct {
+	stream grpc.ServerStreamingServer[productService.ExportCatalogChunk]
+}
+
+func (c chunkWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := c.stream.Send(&productService.ExportCatalogChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,14 @@
+package catalog
+
+import (
+	"context"
+	"io"
+
+	"github.com/chuuch/product-microservice/internal/models"
+)
+
+// UseCase catalog
+type UseCase interface {
+	Import(ctx context.Context, r io.Reader, options *models.CatalogImportOptions, progress func(progress *models.CatalogImportProgress) error) error
+	Export(ctx context.Context, w io.Writer, options *models.CatalogExportOptions) error
+}
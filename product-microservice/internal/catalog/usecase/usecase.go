@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/chuuch/product-microservice/internal/catalog/codec"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/product"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Rows of a catalog file imported per bulk write, progress is reported after each chunk
+const importChunkSize = 500
+
+var (
+	importedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "catalog_imported_rows_total",
+		Help: "Total number of catalog import rows",
+	}, []string{"result"})
+
+	exportedRows = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "catalog_exported_rows_total",
+		Help: "Total number of catalog export rows",
+	})
+)
+
+type catalogUC struct {
+	productUC product.UseCase
+	log       logger.Logger
+	validate  *validator.Validate
+}
+
+func NewCatalogUC(productUC product.UseCase, log logger.Logger, validate *validator.Validate) *catalogUC {
+	return &catalogUC{productUC: productUC, log: log, validate: validate}
+}
+
+// importChunk rows read since the last reported progress
+type importChunk struct {
+	products []*models.Product
+	rows     []int64
+	errors   []models.CatalogRowError
+	size     int
+}
+
+// Import creates the products of the file in chunks, rows up to options.SkipRows are not read again.
+// A dry run validates every row with the rules of product creation and writes nothing.
+// With an import id the product ids are derived from it and the row, so the rows a crashed run inserted
+// after its last reported progress count as imported when the run is resumed instead of being created twice.
+func (u *catalogUC) Import(
+	ctx context.Context,
+	r io.Reader,
+	options *models.CatalogImportOptions,
+	progress func(progress *models.CatalogImportProgress) error,
+) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "catalogUC.Import")
+	defer span.Finish()
+
+	if err := u.validate.StructCtx(ctx, options); err != nil {
+		return errors.Wrap(productErrors.ErrInvalidCatalogFile, err.Error())
+	}
+
+	reader, err := codec.NewReader(r, options.Format, options.ColumnMapping)
+	if err != nil {
+		return errors.Wrap(err, "codec.NewReader")
+	}
+
+	total := &models.CatalogImportProgress{CommittedRows: options.SkipRows}
+	chunk := &importChunk{}
+
+	flush := func(done bool) error {
+		if err := u.importChunk(ctx, chunk, options.DryRun); err != nil {
+			return err
+		}
+		if reader.Row() > total.CommittedRows {
+			total.CommittedRows = reader.Row()
+		}
+		total.ImportedCount += int64(len(chunk.products))
+		total.FailedCount += int64(len(chunk.errors))
+		total.Errors = chunk.errors
+		total.Done = done
+		*chunk = importChunk{}
+		return progress(total)
+	}
+
+	for {
+		product, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if reader.Row() <= options.SkipRows {
+			continue
+		}
+		if err != nil && !errors.Is(err, productErrors.ErrInvalidCatalogRow) {
+			return errors.Wrap(err, "reader.Read")
+		}
+
+		chunk.size++
+		if err != nil {
+			chunk.errors = append(chunk.errors, models.CatalogRowError{Row: reader.Row(), Error: err.Error()})
+		} else {
+			if options.ImportID != "" && product.ProductID.IsZero() {
+				product.ProductID = importProductID(options.ImportID, reader.Row())
+			}
+			chunk.products = append(chunk.products, product)
+			chunk.rows = append(chunk.rows, reader.Row())
+		}
+
+		if chunk.size == importChunkSize {
+			if err := flush(false); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush(true)
+}
+
+// importChunk creates or validates the products of the chunk, products that fail move to the chunk errors
+func (u *catalogUC) importChunk(ctx context.Context, chunk *importChunk, dryRun bool) error {
+	itemErrors := make([]error, len(chunk.products))
+	if dryRun {
+		for i, product := range chunk.products {
+			if err := u.validate.StructCtx(ctx, product); err != nil {
+				itemErrors[i] = errors.Wrap(err, "validate.StructCtx failed")
+			}
+		}
+	} else if len(chunk.products) > 0 {
+		var err error
+		itemErrors, err = u.productUC.BatchCreateProducts(ctx, chunk.products)
+		if err != nil {
+			return errors.Wrap(err, "productUC.BatchCreateProducts")
+		}
+	}
+
+	result := "imported"
+	if dryRun {
+		result = "validated"
+	}
+
+	created := chunk.products[:0]
+	for i, product := range chunk.products {
+		// A run that crashed before reporting the chunk already inserted the row under the same id
+		if mongo.IsDuplicateKeyError(itemErrors[i]) {
+			itemErrors[i] = nil
+		}
+		if itemErrors[i] != nil {
+			chunk.errors = append(chunk.errors, models.CatalogRowError{Row: chunk.rows[i], Error: itemErrors[i].Error()})
+			continue
+		}
+		created = append(created, product)
+	}
+	chunk.products = created
+	sort.Slice(chunk.errors, func(i, j int) bool { return chunk.errors[i].Row < chunk.errors[j].Row })
+
+	importedRows.WithLabelValues(result).Add(float64(len(chunk.products)))
+	importedRows.WithLabelValues("failed").Add(float64(len(chunk.errors)))
+	return nil
+}
+
+// importProductID id of the product of a row of the import, the same for every run of the import
+func importProductID(importID string, row int64) primitive.ObjectID {
+	sum := sha256.Sum256([]byte(importID + ":" + strconv.FormatInt(row, 10)))
+
+	var productID primitive.ObjectID
+	copy(productID[:], sum[:])
+	return productID
+}
+
+// Export writes the products of the filter in id order
+func (u *catalogUC) Export(ctx context.Context, w io.Writer, options *models.CatalogExportOptions) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "catalogUC.Export")
+	defer span.Finish()
+
+	if err := u.validate.StructCtx(ctx, options); err != nil {
+		return errors.Wrap(productErrors.ErrInvalidCatalogFile, err.Error())
+	}
+
+	writer, err := codec.NewWriter(w, options.Format, options.ColumnMapping)
+	if err != nil {
+		return errors.Wrap(err, "codec.NewWriter")
+	}
+
+	if err := u.productUC.ListProducts(ctx, &options.Filter, func(product *models.Product) error {
+		exportedRows.Inc()
+		return writer.Write(product)
+	}); err != nil {
+		return errors.Wrap(err, "productUC.ListProducts")
+	}
+
+	return writer.Flush()
+}
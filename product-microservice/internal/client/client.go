@@ -34,9 +34,8 @@ func main() {
 		CategoryID:  primitive.NewObjectID(),
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       100.00,
+		Price:       models.Money{Amount: 10000, Currency: "USD"},
 		Quantity:    100,
-		Rating:      5,
 		Photos:      []string{"https://example.com/photo1.jpg", "https://example.com/photo2.jpg"},
 		ImageURL:    nil,
 		CreatedAt:   time.Now().UTC(),
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/consumer"
+	"github.com/chuuch/product-microservice/internal/middleware"
+	"github.com/chuuch/product-microservice/internal/models"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+)
+
+type consumerHandlers struct {
+	log        logger.Logger
+	consumerUC consumer.UseCase
+	group      *echo.Group
+	mw         middleware.MiddlewareManager
+}
+
+func NewConsumerHandlers(log logger.Logger, consumerUC consumer.UseCase, group *echo.Group, mw middleware.MiddlewareManager) *consumerHandlers {
+	return &consumerHandlers{
+		log:        log,
+		consumerUC: consumerUC,
+		group:      group,
+		mw:         mw,
+	}
+}
+
+func (h *consumerHandlers) ListConsumers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "consumerHandlers.ListConsumers")
+		defer span.Finish()
+
+		states, err := h.consumerUC.ListConsumers(ctx)
+		if err != nil {
+			h.log.Errorf("consumerUC.ListConsumers: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, states)
+	}
+}
+
+func (h *consumerHandlers) PauseConsumers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "consumerHandlers.PauseConsumers")
+		defer span.Finish()
+
+		state, err := h.consumerUC.PauseConsumers(ctx, c.Param("topic"))
+		if err != nil {
+			h.log.Errorf("consumerUC.PauseConsumers: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, state)
+	}
+}
+
+func (h *consumerHandlers) ResumeConsumers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "consumerHandlers.ResumeConsumers")
+		defer span.Finish()
+
+		state, err := h.consumerUC.ResumeConsumers(ctx, c.Param("topic"))
+		if err != nil {
+			h.log.Errorf("consumerUC.ResumeConsumers: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, state)
+	}
+}
+
+func (h *consumerHandlers) SetConsumerWorkers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "consumerHandlers.SetConsumerWorkers")
+		defer span.Finish()
+
+		var workers models.ConsumerWorkers
+		if err := c.Bind(&workers); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		workers.Topic = c.Param("topic")
+
+		state, err := h.consumerUC.SetConsumerWorkers(ctx, &workers)
+		if err != nil {
+			h.log.Errorf("consumerUC.SetConsumerWorkers: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, state)
+	}
+}
@@ -0,0 +1,9 @@
+package v1
+
+// MapRoutes consumers admin routes, the changes apply to the consumers of this replica
+func (h *consumerHandlers) MapRoutes() {
+	h.group.GET("/admin/consumers", h.ListConsumers(), h.mw.AuthSession)
+	h.group.POST("/admin/consumers/:topic/pause", h.PauseConsumers(), h.mw.AuthSession)
+	h.group.POST("/admin/consumers/:topic/resume", h.ResumeConsumers(), h.mw.AuthSession)
+	h.group.PUT("/admin/consumers/:topic/workers", h.SetConsumerWorkers(), h.mw.AuthSession)
+}
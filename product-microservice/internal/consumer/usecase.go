@@ -0,0 +1,23 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+)
+
+// Consumers kafka consumers that are paused and resized at runtime
+type Consumers interface {
+	ConsumerStates() []*models.ConsumerState
+	PauseTopic(topic string) (*models.ConsumerState, error)
+	ResumeTopic(topic string) (*models.ConsumerState, error)
+	SetTopicWorkers(topic string, workers int) (*models.ConsumerState, error)
+}
+
+// UseCase consumer
+type UseCase interface {
+	ListConsumers(ctx context.Context) ([]*models.ConsumerState, error)
+	PauseConsumers(ctx context.Context, topic string) (*models.ConsumerState, error)
+	ResumeConsumers(ctx context.Context, topic string) (*models.ConsumerState, error)
+	SetConsumerWorkers(ctx context.Context, workers *models.ConsumerWorkers) (*models.ConsumerState, error)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/auth"
+	"github.com/chuuch/product-microservice/internal/consumer"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+type consumerUC struct {
+	consumers consumer.Consumers
+	log       logger.Logger
+	validate  *validator.Validate
+}
+
+func NewConsumerUC(consumers consumer.Consumers, log logger.Logger, validate *validator.Validate) *consumerUC {
+	return &consumerUC{
+		consumers: consumers,
+		log:       log,
+		validate:  validate,
+	}
+}
+
+// ListConsumers state of the consumers of every topic for admins
+func (u *consumerUC) ListConsumers(ctx context.Context) ([]*models.ConsumerState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "consumerUC.ListConsumers")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return u.consumers.ConsumerStates(), nil
+}
+
+// PauseConsumers stops consuming the topic on this replica until it is resumed, admins only
+func (u *consumerUC) PauseConsumers(ctx context.Context, topic string) (*models.ConsumerState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "consumerUC.PauseConsumers")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	state, err := u.consumers.PauseTopic(topic)
+	if err != nil {
+		return nil, errors.Wrap(err, "consumers.PauseTopic")
+	}
+
+	return state, nil
+}
+
+// ResumeConsumers consumes the paused topic again, admins only
+func (u *consumerUC) ResumeConsumers(ctx context.Context, topic string) (*models.ConsumerState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "consumerUC.ResumeConsumers")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	state, err := u.consumers.ResumeTopic(topic)
+	if err != nil {
+		return nil, errors.Wrap(err, "consumers.ResumeTopic")
+	}
+
+	return state, nil
+}
+
+// SetConsumerWorkers changes the worker concurrency of the topic on this replica, admins only
+func (u *consumerUC) SetConsumerWorkers(ctx context.Context, workers *models.ConsumerWorkers) (*models.ConsumerState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "consumerUC.SetConsumerWorkers")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := u.validate.StructCtx(ctx, workers); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx")
+	}
+
+	state, err := u.consumers.SetTopicWorkers(workers.Topic, workers.Workers)
+	if err != nil {
+		return nil, errors.Wrap(err, "consumers.SetTopicWorkers")
+	}
+
+	return state, nil
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/currency"
+	"github.com/chuuch/product-microservice/internal/models"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CurrencyGRPCService gRPC service
+type CurrencyGRPCService struct {
+	productService.UnimplementedCurrencyServiceServer
+	currencyUC currency.UseCase
+	log        logger.Logger
+}
+
+// CurrencyGRPCService constructor
+func NewCurrencyGRPCService(currencyUC currency.UseCase, log logger.Logger) *CurrencyGRPCService {
+	return &CurrencyGRPCService{
+		currencyUC: currencyUC,
+		log:        log,
+	}
+}
+
+func (c *CurrencyGRPCService) SetCurrencyRate(ctx context.Context, req *productService.SetCurrencyRateRequest) (*productService.SetCurrencyRateResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CurrencyGRPCService.SetCurrencyRate")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	rate, err := primitive.ParseDecimal128(req.GetRate())
+	if err != nil {
+		errorMessages.Inc()
+		c.log.Errorf("primitive.ParseDecimal128: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	created, err := c.currencyUC.SetRate(ctx, &models.CurrencyRate{
+		Base:  req.GetBase(),
+		Quote: req.GetQuote(),
+		Rate:  rate,
+	})
+	if err != nil {
+		errorMessages.Inc()
+		c.log.Errorf("currencyUC.SetRate: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.SetCurrencyRateResponse{Rate: created.ToProto()}, nil
+}
+
+func (c *CurrencyGRPCService) ListCurrencyRates(ctx context.Context, req *productService.ListCurrencyRatesRequest) (*productService.ListCurrencyRatesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CurrencyGRPCService.ListCurrencyRates")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	rates, err := c.currencyUC.GetRates(ctx)
+	if err != nil {
+		errorMessages.Inc()
+		c.log.Errorf("currencyUC.GetRates: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	protoRates := make([]*productService.CurrencyRate, 0, len(rates))
+	for _, rate := range rates {
+		protoRates = append(protoRates, rate.ToProto())
+	}
+
+	successMessages.Inc()
+
+	return &productService.ListCurrencyRatesResponse{Rates: protoRates}, nil
+}
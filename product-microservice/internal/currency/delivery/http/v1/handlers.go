@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/currency"
+	"github.com/chuuch/product-microservice/internal/models"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+)
+
+type currencyHandlers struct {
+	log        logger.Logger
+	currencyUC currency.UseCase
+	group      *echo.Group
+}
+
+func NewCurrencyHandlers(log logger.Logger, currencyUC currency.UseCase, group *echo.Group) *currencyHandlers {
+	return &currencyHandlers{
+		log:        log,
+		currencyUC: currencyUC,
+		group:      group,
+	}
+}
+
+func (h *currencyHandlers) SetRate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "currencyHandlers.SetRate")
+		defer span.Finish()
+
+		var rate models.CurrencyRate
+		if err := c.Bind(&rate); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		rate.Base = c.Param("base")
+		rate.Quote = c.Param("quote")
+
+		updated, err := h.currencyUC.SetRate(ctx, &rate)
+		if err != nil {
+			h.log.Errorf("currencyUC.SetRate: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, updated)
+	}
+}
+
+func (h *currencyHandlers) GetRates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "currencyHandlers.GetRates")
+		defer span.Finish()
+
+		rates, err := h.currencyUC.GetRates(ctx)
+		if err != nil {
+			h.log.Errorf("currencyUC.GetRates: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, rates)
+	}
+}
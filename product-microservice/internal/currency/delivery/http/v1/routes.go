@@ -0,0 +1,7 @@
+package v1
+
+// MapRoutes currency routes
+func (h *currencyHandlers) MapRoutes() {
+	h.group.GET("/currency/rates", h.GetRates())
+	h.group.PUT("/currency/rates/:base/:quote", h.SetRate())
+}
@@ -0,0 +1,14 @@
+package currency
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+)
+
+// Currency rate repository interface
+type MongoRepository interface {
+	SetRate(ctx context.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error)
+	GetRate(ctx context.Context, base, quote string) (*models.CurrencyRate, error)
+	GetRates(ctx context.Context) ([]*models.CurrencyRate, error)
+}
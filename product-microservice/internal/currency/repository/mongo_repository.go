@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	currencyDB              = "products"
+	currencyRatesCollection = "currency_rates"
+)
+
+type currencyMongoRepo struct {
+	mongoDB *mongo.Client
+}
+
+// CurrencyMongo Constructor
+func NewCurrencyMongoRepository(mongoDB *mongo.Client) *currencyMongoRepo {
+	return &currencyMongoRepo{
+		mongoDB: mongoDB,
+	}
+}
+
+func (c *currencyMongoRepo) collection() *mongo.Collection {
+	return c.mongoDB.Database(currencyDB).Collection(currencyRatesCollection)
+}
+
+// CreateIndexes one rate per currency pair
+func (c *currencyMongoRepo) CreateIndexes(ctx context.Context) error {
+	_, err := c.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "base", Value: 1}, {Key: "quote", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return errors.Wrap(err, "Indexes.CreateOne")
+	}
+	return nil
+}
+
+// SetRate creates or replaces the rate of the pair
+func (c *currencyMongoRepo) SetRate(ctx context.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyMongoRepo.SetRate")
+	defer span.Finish()
+
+	rate.UpdatedAt = time.Now().UTC()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := c.collection().ReplaceOne(ctx, bson.M{"base": rate.Base, "quote": rate.Quote}, rate, opts); err != nil {
+		return nil, errors.Wrap(err, "ReplaceOne failed")
+	}
+
+	return rate, nil
+}
+
+func (c *currencyMongoRepo) GetRate(ctx context.Context, base, quote string) (*models.CurrencyRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyMongoRepo.GetRate")
+	defer span.Finish()
+
+	var rate models.CurrencyRate
+	if err := c.collection().FindOne(ctx, bson.M{"base": base, "quote": quote}).Decode(&rate); err != nil {
+		return nil, errors.Wrap(err, "FindOne failed")
+	}
+
+	return &rate, nil
+}
+
+func (c *currencyMongoRepo) GetRates(ctx context.Context) ([]*models.CurrencyRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyMongoRepo.GetRates")
+	defer span.Finish()
+
+	cursor, err := c.collection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "base", Value: 1}, {Key: "quote", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	rates := make([]*models.CurrencyRate, 0)
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,14 @@
+package currency
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+)
+
+// UseCase currency
+type UseCase interface {
+	SetRate(ctx context.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error)
+	GetRates(ctx context.Context) ([]*models.CurrencyRate, error)
+	Convert(ctx context.Context, money models.Money, currency string) (models.Money, error)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/chuuch/product-microservice/internal/currency"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type currencyUC struct {
+	currencyRepo currency.MongoRepository
+	log          logger.Logger
+	validate     *validator.Validate
+}
+
+func NewCurrencyUC(currencyRepo currency.MongoRepository, log logger.Logger, validate *validator.Validate) *currencyUC {
+	return &currencyUC{
+		currencyRepo: currencyRepo,
+		log:          log,
+		validate:     validate,
+	}
+}
+
+func (u *currencyUC) SetRate(ctx context.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyUC.SetRate")
+	defer span.Finish()
+
+	if err := u.validate.StructCtx(ctx, rate); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx")
+	}
+
+	r, err := models.DecimalToRat(rate.Rate)
+	if err != nil || r.Sign() <= 0 {
+		return nil, errors.Wrapf(productErrors.ErrInvalidCurrencyRate, "rate: %s", rate.Rate.String())
+	}
+
+	return u.currencyRepo.SetRate(ctx, rate)
+}
+
+func (u *currencyUC) GetRates(ctx context.Context) ([]*models.CurrencyRate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyUC.GetRates")
+	defer span.Finish()
+
+	return u.currencyRepo.GetRates(ctx)
+}
+
+// Convert money with the direct rate of the pair, falling back to the inverse of the reverse pair
+func (u *currencyUC) Convert(ctx context.Context, money models.Money, currency string) (models.Money, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "currencyUC.Convert")
+	defer span.Finish()
+
+	if money.Currency == currency {
+		return money, nil
+	}
+
+	rate, err := u.rate(ctx, money.Currency, currency)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	return money.Convert(currency, rate), nil
+}
+
+func (u *currencyUC) rate(ctx context.Context, base, quote string) (*big.Rat, error) {
+	direct, err := u.currencyRepo.GetRate(ctx, base, quote)
+	if err == nil {
+		return models.DecimalToRat(direct.Rate)
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, errors.Wrap(err, "currencyRepo.GetRate")
+	}
+
+	inverse, err := u.currencyRepo.GetRate(ctx, quote, base)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrapf(productErrors.ErrNoCurrencyRate, "%s to %s", base, quote)
+		}
+		return nil, errors.Wrap(err, "currencyRepo.GetRate")
+	}
+
+	r, err := models.DecimalToRat(inverse.Rate)
+	if err != nil {
+		return nil, err
+	}
+	if r.Sign() == 0 {
+		return nil, errors.Wrapf(productErrors.ErrNoCurrencyRate, "%s to %s", base, quote)
+	}
+
+	return r.Inv(r), nil
+}
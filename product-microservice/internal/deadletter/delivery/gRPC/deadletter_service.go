@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/deadletter"
+	"github.com/chuuch/product-microservice/internal/models"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+)
+
+// DeadLetterGRPCService gRPC service
+type DeadLetterGRPCService struct {
+	productService.UnimplementedDeadLetterServiceServer
+	deadLetterUC deadletter.UseCase
+	log          logger.Logger
+}
+
+// DeadLetterGRPCService constructor
+func NewDeadLetterGRPCService(deadLetterUC deadletter.UseCase, log logger.Logger) *DeadLetterGRPCService {
+	return &DeadLetterGRPCService{
+		deadLetterUC: deadLetterUC,
+		log:          log,
+	}
+}
+
+func (s *DeadLetterGRPCService) ListDeadLetters(ctx context.Context, req *productService.ListDeadLettersRequest) (*productService.ListDeadLettersResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeadLetterGRPCService.ListDeadLetters")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	query := &models.DeadLetterQuery{
+		Topic:      req.GetTopic(),
+		ErrorClass: req.GetErrorClass(),
+		Status:     req.GetStatus(),
+	}
+
+	list, err := s.deadLetterUC.ListDeadLetters(ctx, query, utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage())))
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("deadLetterUC.ListDeadLetters: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.ListDeadLettersResponse{
+		TotalCount:  list.TotalCount,
+		TotalPages:  list.TotalPages,
+		Page:        list.Page,
+		Size:        list.Size,
+		HasMore:     list.HasMore,
+		DeadLetters: list.ToProtoList(),
+	}, nil
+}
+
+func (s *DeadLetterGRPCService) ReplayDeadLetters(ctx context.Context, req *productService.ReplayDeadLettersRequest) (*productService.ReplayDeadLettersResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeadLetterGRPCService.ReplayDeadLetters")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	query := &models.DeadLetterQuery{
+		Topic:      req.GetTopic(),
+		ErrorClass: req.GetErrorClass(),
+	}
+
+	replayed, err := s.deadLetterUC.ReplayDeadLetters(ctx, req.GetMessageIds(), query)
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("deadLetterUC.ReplayDeadLetters: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	list := &models.DeadLettersList{DeadLetters: replayed}
+	return &productService.ReplayDeadLettersResponse{DeadLetters: list.ToProtoList()}, nil
+}
@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/deadletter"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	deadLettersGroupID = "dead_letters_group" // group id of the dead letter consumer
+	heartbeatInterval  = 3 * time.Second      // hearbeat interval to the group coordinator
+	dialTimeout        = 3 * time.Minute      // timeout for connecting to the broker
+	retryDelay         = 1 * time.Second      // delay between the attempts to save a dead letter
+)
+
+// DeadLettersConsumer keeps the messages of the dead letter queue for inspection and replay
+type DeadLettersConsumer struct {
+	Brokers      []string
+	Topic        string
+	deadLetterUC deadletter.UseCase
+	log          logger.Logger
+}
+
+// NewDeadLettersConsumer constructor, topic is written by the product consumers
+func NewDeadLettersConsumer(brokers []string, topic string, deadLetterUC deadletter.UseCase, log logger.Logger) *DeadLettersConsumer {
+	return &DeadLettersConsumer{
+		Brokers:      brokers,
+		Topic:        topic,
+		deadLetterUC: deadLetterUC,
+		log:          log,
+	}
+}
+
+// Run consumes the dead letter queue until the context is cancelled, one message at a time
+func (c *DeadLettersConsumer) Run(ctx context.Context) {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:           c.Brokers,
+		GroupID:           deadLettersGroupID,
+		Topic:             c.Topic,
+		HeartbeatInterval: heartbeatInterval,
+		Logger:            kafka.LoggerFunc(c.log.Infof),
+		ErrorLogger:       kafka.LoggerFunc(c.log.Errorf),
+		Dialer: &kafka.Dialer{
+			Timeout: dialTimeout,
+		},
+	})
+	defer func() {
+		if err := r.Close(); err != nil {
+			c.log.Errorf("r.Close: %v", err)
+		}
+	}()
+
+	c.log.Infof("Starting consume dead letters from topic: %s", c.Topic)
+
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			c.log.Errorf("r.FetchMessage: %v", err)
+			return
+		}
+
+		var message models.ErrorMessage
+		if err := json.Unmarshal(m.Value, &message); err != nil || message.MessageID == "" {
+			// Written before dead letters carried the original message, nothing to replay
+			c.log.Errorf("dead letter at offset %d is not an error message: %v", m.Offset, err)
+		} else if !c.save(ctx, &message) {
+			return
+		}
+
+		if err := r.CommitMessages(ctx, m); err != nil {
+			c.log.Errorf("r.CommitMessages: %v", err)
+		}
+	}
+}
+
+// save retries until the dead letter is saved, so the offset is never committed past a dead letter that is not kept.
+// It is false when the context is cancelled first.
+func (c *DeadLettersConsumer) save(ctx context.Context, message *models.ErrorMessage) bool {
+	for {
+		err := c.deadLetterUC.SaveDeadLetter(ctx, message)
+		if err == nil {
+			return true
+		}
+		c.log.Errorf("deadLetterUC.SaveDeadLetter: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retryDelay):
+		}
+	}
+}
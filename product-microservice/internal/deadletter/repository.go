@@ -0,0 +1,19 @@
+package deadletter
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+)
+
+// Dead letter repository interface
+type MongoRepository interface {
+	SaveDeadLetter(ctx context.Context, message *models.ErrorMessage) error
+	GetDeadLettersByIDs(ctx context.Context, messageIDs []string) ([]*models.DeadLetter, error)
+	ListDeadLetters(ctx context.Context, query *models.DeadLetterQuery, pagination *utils.Pagination) (*models.DeadLettersList, error)
+	ListPendingDeadLetters(ctx context.Context, query *models.DeadLetterQuery, limit int64) ([]*models.DeadLetter, error)
+	ClaimDeadLetter(ctx context.Context, messageID string) (*models.DeadLetter, error)
+	ReleaseDeadLetter(ctx context.Context, messageID string, status string) error
+	MarkReplayed(ctx context.Context, messageID string) (*models.DeadLetter, error)
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	deadLettersDB         = "products"
+	deadLettersCollection = "dead_letters"
+	claimTimeout          = time.Minute // a claim older than this belongs to a replay that died before marking it
+)
+
+type deadLetterMongoRepo struct {
+	mongoDB *mongo.Client
+}
+
+// DeadLetterMongo Constructor
+func NewDeadLetterMongoRepository(mongoDB *mongo.Client) *deadLetterMongoRepo {
+	return &deadLetterMongoRepo{
+		mongoDB: mongoDB,
+	}
+}
+
+func (r *deadLetterMongoRepo) deadLetters() *mongo.Collection {
+	return r.mongoDB.Database(deadLettersDB).Collection(deadLettersCollection)
+}
+
+// CreateIndexes listings by status, topic and error class in failure order
+func (r *deadLetterMongoRepo) CreateIndexes(ctx context.Context) error {
+	if _, err := r.deadLetters().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "failed_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "topic", Value: 1}, {Key: "error_class", Value: 1}, {Key: "failed_at", Value: -1}},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "dead_letters Indexes.CreateMany")
+	}
+
+	return nil
+}
+
+// SaveDeadLetter inserts the message as pending, a redelivered message keeps its status
+func (r *deadLetterMongoRepo) SaveDeadLetter(ctx context.Context, message *models.ErrorMessage) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.SaveDeadLetter")
+	defer span.Finish()
+
+	deadLetter := &models.DeadLetter{
+		ErrorMessage: *message,
+		Status:       models.DeadLetterStatusPending,
+	}
+
+	if _, err := r.deadLetters().UpdateOne(
+		ctx,
+		bson.M{"_id": message.MessageID},
+		bson.M{"$setOnInsert": deadLetter},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return errors.Wrap(err, "UpdateOne failed")
+	}
+
+	return nil
+}
+
+func (r *deadLetterMongoRepo) GetDeadLettersByIDs(ctx context.Context, messageIDs []string) ([]*models.DeadLetter, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.GetDeadLettersByIDs")
+	defer span.Finish()
+
+	cursor, err := r.deadLetters().Find(ctx, bson.M{"_id": bson.M{"$in": messageIDs}})
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	deadLetters := make([]*models.DeadLetter, 0, len(messageIDs))
+	if err := cursor.All(ctx, &deadLetters); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return deadLetters, nil
+}
+
+// ListDeadLetters of the query, most recent failure first
+func (r *deadLetterMongoRepo) ListDeadLetters(ctx context.Context, query *models.DeadLetterQuery, pagination *utils.Pagination) (*models.DeadLettersList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.ListDeadLetters")
+	defer span.Finish()
+
+	filter := queryFilter(query)
+
+	count, err := r.deadLetters().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "CountDocuments failed")
+	}
+
+	if count == 0 {
+		return &models.DeadLettersList{DeadLetters: make([]*models.DeadLetter, 0)}, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "failed_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(pagination.GetLimit())).
+		SetSkip(int64(pagination.GetOffset()))
+
+	cursor, err := r.deadLetters().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	deadLetters := make([]*models.DeadLetter, 0, pagination.GetSize())
+	if err := cursor.All(ctx, &deadLetters); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return &models.DeadLettersList{
+		TotalCount:  count,
+		TotalPages:  int64(pagination.GetTotalPages(int(count))),
+		Page:        int64(pagination.GetPage()),
+		Size:        int64(pagination.GetSize()),
+		HasMore:     pagination.GetHasMore(int(count)),
+		DeadLetters: deadLetters,
+	}, nil
+}
+
+// ListPendingDeadLetters of the query that were never replayed, oldest failure first
+func (r *deadLetterMongoRepo) ListPendingDeadLetters(ctx context.Context, query *models.DeadLetterQuery, limit int64) ([]*models.DeadLetter, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.ListPendingDeadLetters")
+	defer span.Finish()
+
+	filter := queryFilter(query)
+	filter["status"] = models.DeadLetterStatusPending
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "failed_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.deadLetters().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	deadLetters := make([]*models.DeadLetter, 0)
+	if err := cursor.All(ctx, &deadLetters); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return deadLetters, nil
+}
+
+// ClaimDeadLetter moves the dead letter to replaying and returns it as it was before, so only one replay publishes it.
+// ErrDeadLetterReplaying when another replay holds a claim that is not stale yet.
+func (r *deadLetterMongoRepo) ClaimDeadLetter(ctx context.Context, messageID string) (*models.DeadLetter, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.ClaimDeadLetter")
+	defer span.Finish()
+
+	before := options.Before
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &before,
+	}
+
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": messageID,
+		"$or": bson.A{
+			bson.M{"status": bson.M{"$ne": models.DeadLetterStatusReplaying}},
+			bson.M{"claimed_at": bson.M{"$lt": now.Add(-claimTimeout)}},
+		},
+	}
+
+	var deadLetter models.DeadLetter
+	if err := r.deadLetters().FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"status": models.DeadLetterStatusReplaying, "claimed_at": now}},
+		&opts,
+	).Decode(&deadLetter); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(productErrors.ErrDeadLetterReplaying, messageID)
+		}
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &deadLetter, nil
+}
+
+// ReleaseDeadLetter gives up the claim of a replay that failed to publish, the dead letter gets its status back
+func (r *deadLetterMongoRepo) ReleaseDeadLetter(ctx context.Context, messageID string, status string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.ReleaseDeadLetter")
+	defer span.Finish()
+
+	if _, err := r.deadLetters().UpdateOne(
+		ctx,
+		bson.M{"_id": messageID, "status": models.DeadLetterStatusReplaying},
+		bson.M{"$set": bson.M{"status": status}, "$unset": bson.M{"claimed_at": ""}},
+	); err != nil {
+		return errors.Wrap(err, "UpdateOne failed")
+	}
+
+	return nil
+}
+
+// MarkReplayed records one more replay of the dead letter and ends its claim
+func (r *deadLetterMongoRepo) MarkReplayed(ctx context.Context, messageID string) (*models.DeadLetter, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterMongoRepo.MarkReplayed")
+	defer span.Finish()
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	var deadLetter models.DeadLetter
+	if err := r.deadLetters().FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": messageID},
+		bson.M{
+			"$set":   bson.M{"status": models.DeadLetterStatusReplayed, "replayed_at": time.Now().UTC()},
+			"$unset": bson.M{"claimed_at": ""},
+			"$inc":   bson.M{"replay_count": 1},
+		},
+		&opts,
+	).Decode(&deadLetter); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &deadLetter, nil
+}
+
+func queryFilter(query *models.DeadLetterQuery) bson.M {
+	filter := bson.M{}
+	if query.Topic != "" {
+		filter["topic"] = query.Topic
+	}
+	if query.ErrorClass != "" {
+		filter["error_class"] = query.ErrorClass
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	return filter
+}
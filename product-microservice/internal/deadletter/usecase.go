@@ -0,0 +1,18 @@
+package deadletter
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+)
+
+// HeaderReplayedFrom kafka header with the message id of the dead letter a message replays
+const HeaderReplayedFrom = "replayed_from"
+
+// UseCase dead letter
+type UseCase interface {
+	SaveDeadLetter(ctx context.Context, message *models.ErrorMessage) error
+	ListDeadLetters(ctx context.Context, query *models.DeadLetterQuery, pagination *utils.Pagination) (*models.DeadLettersList, error)
+	ReplayDeadLetters(ctx context.Context, messageIDs []string, query *models.DeadLetterQuery) ([]*models.DeadLetter, error)
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/auth"
+	"github.com/chuuch/product-microservice/internal/deadletter"
+	"github.com/chuuch/product-microservice/internal/models"
+	productKafka "github.com/chuuch/product-microservice/internal/product/delivery/kafka"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+// Most dead letters replayed by one call
+const maxReplay = 500
+
+var (
+	savedDeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "products_dead_letters_total",
+		Help: "Total number of dead letters by source topic and error class",
+	}, []string{"topic", "error_class"})
+
+	replayedDeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "products_replayed_dead_letters_total",
+		Help: "Total number of dead letters replayed to their source topic",
+	}, []string{"topic"})
+)
+
+type deadLetterUC struct {
+	deadLetterRepo   deadletter.MongoRepository
+	productsProducer productKafka.ProductsProducer
+	log              logger.Logger
+	validate         *validator.Validate
+}
+
+func NewDeadLetterUC(
+	deadLetterRepo deadletter.MongoRepository,
+	productsProducer productKafka.ProductsProducer,
+	log logger.Logger,
+	validate *validator.Validate,
+) *deadLetterUC {
+	return &deadLetterUC{
+		deadLetterRepo:   deadLetterRepo,
+		productsProducer: productsProducer,
+		log:              log,
+		validate:         validate,
+	}
+}
+
+// SaveDeadLetter keeps a message of the dead letter queue for inspection and replay
+func (u *deadLetterUC) SaveDeadLetter(ctx context.Context, message *models.ErrorMessage) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterUC.SaveDeadLetter")
+	defer span.Finish()
+
+	if err := u.deadLetterRepo.SaveDeadLetter(ctx, message); err != nil {
+		return errors.Wrap(err, "deadLetterRepo.SaveDeadLetter")
+	}
+
+	savedDeadLetters.WithLabelValues(message.Topic, message.ErrorClass).Inc()
+	return nil
+}
+
+// ListDeadLetters of the query for admins, most recent failure first
+func (u *deadLetterUC) ListDeadLetters(ctx context.Context, query *models.DeadLetterQuery, pagination *utils.Pagination) (*models.DeadLettersList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterUC.ListDeadLetters")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := u.validate.StructCtx(ctx, query); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx failed")
+	}
+
+	return u.deadLetterRepo.ListDeadLetters(ctx, query, pagination)
+}
+
+// ReplayDeadLetters writes the original messages back to their source topic and marks them replayed.
+// Without message ids the oldest pending dead letters of the query are replayed, at most maxReplay of them.
+// Each dead letter is claimed before it is published, so concurrent replays publish it once. A dead letter of
+// the ids that another replay holds fails with ErrDeadLetterReplaying, one of the query is left to that replay.
+// A replayed message carries the replayed_from header, a failed replay becomes a dead letter of its own.
+func (u *deadLetterUC) ReplayDeadLetters(ctx context.Context, messageIDs []string, query *models.DeadLetterQuery) ([]*models.DeadLetter, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deadLetterUC.ReplayDeadLetters")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := u.validate.StructCtx(ctx, query); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx failed")
+	}
+	if len(messageIDs) > maxReplay {
+		return nil, errors.Wrapf(productErrors.ErrTooManyMessageIDs, "%d dead letters, at most %d", len(messageIDs), maxReplay)
+	}
+
+	deadLetters, err := u.replayCandidates(ctx, messageIDs, query)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed := make([]*models.DeadLetter, 0, len(deadLetters))
+	for _, candidate := range deadLetters {
+		deadLetter, err := u.deadLetterRepo.ClaimDeadLetter(ctx, candidate.MessageID)
+		if err != nil {
+			if len(messageIDs) == 0 && errors.Is(err, productErrors.ErrDeadLetterReplaying) {
+				continue
+			}
+			return replayed, errors.Wrap(err, "deadLetterRepo.ClaimDeadLetter")
+		}
+
+		headers := make([]kafka.Header, 0, len(deadLetter.Headers)+1)
+		for _, header := range deadLetter.Headers {
+			if header.Key != deadletter.HeaderReplayedFrom {
+				headers = append(headers, kafka.Header{Key: header.Key, Value: header.Value})
+			}
+		}
+		headers = append(headers, kafka.Header{Key: deadletter.HeaderReplayedFrom, Value: []byte(deadLetter.MessageID)})
+
+		if err := u.productsProducer.Republish(ctx, kafka.Message{
+			Topic:   deadLetter.Topic,
+			Key:     deadLetter.Key,
+			Value:   deadLetter.Value,
+			Headers: headers,
+		}); err != nil {
+			status := deadLetter.Status
+			if status == models.DeadLetterStatusReplaying {
+				// The claim was taken over from a replay that died, the message was not replayed by it either
+				status = models.DeadLetterStatusPending
+			}
+			if err := u.deadLetterRepo.ReleaseDeadLetter(ctx, deadLetter.MessageID, status); err != nil {
+				u.log.Errorf("deadLetterRepo.ReleaseDeadLetter: %v", err)
+			}
+			return replayed, errors.Wrapf(err, "productsProducer.Republish %s", deadLetter.MessageID)
+		}
+
+		marked, err := u.deadLetterRepo.MarkReplayed(ctx, deadLetter.MessageID)
+		if err != nil {
+			return replayed, errors.Wrap(err, "deadLetterRepo.MarkReplayed")
+		}
+		replayedDeadLetters.WithLabelValues(deadLetter.Topic).Inc()
+		replayed = append(replayed, marked)
+	}
+
+	return replayed, nil
+}
+
+// replayCandidates dead letters of the ids in request order, or the pending ones of the query
+func (u *deadLetterUC) replayCandidates(ctx context.Context, messageIDs []string, query *models.DeadLetterQuery) ([]*models.DeadLetter, error) {
+	if len(messageIDs) == 0 {
+		deadLetters, err := u.deadLetterRepo.ListPendingDeadLetters(ctx, query, maxReplay)
+		if err != nil {
+			return nil, errors.Wrap(err, "deadLetterRepo.ListPendingDeadLetters")
+		}
+		return deadLetters, nil
+	}
+
+	found, err := u.deadLetterRepo.GetDeadLettersByIDs(ctx, messageIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "deadLetterRepo.GetDeadLettersByIDs")
+	}
+	byID := make(map[string]*models.DeadLetter, len(found))
+	for _, deadLetter := range found {
+		byID[deadLetter.MessageID] = deadLetter
+	}
+
+	deadLetters := make([]*models.DeadLetter, 0, len(messageIDs))
+	for _, messageID := range messageIDs {
+		deadLetter, ok := byID[messageID]
+		if !ok {
+			return nil, errors.Wrap(productErrors.ErrDeadLetterNotFound, messageID)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, nil
+}
@@ -0,0 +1,22 @@
+package idempotency
+
+import (
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key" // HTTP header of a client chosen key
+	MetadataIdempotencyKey    = "idempotency-key" // gRPC metadata of a client chosen key
+	KafkaHeaderIdempotencyKey = "idempotency_key" // Kafka header carrying the key to the consumers
+
+	maxKeyLength = 255
+)
+
+// ValidateKey an empty key is valid and disables idempotency
+func ValidateKey(key string) error {
+	if len(key) > maxKeyLength {
+		return errors.Wrapf(productErrors.ErrInvalidIdempotencyKey, "longer than %d characters", maxKeyLength)
+	}
+	return nil
+}
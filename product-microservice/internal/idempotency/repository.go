@@ -0,0 +1,11 @@
+package idempotency
+
+import "context"
+
+// RedisRepository results of idempotent requests by key
+type RedisRepository interface {
+	// Reserve stores the value unless the key has one, a replay gets the stored value and false
+	Reserve(ctx context.Context, key string, value string) (string, bool, error)
+	// Release forgets the key so a failed request can be retried
+	Release(ctx context.Context, key string) error
+}
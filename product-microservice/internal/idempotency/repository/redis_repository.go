@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	prefix         = "idempotency"
+	reserveRetries = 2 // the stored value can expire between SETNX and GET
+)
+
+type idempotencyRedisRepo struct {
+	prefix string
+	redis  *redis.Client
+	ttl    time.Duration
+}
+
+// NewIdempotencyRedisRepository constructor, keys are kept for ttl
+func NewIdempotencyRedisRepository(redis *redis.Client, ttl time.Duration) *idempotencyRedisRepo {
+	return &idempotencyRedisRepo{
+		prefix: prefix,
+		redis:  redis,
+		ttl:    ttl,
+	}
+}
+
+// Reserve stores the value unless the key has one, a replay gets the stored value and false
+func (r *idempotencyRedisRepo) Reserve(ctx context.Context, key string, value string) (string, bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRedisRepo.Reserve")
+	defer span.Finish()
+
+	for range reserveRetries {
+		reserved, err := r.redis.SetNX(ctx, r.createKey(key), value, r.ttl).Result()
+		if err != nil {
+			return "", false, errors.Wrap(err, "redis.SetNX failed")
+		}
+		if reserved {
+			return value, true, nil
+		}
+
+		stored, err := r.redis.Get(ctx, r.createKey(key)).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return "", false, errors.Wrap(err, "redis.Get failed")
+		}
+		return stored, false, nil
+	}
+
+	return "", false, errors.Errorf("idempotency key %s expired while reserving", key)
+}
+
+// Release forgets the key so a failed request can be retried
+func (r *idempotencyRedisRepo) Release(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "idempotencyRedisRepo.Release")
+	defer span.Finish()
+
+	return r.redis.Del(ctx, r.createKey(key)).Err()
+}
+
+func (r *idempotencyRedisRepo) createKey(key string) string {
+	return fmt.Sprintf("%s:%s", r.prefix, key)
+}
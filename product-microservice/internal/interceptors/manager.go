@@ -5,7 +5,10 @@ import (
 	"time"
 
 	"github.com/chuuch/product-microservice/config"
+	"github.com/chuuch/product-microservice/internal/auth"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
 	"github.com/chuuch/product-microservice/pkg/logger"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc"
@@ -21,15 +24,17 @@ var (
 
 // InterceptorManager
 type InterceptorManager struct {
-	logger logger.Logger
-	cfg    *config.Config
+	logger        logger.Logger
+	cfg           *config.Config
+	authenticator auth.Authenticator
 }
 
 // InterceptorManager constructor
-func NewInterceptorManager(logger logger.Logger, cfg *config.Config) *InterceptorManager {
+func NewInterceptorManager(logger logger.Logger, cfg *config.Config, authenticator auth.Authenticator) *InterceptorManager {
 	return &InterceptorManager{
-		logger: logger,
-		cfg:    cfg,
+		logger:        logger,
+		cfg:           cfg,
+		authenticator: authenticator,
 	}
 }
 
@@ -47,3 +52,63 @@ func (im *InterceptorManager) Logger(
 	im.logger.Infof("METHOD: %s, REQUEST: %v, RESPONSE: %v, ERROR: %v, TIME: %s, METADATA: %v", info.FullMethod, req, reply, err, time.Since(start), md)
 	return reply, err
 }
+
+// StreamLogger Interceptor logs a stream when it ends
+func (im *InterceptorManager) StreamLogger(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	totalRequests.Inc()
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	err := handler(srv, stream)
+	im.logger.Infof("Total requests: %v", totalRequests)
+	im.logger.Infof("METHOD: %s, STREAM, ERROR: %v, TIME: %s, METADATA: %v", info.FullMethod, err, time.Since(start), md)
+	return err
+}
+
+// Auth Interceptor resolves the session_id metadata to the user, requests without a session stay anonymous
+func (im *InterceptorManager) Auth(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	sessionID := md.Get(auth.SessionIDKey)
+	if len(sessionID) == 0 || sessionID[0] == "" {
+		return handler(ctx, req)
+	}
+
+	user, err := im.authenticator.GetUserBySession(ctx, sessionID[0])
+	if err != nil {
+		im.logger.Errorf("authenticator.GetUserBySession: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	return handler(auth.ContextWithUser(ctx, user), req)
+}
+
+// StreamAuth Interceptor resolves the session_id metadata of a stream like Auth
+func (im *InterceptorManager) StreamAuth(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx := stream.Context()
+	md, _ := metadata.FromIncomingContext(ctx)
+	sessionID := md.Get(auth.SessionIDKey)
+	if len(sessionID) == 0 || sessionID[0] == "" {
+		return handler(srv, stream)
+	}
+
+	user, err := im.authenticator.GetUserBySession(ctx, sessionID[0])
+	if err != nil {
+		im.logger.Errorf("authenticator.GetUserBySession: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	wrapped := grpc_middleware.WrapServerStream(stream)
+	wrapped.WrappedContext = auth.ContextWithUser(ctx, user)
+	return handler(srv, wrapped)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/chuuch/product-microservice/internal/auth"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/labstack/echo/v4"
+)
+
+// AuthSession resolves the session cookie or header to the user, requests without a session stay anonymous
+func (m *middlewareManager) AuthSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		sessionID := c.Request().Header.Get(auth.SessionIDKey)
+		if cookie, err := c.Cookie(m.cfg.Http.SessionCookieName); err == nil && cookie.Value != "" {
+			sessionID = cookie.Value
+		}
+		if sessionID == "" {
+			return next(c)
+		}
+
+		ctx := c.Request().Context()
+		user, err := m.authenticator.GetUserBySession(ctx, sessionID)
+		if err != nil {
+			m.log.Errorf("authenticator.GetUserBySession: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		c.SetRequest(c.Request().WithContext(auth.ContextWithUser(ctx, user)))
+		return next(c)
+	}
+}
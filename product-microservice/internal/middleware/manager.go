@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/chuuch/product-microservice/config"
+	"github.com/chuuch/product-microservice/internal/auth"
 	"github.com/chuuch/product-microservice/pkg/logger"
 	"github.com/labstack/echo/v4"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,20 +18,23 @@ var (
 
 // MiddlewareManager http midlewares
 type middlewareManager struct {
-	log logger.Logger
-	cfg *config.Config
+	log           logger.Logger
+	cfg           *config.Config
+	authenticator auth.Authenticator
 }
 
 // MiddelwareManager interface
 type MiddlewareManager interface {
 	Metrics(next echo.HandlerFunc) echo.HandlerFunc
+	AuthSession(next echo.HandlerFunc) echo.HandlerFunc
 }
 
 // NewMiddlewareManager constructor
-func NewMiddlewareManager(log logger.Logger, cfg *config.Config) *middlewareManager {
+func NewMiddlewareManager(log logger.Logger, cfg *config.Config, authenticator auth.Authenticator) *middlewareManager {
 	return &middlewareManager{
-		log: log,
-		cfg: cfg,
+		log:           log,
+		cfg:           cfg,
+		authenticator: authenticator,
 	}
 }
 
@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	productsDB = "products"
+)
+
+// Numeric bson types written before prices became Money
+var legacyPriceTypes = bson.A{"double", "int", "long"}
+
+// priceField a legacy float field to migrate, zeroIsUnset fields treat 0 as "not set"
+type priceField struct {
+	collection  string
+	field       string
+	zeroIsUnset bool
+}
+
+var priceFields = []priceField{
+	{collection: "products", field: "price"},
+	{collection: "skus", field: "price"},
+	{collection: "subscriptions", field: "threshold", zeroIsUnset: true},
+}
+
+// PriceMigrationReport counts per collection and field
+type PriceMigrationReport struct {
+	Collection string
+	Field      string
+	Found      int64
+	Migrated   int64
+	Skipped    int64
+}
+
+// PriceMigration rewrites legacy float prices as Money in minor units
+type PriceMigration struct {
+	mongoDB  *mongo.Client
+	log      logger.Logger
+	currency string
+	dryRun   bool
+}
+
+// PriceMigration constructor
+func NewPriceMigration(mongoDB *mongo.Client, log logger.Logger, currency string, dryRun bool) *PriceMigration {
+	return &PriceMigration{
+		mongoDB:  mongoDB,
+		log:      log,
+		currency: currency,
+		dryRun:   dryRun,
+	}
+}
+
+// Run migrates every legacy field, it only matches numeric values so it can be re-run after a failure
+func (m *PriceMigration) Run(ctx context.Context) ([]*PriceMigrationReport, error) {
+	reports := make([]*PriceMigrationReport, 0, len(priceFields))
+	for _, field := range priceFields {
+		report, err := m.migrate(ctx, field)
+		if err != nil {
+			return reports, errors.Wrapf(err, "migrate %s.%s", field.collection, field.field)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (m *PriceMigration) migrate(ctx context.Context, field priceField) (*PriceMigrationReport, error) {
+	report := &PriceMigrationReport{Collection: field.collection, Field: field.field}
+	collection := m.mongoDB.Database(productsDB).Collection(field.collection)
+
+	filter := bson.M{field.field: bson.M{"$type": legacyPriceTypes}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{field.field: 1}))
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		report.Found++
+
+		id := cursor.Current.Lookup("_id")
+		value := cursor.Current.Lookup(field.field)
+
+		var legacy float64
+		switch value.Type {
+		case bsontype.Double:
+			legacy = value.Double()
+		default:
+			legacy = float64(value.AsInt64())
+		}
+
+		var migrated interface{}
+		if !(field.zeroIsUnset && legacy == 0) {
+			migrated = models.NewMoneyFromFloat(legacy, m.currency)
+		}
+
+		if m.dryRun {
+			m.log.Infof("dry run %s.%s %v: %v -> %v", field.collection, field.field, id, legacy, migrated)
+			continue
+		}
+
+		// Matching the old value skips documents rewritten since they were read
+		result, err := collection.UpdateOne(ctx, bson.M{"_id": id, field.field: value}, bson.M{"$set": bson.M{field.field: migrated}})
+		if err != nil {
+			return nil, errors.Wrap(err, "UpdateOne failed")
+		}
+		if result.ModifiedCount == 0 {
+			report.Skipped++
+			continue
+		}
+		report.Migrated++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "cursor.Err")
+	}
+
+	return report, nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import productService "github.com/chuuch/product-microservice/proto/product"
+
+const (
+	CatalogFormatCSV   = "csv"
+	CatalogFormatJSONL = "jsonl"
+)
+
+// CatalogImportOptions how a catalog file is read and imported
+type CatalogImportOptions struct {
+	Format        string            `validate:"required,oneof=csv jsonl"`
+	ColumnMapping map[string]string // product column to file column or key
+	DryRun        bool
+	SkipRows      int64  `validate:"min=0"`   // rows committed by an earlier run
+	ImportID      string `validate:"max=128"` // identifies the file, product ids are derived from it and the row when set
+}
+
+// CatalogExportOptions which products are exported and how
+type CatalogExportOptions struct {
+	Format        string `validate:"required,oneof=csv jsonl"`
+	Filter        ProductFilter
+	ColumnMapping map[string]string
+}
+
+// CatalogRowError error of a rejected row, rows are numbered from 1 without the CSV header
+type CatalogRowError struct {
+	Row   int64  `json:"row"`
+	Error string `json:"error"`
+}
+
+// CatalogImportProgress reported after each committed chunk, Errors are the errors of that chunk
+type CatalogImportProgress struct {
+	CommittedRows int64             `json:"committed_rows"`
+	ImportedCount int64             `json:"imported_count"`
+	FailedCount   int64             `json:"failed_count"`
+	Errors        []CatalogRowError `json:"errors"`
+	Done          bool              `json:"done"`
+}
+
+// CatalogImportOptionsFromProto import options from proto to model
+func CatalogImportOptionsFromProto(options *productService.CatalogImportOptions) *CatalogImportOptions {
+	return &CatalogImportOptions{
+		Format:        options.GetFormat(),
+		ColumnMapping: options.GetColumnMapping(),
+		DryRun:        options.GetDryRun(),
+		SkipRows:      options.GetSkipRows(),
+		ImportID:      options.GetImportId(),
+	}
+}
+
+// ToProto Convert import progress to proto
+func (p *CatalogImportProgress) ToProto() *productService.ImportCatalogResponse {
+	rowErrors := make([]*productService.CatalogRowError, 0, len(p.Errors))
+	for _, rowError := range p.Errors {
+		rowErrors = append(rowErrors, &productService.CatalogRowError{Row: rowError.Row, Error: rowError.Error})
+	}
+	return &productService.ImportCatalogResponse{
+		CommittedRows: p.CommittedRows,
+		ImportedCount: p.ImportedCount,
+		FailedCount:   p.FailedCount,
+		Errors:        rowErrors,
+		Done:          p.Done,
+	}
+}
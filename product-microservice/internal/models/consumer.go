@@ -0,0 +1,16 @@
+package models
+
+// ConsumerState runtime state of the consumers of a topic
+type ConsumerState struct {
+	Topic   string `json:"topic"`
+	GroupID string `json:"group_id"`
+	Paused  bool   `json:"paused"`
+	Workers int    `json:"workers"` // concurrent workers, batch mode handles one batch at a time
+	Batch   bool   `json:"batch"`
+}
+
+// ConsumerWorkers new worker concurrency of the consumers of a topic
+type ConsumerWorkers struct {
+	Topic   string `json:"topic" validate:"required"`
+	Workers int    `json:"workers" validate:"required,min=1"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CurrencyRate 1 base = rate quote, stored as Decimal128 so conversions stay exact
+type CurrencyRate struct {
+	Base      string               `json:"base" bson:"base" validate:"required,iso4217"`
+	Quote     string               `json:"quote" bson:"quote" validate:"required,iso4217,nefield=Base"`
+	Rate      primitive.Decimal128 `json:"rate" bson:"rate"`
+	UpdatedAt time.Time            `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+// ToProto Convert currency rate to proto
+func (c *CurrencyRate) ToProto() *productService.CurrencyRate {
+	return &productService.CurrencyRate{
+		Base:      c.Base,
+		Quote:     c.Quote,
+		Rate:      c.Rate.String(),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	}
+}
@@ -1,13 +1,107 @@
 package models
 
-import "time"
+import (
+	"time"
 
-// ErrorMessage
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Error classes of the messages sent to the dead letter queue
+const (
+	ErrorClassMalformed = "malformed" // the payload could not be decoded
+	ErrorClassInvalid   = "invalid"   // the payload failed validation
+	ErrorClassRejected  = "rejected"  // the write cannot succeed as is, a version conflict or an unknown product
+	ErrorClassTransient = "transient" // the write failed on every retry, a replay may succeed
+)
+
+// Dead letter statuses
+const (
+	DeadLetterStatusPending   = "pending"
+	DeadLetterStatusReplaying = "replaying" // claimed by a replay that has not published it yet
+	DeadLetterStatusReplayed  = "replayed"
+)
+
+// MessageHeader kafka message header
+type MessageHeader struct {
+	Key   string `json:"key" bson:"key"`
+	Value []byte `json:"value" bson:"value"`
+}
+
+// ErrorMessage a message a consumer could not handle, sent to the dead letter queue with the whole original message
 type ErrorMessage struct {
-	MessageID string    `json:"message_id"`
-	Offset    int64     `json:"offset"`
-	Topic     string    `json:"topic"`
-	Partition int       `json:"partition"`
-	Error     string    `json:"error"`
-	Time      time.Time `json:"time"`
+	MessageID  string          `json:"message_id" bson:"_id"` // topic, partition and offset of the original message
+	Offset     int64           `json:"offset" bson:"offset"`
+	Topic      string          `json:"topic" bson:"topic"`
+	Partition  int             `json:"partition" bson:"partition"`
+	Key        []byte          `json:"key,omitempty" bson:"key,omitempty"`
+	Value      []byte          `json:"value" bson:"value"`
+	Headers    []MessageHeader `json:"headers,omitempty" bson:"headers,omitempty"`
+	Error      string          `json:"error" bson:"error"`
+	ErrorClass string          `json:"error_class" bson:"error_class"`
+	Time       time.Time       `json:"time" bson:"time"` // of the original message
+	FailedAt   time.Time       `json:"failed_at" bson:"failed_at"`
+}
+
+// DeadLetter an error message kept for inspection and replay
+type DeadLetter struct {
+	ErrorMessage `bson:",inline"`
+	Status       string     `json:"status" bson:"status"`
+	ReplayCount  int64      `json:"replay_count" bson:"replay_count"`
+	ReplayedAt   *time.Time `json:"replayed_at,omitempty" bson:"replayed_at,omitempty"`
+	ClaimedAt    *time.Time `json:"-" bson:"claimed_at,omitempty"` // of the replay holding the claim
+}
+
+// ToProto convert dead letter to proto
+func (d *DeadLetter) ToProto() *productService.DeadLetter {
+	headers := make([]*productService.MessageHeader, 0, len(d.Headers))
+	for _, header := range d.Headers {
+		headers = append(headers, &productService.MessageHeader{Key: header.Key, Value: header.Value})
+	}
+
+	res := &productService.DeadLetter{
+		MessageId:   d.MessageID,
+		Topic:       d.Topic,
+		Partition:   int64(d.Partition),
+		Offset:      d.Offset,
+		Key:         d.Key,
+		Value:       d.Value,
+		Headers:     headers,
+		Error:       d.Error,
+		ErrorClass:  d.ErrorClass,
+		Status:      d.Status,
+		ReplayCount: d.ReplayCount,
+		Time:        timestamppb.New(d.Time),
+		FailedAt:    timestamppb.New(d.FailedAt),
+	}
+	if d.ReplayedAt != nil {
+		res.ReplayedAt = timestamppb.New(*d.ReplayedAt)
+	}
+	return res
+}
+
+// DeadLetterQuery filter of a dead letters listing, empty fields match every dead letter
+type DeadLetterQuery struct {
+	Topic      string
+	ErrorClass string `validate:"omitempty,oneof=malformed invalid rejected transient"`
+	Status     string `validate:"omitempty,oneof=pending replaying replayed"`
+}
+
+// DeadLettersList dead letters response with pagination
+type DeadLettersList struct {
+	TotalCount  int64         `json:"total_count"`
+	TotalPages  int64         `json:"total_pages"`
+	Page        int64         `json:"page"`
+	Size        int64         `json:"size"`
+	HasMore     bool          `json:"has_more"`
+	DeadLetters []*DeadLetter `json:"dead_letters"`
+}
+
+// ToProtoList convert dead letters list to proto
+func (l *DeadLettersList) ToProtoList() []*productService.DeadLetter {
+	deadLetters := make([]*productService.DeadLetter, 0, len(l.DeadLetters))
+	for _, deadLetter := range l.DeadLetters {
+		deadLetters = append(deadLetters, deadLetter.ToProto())
+	}
+	return deadLetters
 }
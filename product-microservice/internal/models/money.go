@@ -0,0 +1,224 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultCurrency currency of legacy float prices, set from config at startup
+var DefaultCurrency = "USD"
+
+// Minor unit digits of currencies that do not use cents
+var currencyExponents = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+// CurrencyExponent number of minor unit digits of the currency
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Money exact amount in minor units of the currency, e.g. 1999 USD is 19.99
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount" validate:"gt=0"`
+	Currency string `json:"currency" bson:"currency" validate:"required,iso4217"`
+}
+
+// NewMoneyFromFloat rounds a legacy float price to the minor units of the currency
+func NewMoneyFromFloat(value float64, currency string) Money {
+	return Money{
+		Amount:   int64(math.Round(value * math.Pow10(CurrencyExponent(currency)))),
+		Currency: currency,
+	}
+}
+
+// ParseMoney parses a decimal string like "19.99" without going through float
+func ParseMoney(value string, currency string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, errors.Errorf("invalid amount: %q", value)
+	}
+	r.Mul(r, new(big.Rat).SetInt(pow10(CurrencyExponent(currency))))
+	if !r.IsInt() {
+		return Money{}, errors.Errorf("amount %q has more decimals than %s allows", value, currency)
+	}
+	return Money{Amount: r.Num().Int64(), Currency: currency}, nil
+}
+
+// IsZero no amount and no currency
+func (m Money) IsZero() bool {
+	return m.Amount == 0 && m.Currency == ""
+}
+
+// Decimal amount formatted with the currency minor unit digits, e.g. "19.99"
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	if exp == 0 {
+		return fmt.Sprintf("%d", m.Amount)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	unit := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, exp, amount%unit)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Compare returns -1, 0 or 1, amounts in different currencies are not comparable
+func (m Money) Compare(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, errors.Wrapf(productErrors.ErrCurrencyMismatch, "%s and %s", m.Currency, other.Currency)
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Convert to another currency with the given rate, rounding half away from zero
+func (m Money) Convert(currency string, rate *big.Rat) Money {
+	r := new(big.Rat).SetInt64(m.Amount)
+	r.Mul(r, rate)
+	r.Mul(r, new(big.Rat).SetInt(pow10(CurrencyExponent(currency))))
+	r.Quo(r, new(big.Rat).SetInt(pow10(CurrencyExponent(m.Currency))))
+
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	return Money{Amount: q.Int64(), Currency: currency}
+}
+
+// UnmarshalJSON accepts legacy float prices in the default currency
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '{' {
+		var value float64
+		if err := json.Unmarshal(data, &value); err != nil {
+			return errors.Wrap(err, "json.Unmarshal legacy price")
+		}
+		*m = NewMoneyFromFloat(value, DefaultCurrency)
+		return nil
+	}
+
+	type money Money
+	return json.Unmarshal(data, (*money)(m))
+}
+
+// UnmarshalBSONValue accepts legacy float prices stored before the migration
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.Double:
+		*m = NewMoneyFromFloat(raw.Double(), DefaultCurrency)
+		return nil
+	case bsontype.Int32, bsontype.Int64:
+		*m = NewMoneyFromFloat(float64(raw.AsInt64()), DefaultCurrency)
+		return nil
+	case bsontype.Null:
+		return nil
+	}
+
+	type money Money
+	return raw.Unmarshal((*money)(m))
+}
+
+// ToProto Convert money to proto
+func (m Money) ToProto() *productService.Money {
+	return &productService.Money{
+		Amount:       m.Amount,
+		CurrencyCode: m.Currency,
+	}
+}
+
+// MoneyFromProto Convert proto to money
+func MoneyFromProto(money *productService.Money) Money {
+	return Money{
+		Amount:   money.GetAmount(),
+		Currency: money.GetCurrencyCode(),
+	}
+}
+
+// MoneyPtrFromProto Convert optional proto money, nil when unset
+func MoneyPtrFromProto(money *productService.Money) *Money {
+	if money == nil {
+		return nil
+	}
+	m := MoneyFromProto(money)
+	return &m
+}
+
+// MoneyListToProto Convert money list to proto
+func MoneyListToProto(list []Money) []*productService.Money {
+	protoList := make([]*productService.Money, 0, len(list))
+	for _, money := range list {
+		protoList = append(protoList, money.ToProto())
+	}
+	return protoList
+}
+
+// MoneyListFromProto Convert proto to money list
+func MoneyListFromProto(list []*productService.Money) []Money {
+	moneyList := make([]Money, 0, len(list))
+	for _, money := range list {
+		moneyList = append(moneyList, MoneyFromProto(money))
+	}
+	return moneyList
+}
+
+// DecimalToRat exact value of a Decimal128 rate
+func DecimalToRat(d primitive.Decimal128) (*big.Rat, error) {
+	coefficient, exp, err := d.BigInt()
+	if err != nil {
+		return nil, errors.Wrap(err, "Decimal128.BigInt")
+	}
+
+	r := new(big.Rat).SetInt(coefficient)
+	if exp > 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow10(exp)))
+	} else if exp < 0 {
+		r.Quo(r, new(big.Rat).SetInt(pow10(-exp)))
+	}
+	return r, nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{name: "cents", value: "19.99", currency: "USD", want: 1999},
+		{name: "whole amount", value: "20", currency: "USD", want: 2000},
+		{name: "fewer decimals than the currency", value: "12.5", currency: "EUR", want: 1250},
+		{name: "surrounding spaces", value: " 5.00 ", currency: "USD", want: 500},
+		{name: "negative", value: "-1.50", currency: "USD", want: -150},
+		{name: "exponent", value: "1e2", currency: "USD", want: 10000},
+		{name: "zero decimal currency", value: "1200", currency: "JPY", want: 1200},
+		{name: "three decimal currency", value: "1.234", currency: "KWD", want: 1234},
+		{name: "more decimals than cents", value: "19.999", currency: "USD", wantErr: true},
+		{name: "decimals of a zero decimal currency", value: "12.5", currency: "JPY", wantErr: true},
+		{name: "more decimals than three", value: "1.2345", currency: "KWD", wantErr: true},
+		{name: "not a number", value: "abc", currency: "USD", wantErr: true},
+		{name: "empty", value: "", currency: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMoney(%q, %s) = %v, want an error", tt.value, tt.currency, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q, %s): %v", tt.value, tt.currency, err)
+			}
+			if want := (Money{Amount: tt.want, Currency: tt.currency}); got != want {
+				t.Fatalf("ParseMoney(%q, %s) = %v, want %v", tt.value, tt.currency, got, want)
+			}
+		})
+	}
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: Money{Amount: 1999, Currency: "USD"}, want: "19.99"},
+		{money: Money{Amount: 5, Currency: "USD"}, want: "0.05"},
+		{money: Money{Amount: -5, Currency: "USD"}, want: "-0.05"},
+		{money: Money{Amount: 1200, Currency: "JPY"}, want: "1200"},
+		{money: Money{Amount: 1234, Currency: "KWD"}, want: "1.234"},
+		{money: Money{Amount: 7, Currency: "KWD"}, want: "0.007"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want+" "+tt.money.Currency, func(t *testing.T) {
+			if got := tt.money.Decimal(); got != tt.want {
+				t.Fatalf("Decimal() = %q, want %q", got, tt.want)
+			}
+			// The formatted amount parses back to the same money
+			parsed, err := ParseMoney(tt.money.Decimal(), tt.money.Currency)
+			if err != nil || parsed != tt.money {
+				t.Fatalf("ParseMoney(Decimal()) = %v, %v, want %v", parsed, err, tt.money)
+			}
+		})
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    Money
+		currency string
+		rate     string
+		want     int64
+	}{
+		{name: "exact", money: Money{Amount: 1000, Currency: "USD"}, currency: "EUR", rate: "0.9", want: 900},
+		{name: "to zero decimal currency", money: Money{Amount: 1999, Currency: "USD"}, currency: "JPY", rate: "150", want: 2999},
+		{name: "from zero decimal currency", money: Money{Amount: 1000, Currency: "JPY"}, currency: "USD", rate: "0.0067", want: 670},
+		{name: "to three decimal currency", money: Money{Amount: 1000, Currency: "JPY"}, currency: "KWD", rate: "0.002", want: 2000},
+		{name: "from three decimal currency", money: Money{Amount: 1234, Currency: "KWD"}, currency: "USD", rate: "3.25", want: 401},
+		{name: "below half rounds down", money: Money{Amount: 1, Currency: "USD"}, currency: "EUR", rate: "0.49", want: 0},
+		{name: "above half rounds up", money: Money{Amount: 1, Currency: "USD"}, currency: "EUR", rate: "0.51", want: 1},
+		// Half away from zero, half-even would give 0, 2 and 2
+		{name: "half of zero rounds up", money: Money{Amount: 1, Currency: "USD"}, currency: "EUR", rate: "0.5", want: 1},
+		{name: "half of odd rounds up", money: Money{Amount: 3, Currency: "USD"}, currency: "EUR", rate: "0.5", want: 2},
+		{name: "half of even rounds up", money: Money{Amount: 5, Currency: "USD"}, currency: "EUR", rate: "0.5", want: 3},
+		{name: "negative half rounds away from zero", money: Money{Amount: -5, Currency: "USD"}, currency: "EUR", rate: "0.5", want: -3},
+		{name: "same currency", money: Money{Amount: 1999, Currency: "USD"}, currency: "USD", rate: "1", want: 1999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, ok := new(big.Rat).SetString(tt.rate)
+			if !ok {
+				t.Fatalf("invalid rate %q", tt.rate)
+			}
+			got := tt.money.Convert(tt.currency, rate)
+			if want := (Money{Amount: tt.want, Currency: tt.currency}); got != want {
+				t.Fatalf("%v.Convert(%s, %s) = %v, want %v", tt.money, tt.currency, tt.rate, got, want)
+			}
+		})
+	}
+}
+
+func TestNewMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		value    float64
+		currency string
+		want     int64
+	}{
+		{value: 19.99, currency: "USD", want: 1999},
+		{value: 0.1 + 0.2, currency: "USD", want: 30},
+		{value: 2.5, currency: "JPY", want: 3},
+		{value: -2.5, currency: "JPY", want: -3},
+		{value: 1.2345, currency: "KWD", want: 1235},
+	}
+
+	for _, tt := range tests {
+		if got := NewMoneyFromFloat(tt.value, tt.currency); got.Amount != tt.want || got.Currency != tt.currency {
+			t.Fatalf("NewMoneyFromFloat(%v, %s) = %v, want %d %s", tt.value, tt.currency, got, tt.want, tt.currency)
+		}
+	}
+}
+
+func TestMoney_Compare(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Money
+		want    int
+		wantErr error
+	}{
+		{name: "less", a: Money{Amount: 100, Currency: "USD"}, b: Money{Amount: 101, Currency: "USD"}, want: -1},
+		{name: "equal", a: Money{Amount: 100, Currency: "USD"}, b: Money{Amount: 100, Currency: "USD"}, want: 0},
+		{name: "greater", a: Money{Amount: 101, Currency: "USD"}, b: Money{Amount: 100, Currency: "USD"}, want: 1},
+		{name: "currency mismatch", a: Money{Amount: 100, Currency: "USD"}, b: Money{Amount: 100, Currency: "EUR"}, wantErr: productErrors.ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Compare(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Compare() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Compare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Operation types, one per asynchronous write
+const (
+	OperationTypeCreateProduct = "create_product"
+	OperationTypeUpdateProduct = "update_product"
+)
+
+// Operation statuses, pending until a consumer records the outcome
+const (
+	OperationStatusPending   = "pending"
+	OperationStatusSucceeded = "succeeded"
+	OperationStatusFailed    = "failed"
+)
+
+// Operation outcome of a write published to Kafka and applied by a consumer
+type Operation struct {
+	OperationID string              `json:"operation_id"`
+	Type        string              `json:"type"`
+	Status      string              `json:"status"`
+	ProductID   *primitive.ObjectID `json:"product_id,omitempty"`
+	Product     *Product            `json:"product,omitempty"` // resulting product of a succeeded operation
+	Error       string              `json:"error,omitempty"`   // cause of a failed operation
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	PriceChangeSourceCreate   = "create"
+	PriceChangeSourceUpdate   = "update"
+	PriceChangeSourceSchedule = "schedule"
+	PriceChangeSourceRevert   = "revert"
+)
+
+const (
+	ScheduleStatusPending   = "pending"
+	ScheduleStatusActive    = "active"
+	ScheduleStatusCompleted = "completed"
+	ScheduleStatusCancelled = "cancelled"
+)
+
+// PriceChange append only record of a product price change
+type PriceChange struct {
+	PriceChangeID primitive.ObjectID  `json:"price_change_id" bson:"_id,omitempty"`
+	ProductID     primitive.ObjectID  `json:"product_id" bson:"product_id"`
+	OldPrice      *Money              `json:"old_price,omitempty" bson:"old_price,omitempty"` // nil for the first price of a product
+	NewPrice      Money               `json:"new_price" bson:"new_price"`
+	Source        string              `json:"source" bson:"source"`
+	ScheduleID    *primitive.ObjectID `json:"schedule_id,omitempty" bson:"schedule_id,omitempty"`
+	ChangedAt     time.Time           `json:"changed_at" bson:"changed_at"`
+}
+
+// ToProto Convert price change to proto
+func (p *PriceChange) ToProto() *productService.PriceChange {
+	change := &productService.PriceChange{
+		PriceChangeId: p.PriceChangeID.Hex(),
+		ProductId:     p.ProductID.Hex(),
+		NewPrice:      p.NewPrice.ToProto(),
+		Source:        p.Source,
+		ChangedAt:     timestamppb.New(p.ChangedAt),
+	}
+	if p.OldPrice != nil {
+		change.OldPrice = p.OldPrice.ToProto()
+	}
+	if p.ScheduleID != nil {
+		change.ScheduleId = p.ScheduleID.Hex()
+	}
+	return change
+}
+
+// PriceHistory price changes of a product, newest first, with pagination
+type PriceHistory struct {
+	TotalCount   int64          `json:"total_count"`
+	TotalPages   int64          `json:"total_pages"`
+	Page         int64          `json:"page"`
+	Size         int64          `json:"size"`
+	HasMore      bool           `json:"has_more"`
+	PriceChanges []*PriceChange `json:"price_changes"`
+}
+
+// ScheduledPriceChange price applied at StartAt and reverted at EndAt by the scheduler
+type ScheduledPriceChange struct {
+	ScheduleID    primitive.ObjectID `json:"schedule_id" bson:"_id,omitempty"`
+	ProductID     primitive.ObjectID `json:"product_id" bson:"product_id" validate:"required"`
+	Price         Money              `json:"price" bson:"price"`
+	StartAt       time.Time          `json:"start_at" bson:"start_at" validate:"required"`
+	EndAt         *time.Time         `json:"end_at,omitempty" bson:"end_at"` // nil keeps the price after it is applied
+	Status        string             `json:"status" bson:"status"`
+	PreviousPrice *Money             `json:"previous_price,omitempty" bson:"previous_price,omitempty"` // price replaced when applied, restored at EndAt
+	AppliedAt     *time.Time         `json:"applied_at,omitempty" bson:"applied_at,omitempty"`
+	EndedAt       *time.Time         `json:"ended_at,omitempty" bson:"ended_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+// ToProto Convert scheduled price change to proto
+func (s *ScheduledPriceChange) ToProto() *productService.ScheduledPriceChange {
+	schedule := &productService.ScheduledPriceChange{
+		ScheduleId: s.ScheduleID.Hex(),
+		ProductId:  s.ProductID.Hex(),
+		Price:      s.Price.ToProto(),
+		StartAt:    timestamppb.New(s.StartAt),
+		Status:     s.Status,
+		CreatedAt:  timestamppb.New(s.CreatedAt),
+		UpdatedAt:  timestamppb.New(s.UpdatedAt),
+	}
+	if s.EndAt != nil {
+		schedule.EndAt = timestamppb.New(*s.EndAt)
+	}
+	if s.PreviousPrice != nil {
+		schedule.PreviousPrice = s.PreviousPrice.ToProto()
+	}
+	if s.AppliedAt != nil {
+		schedule.AppliedAt = timestamppb.New(*s.AppliedAt)
+	}
+	if s.EndedAt != nil {
+		schedule.EndedAt = timestamppb.New(*s.EndedAt)
+	}
+	return schedule
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"sort"
 	"time"
 
 	productService "github.com/chuuch/product-microservice/proto/product"
@@ -15,13 +16,51 @@ type Product struct {
 	CategoryID  primitive.ObjectID `json:"category_id" bson:"category_id,omitempty"`
 	Name        string             `json:"name" bson:"name,omitempty" validate:"required,min=3,max=100"`
 	Description string             `json:"description"`
-	Price       float64            `json:"price" bson:"price,omitempty" validate:"required"`
+	Price       Money              `json:"price" bson:"price,omitempty"`
+	Prices      []Money            `json:"prices,omitempty" bson:"prices,omitempty" validate:"dive"` // explicit prices in other currencies
 	ImageURL    *string            `json:"image_url" bson:"image_url,omitempty"`
 	Photos      []string           `json:"photos" bson:"photos,omitempty"`
-	Quantity    int64              `json:"quantity" bson:"quantity,omitempty" validate:"required"`
-	Rating      int64              `json:"rating" bson:"rating,omitempty" validate:"required,min=0,max=10"`
+	Quantity    int64              `json:"quantity" bson:"quantity,omitempty" validate:"min=0"`
+	Options     []VariantOption    `json:"options,omitempty" bson:"options,omitempty" validate:"dive"`
+	Variants    []*SKU             `json:"variants,omitempty" bson:"-"`
 	CreatedAt   time.Time          `json:"created_at" bson:"created_at,omitempty"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at,omitempty"`
+	Version     int64              `json:"version" bson:"version,omitempty"` // incremented by every write, an update with a version only applies to that version
+
+	// Computed from the accepted reviews, never written by product updates
+	RatingAverage float64 `json:"rating_average" bson:"rating_average,omitempty"`
+	RatingCount   int64   `json:"rating_count" bson:"rating_count,omitempty"`
+	RatingSum     int64   `json:"-" bson:"rating_sum,omitempty"`
+}
+
+// Update mask paths of the fields a product update may change, with their struct field names.
+// The paths are the proto, json and bson names of the fields.
+var productMaskFields = map[string]string{
+	"category_id": "CategoryID",
+	"name":        "Name",
+	"description": "Description",
+	"price":       "Price",
+	"prices":      "Prices",
+	"image_url":   "ImageURL",
+	"photos":      "Photos",
+	"quantity":    "Quantity",
+	"options":     "Options",
+}
+
+// ProductMaskPaths every update mask path, the mask of a full update
+func ProductMaskPaths() []string {
+	paths := make([]string, 0, len(productMaskFields))
+	for path := range productMaskFields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ProductMaskField struct field name of the update mask path
+func ProductMaskField(path string) (string, bool) {
+	field, ok := productMaskFields[path]
+	return field, ok
 }
 
 // Get Image url helper
@@ -33,6 +72,19 @@ func (p *Product) GetImageURL() string {
 	return imageURL
 }
 
+// PriceIn explicit price of the product in the currency, ok is false when it has to be converted
+func (p *Product) PriceIn(currency string) (Money, bool) {
+	if p.Price.Currency == currency {
+		return p.Price, true
+	}
+	for _, price := range p.Prices {
+		if price.Currency == currency {
+			return price, true
+		}
+	}
+	return Money{}, false
+}
+
 // ToProto Convert product to proto
 func (p *Product) ToProto() *productService.Product {
 	return &productService.Product{
@@ -40,13 +92,19 @@ func (p *Product) ToProto() *productService.Product {
 		CategoryId:  p.CategoryID.String(),
 		Name:        p.Name,
 		Description: p.Description,
-		Price:       p.Price,
+		Price:       p.Price.ToProto(),
+		Prices:      MoneyListToProto(p.Prices),
 		ImageUrl:    p.GetImageURL(),
 		Photos:      p.Photos,
 		Quantity:    p.Quantity,
-		Rating:      int64(p.Rating),
+		Options:     VariantOptionsToProto(p.Options),
+		Variants:    SKUsToProto(p.Variants),
 		CreatedAt:   timestamppb.New(p.CreatedAt),
 		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+
+		RatingAverage: p.RatingAverage,
+		RatingCount:   p.RatingCount,
+		Version:       p.Version,
 	}
 }
 
@@ -67,16 +125,33 @@ func ProductFromProto(product *productService.Product) (*Product, error) {
 		CategoryID:  catID,
 		Name:        product.GetName(),
 		Description: product.GetDescription(),
-		Price:       product.GetPrice(),
+		Price:       MoneyFromProto(product.GetPrice()),
+		Prices:      MoneyListFromProto(product.GetPrices()),
 		ImageURL:    &product.ImageUrl,
 		Photos:      product.GetPhotos(),
 		Quantity:    product.GetQuantity(),
-		Rating:      int64(product.GetRating()),
+		Options:     VariantOptionsFromProto(product.GetOptions()),
 		CreatedAt:   product.GetCreatedAt().AsTime(),
 		UpdatedAt:   product.GetUpdatedAt().AsTime(),
+
+		RatingAverage: product.GetRatingAverage(),
+		RatingCount:   product.GetRatingCount(),
+		Version:       product.GetVersion(),
 	}, nil
 }
 
+// ProductFilter selects the products of a catalog scan, in id order after AfterID
+type ProductFilter struct {
+	CategoryID *primitive.ObjectID
+	AfterID    *primitive.ObjectID
+}
+
+// ProductUpdate state of a product before and after an update, Previous is nil when it was not read
+type ProductUpdate struct {
+	Previous *Product
+	Updated  *Product
+}
+
 // ProductsList All products response with pagination
 type ProductsList struct {
 	TotalCount int64      `json:"total_count"`
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusAccepted = "accepted"
+	ReviewStatusRejected = "rejected"
+
+	ReviewSortHelpful = "helpful"
+	ReviewSortRecent  = "recent"
+)
+
+// Review of a product by a user, counted in the product rating once accepted
+type Review struct {
+	ReviewID         primitive.ObjectID `json:"review_id" bson:"_id,omitempty"`
+	ProductID        primitive.ObjectID `json:"product_id" bson:"product_id" validate:"required"`
+	UserID           string             `json:"user_id" bson:"user_id" validate:"required"`
+	Rating           int64              `json:"rating" bson:"rating" validate:"required,min=1,max=5"`
+	Title            string             `json:"title" bson:"title" validate:"max=200"`
+	Body             string             `json:"body" bson:"body" validate:"max=5000"`
+	VerifiedPurchase bool               `json:"verified_purchase" bson:"verified_purchase"`
+	Status           string             `json:"status" bson:"status"`
+	ModerationNote   string             `json:"moderation_note,omitempty" bson:"moderation_note,omitempty"`
+	HelpfulCount     int64              `json:"helpful_count" bson:"helpful_count"`
+	ModeratedBy      string             `json:"moderated_by,omitempty" bson:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time         `json:"moderated_at,omitempty" bson:"moderated_at,omitempty"`
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+// ToProto Convert review to proto
+func (r *Review) ToProto() *productService.Review {
+	review := &productService.Review{
+		ReviewId:         r.ReviewID.Hex(),
+		ProductId:        r.ProductID.Hex(),
+		UserId:           r.UserID,
+		Rating:           r.Rating,
+		Title:            r.Title,
+		Body:             r.Body,
+		VerifiedPurchase: r.VerifiedPurchase,
+		Status:           r.Status,
+		ModerationNote:   r.ModerationNote,
+		HelpfulCount:     r.HelpfulCount,
+		ModeratedBy:      r.ModeratedBy,
+		CreatedAt:        timestamppb.New(r.CreatedAt),
+		UpdatedAt:        timestamppb.New(r.UpdatedAt),
+	}
+	if r.ModeratedAt != nil {
+		review.ModeratedAt = timestamppb.New(*r.ModeratedAt)
+	}
+	return review
+}
+
+// ReviewQuery filter and order of a reviews listing, nil ProductID lists all products
+type ReviewQuery struct {
+	ProductID *primitive.ObjectID
+	Status    string `validate:"omitempty,oneof=pending accepted rejected"`
+	Sort      string `validate:"omitempty,oneof=helpful recent"`
+}
+
+// ReviewModeration decision of a moderator, nil VerifiedPurchase keeps the flag
+type ReviewModeration struct {
+	ReviewID         primitive.ObjectID `json:"-" validate:"required"`
+	Status           string             `json:"status" validate:"required,oneof=accepted rejected"`
+	ModerationNote   string             `json:"moderation_note" validate:"max=500"`
+	VerifiedPurchase *bool              `json:"verified_purchase"`
+}
+
+// ReviewsList reviews response with pagination
+type ReviewsList struct {
+	TotalCount int64     `json:"total_count"`
+	TotalPages int64     `json:"total_pages"`
+	Page       int64     `json:"page"`
+	Size       int64     `json:"size"`
+	HasMore    bool      `json:"has_more"`
+	Reviews    []*Review `json:"reviews"`
+}
+
+// ToProtoList convert reviews list to proto
+func (r *ReviewsList) ToProtoList() []*productService.Review {
+	reviewList := make([]*productService.Review, 0, len(r.Reviews))
+	for _, review := range r.Reviews {
+		reviewList = append(reviewList, review.ToProto())
+	}
+	return reviewList
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"time"
+
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VariantOption axis of a product, e.g. size with values S, M, L
+type VariantOption struct {
+	Name   string   `json:"name" bson:"name" validate:"required"`
+	Values []string `json:"values" bson:"values" validate:"required,min=1,dive,required"`
+}
+
+// SKU a sellable variant of a product with its own stock
+type SKU struct {
+	SKUID     primitive.ObjectID `json:"sku_id" bson:"_id,omitempty"`
+	ProductID primitive.ObjectID `json:"product_id" bson:"product_id"`
+	Code      string             `json:"code" bson:"code" validate:"required,max=64"`
+	Options   map[string]string  `json:"options" bson:"options"`
+	Price     *Money             `json:"price,omitempty" bson:"price,omitempty"` // overrides the product price when set
+	Quantity  int64              `json:"quantity" bson:"quantity" validate:"min=0"`
+	Barcode   string             `json:"barcode" bson:"barcode,omitempty"`
+	Images    []string           `json:"images" bson:"images,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+// GetPrice price of the sku, falls back to the product price
+func (s *SKU) GetPrice(product *Product) Money {
+	if s.Price != nil {
+		return *s.Price
+	}
+	return product.Price
+}
+
+// MatchesOptions checks the sku has exactly one allowed value for every product option axis
+func (s *SKU) MatchesOptions(options []VariantOption) bool {
+	if len(s.Options) != len(options) {
+		return false
+	}
+	for _, option := range options {
+		value, ok := s.Options[option.Name]
+		if !ok {
+			return false
+		}
+		allowed := false
+		for _, v := range option.Values {
+			if v == value {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// ToProto Convert sku to proto
+func (s *SKU) ToProto() *productService.SKU {
+	sku := &productService.SKU{
+		SkuId:     s.SKUID.Hex(),
+		ProductId: s.ProductID.Hex(),
+		Code:      s.Code,
+		Options:   s.Options,
+		Quantity:  s.Quantity,
+		Barcode:   s.Barcode,
+		Images:    s.Images,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+	if s.Price != nil {
+		sku.Price = s.Price.ToProto()
+	}
+	return sku
+}
+
+// SKUFromProto Convert proto to sku
+func SKUFromProto(sku *productService.SKU) *SKU {
+	return &SKU{
+		Code:     sku.GetCode(),
+		Options:  sku.GetOptions(),
+		Price:    MoneyPtrFromProto(sku.GetPrice()),
+		Quantity: sku.GetQuantity(),
+		Barcode:  sku.GetBarcode(),
+		Images:   sku.GetImages(),
+	}
+}
+
+// VariantOptionsToProto Convert variant options to proto
+func VariantOptionsToProto(options []VariantOption) []*productService.VariantOption {
+	protoOptions := make([]*productService.VariantOption, 0, len(options))
+	for _, option := range options {
+		protoOptions = append(protoOptions, &productService.VariantOption{Name: option.Name, Values: option.Values})
+	}
+	return protoOptions
+}
+
+// VariantOptionsFromProto Convert proto to variant options
+func VariantOptionsFromProto(options []*productService.VariantOption) []VariantOption {
+	variantOptions := make([]VariantOption, 0, len(options))
+	for _, option := range options {
+		variantOptions = append(variantOptions, VariantOption{Name: option.GetName(), Values: option.GetValues()})
+	}
+	return variantOptions
+}
+
+// SKUsToProto Convert skus to proto
+func SKUsToProto(skus []*SKU) []*productService.SKU {
+	protoSKUs := make([]*productService.SKU, 0, len(skus))
+	for _, sku := range skus {
+		protoSKUs = append(protoSKUs, sku.ToProto())
+	}
+	return protoSKUs
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	SubscriptionKindBackInStock = "back_in_stock"
+	SubscriptionKindPriceDrop   = "price_drop"
+)
+
+// Subscription of a user to a product change, fires once until the user subscribes again
+type Subscription struct {
+	SubscriptionID primitive.ObjectID `json:"subscription_id" bson:"_id,omitempty"`
+	UserID         string             `json:"user_id" bson:"user_id" validate:"required"`
+	Email          string             `json:"email" bson:"email" validate:"required,email"`
+	ProductID      primitive.ObjectID `json:"product_id" bson:"product_id" validate:"required"`
+	Kind           string             `json:"kind" bson:"kind" validate:"required,oneof=back_in_stock price_drop"`
+	Threshold      *Money             `json:"threshold,omitempty" bson:"threshold"` // price drop target, nil fires on any drop
+	FiredAt        *time.Time         `json:"fired_at,omitempty" bson:"fired_at"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at,omitempty"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+// ToProto Convert subscription to proto
+func (s *Subscription) ToProto() *productService.Subscription {
+	subscription := &productService.Subscription{
+		SubscriptionId: s.SubscriptionID.Hex(),
+		UserId:         s.UserID,
+		Email:          s.Email,
+		ProductId:      s.ProductID.Hex(),
+		Kind:           s.Kind,
+		CreatedAt:      timestamppb.New(s.CreatedAt),
+		UpdatedAt:      timestamppb.New(s.UpdatedAt),
+	}
+	if s.Threshold != nil {
+		subscription.Threshold = s.Threshold.ToProto()
+	}
+	if s.FiredAt != nil {
+		subscription.FiredAt = timestamppb.New(*s.FiredAt)
+	}
+	return subscription
+}
+
+// ProductNotification event published to the notifications topic when a subscription fires
+type ProductNotification struct {
+	SubscriptionID string `json:"subscription_id"`
+	Kind           string `json:"kind"`
+	UserID         string `json:"user_id"`
+	Email          string `json:"email"`
+	ProductID      string `json:"product_id"`
+	Name           string `json:"name"`
+	Price          string `json:"price"`
+	OldPrice       string `json:"old_price"`
+	Quantity       int64  `json:"quantity"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// UserRoleAdmin role allowed to moderate reviews
+const UserRoleAdmin = "admin"
+
+// User authenticated by the auth service session
+type User struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+// IsAdmin user has the admin role
+func (u *User) IsAdmin() bool {
+	return u != nil && u.Role == UserRoleAdmin
+}
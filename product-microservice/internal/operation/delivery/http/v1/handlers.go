@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/operation"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+)
+
+type operationHandlers struct {
+	log         logger.Logger
+	operationUC operation.UseCase
+	group       *echo.Group
+}
+
+func NewOperationHandlers(log logger.Logger, operationUC operation.UseCase, group *echo.Group) *operationHandlers {
+	return &operationHandlers{
+		log:         log,
+		operationUC: operationUC,
+		group:       group,
+	}
+}
+
+// GetOperationByID status of the operation with the resulting product or the error
+func (h *operationHandlers) GetOperationByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "operationHandlers.GetOperationByID")
+		defer span.Finish()
+
+		op, err := h.operationUC.GetOperationByID(ctx, c.Param("operation_id"))
+		if err != nil {
+			h.log.Errorf("operationUC.GetOperationByID: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, op)
+	}
+}
@@ -0,0 +1,6 @@
+package v1
+
+// MapRoutes operations routes
+func (h *operationHandlers) MapRoutes() {
+	h.group.GET("/operations/:operation_id", h.GetOperationByID())
+}
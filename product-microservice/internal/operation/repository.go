@@ -0,0 +1,13 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+)
+
+// RedisRepository Operation
+type RedisRepository interface {
+	SetOperation(ctx context.Context, operation *models.Operation) error
+	GetOperationByID(ctx context.Context, operationID string) (*models.Operation, error)
+}
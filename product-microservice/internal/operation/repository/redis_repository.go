@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	prefix     = "operations"
+	expiration = time.Hour * 24 // how long clients can poll an operation
+)
+
+type operationRedisRepo struct {
+	prefix string
+	redis  *redis.Client
+}
+
+// NewOperationRedisRepository constructor
+func NewOperationRedisRepository(redis *redis.Client) *operationRedisRepo {
+	return &operationRedisRepo{
+		prefix: prefix,
+		redis:  redis,
+	}
+}
+
+// SetOperation set operation in redis, every write restarts the expiration
+func (r *operationRedisRepo) SetOperation(ctx context.Context, operation *models.Operation) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationRedisRepo.SetOperation")
+	defer span.Finish()
+
+	operationBytes, err := json.Marshal(operation)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal failed")
+	}
+
+	return r.redis.Set(ctx, r.createKey(operation.OperationID), string(operationBytes), expiration).Err()
+}
+
+// GetOperationByID get operation by id from redis
+func (r *operationRedisRepo) GetOperationByID(ctx context.Context, operationID string) (*models.Operation, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationRedisRepo.GetOperationByID")
+	defer span.Finish()
+
+	result, err := r.redis.Get(ctx, r.createKey(operationID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, errors.Wrap(productErrors.ErrOperationNotFound, operationID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "redis.Get failed")
+	}
+
+	var res models.Operation
+	if err := json.Unmarshal(result, &res); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal failed")
+	}
+
+	return &res, nil
+}
+
+func (r *operationRedisRepo) createKey(operationID string) string {
+	return fmt.Sprintf("%s:%s", r.prefix, operationID)
+}
@@ -0,0 +1,20 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HeaderOperationID Kafka header carrying the operation id of a published write
+const HeaderOperationID = "operation_id"
+
+// UseCase operation
+type UseCase interface {
+	StartOperation(ctx context.Context, operationType string, productID *primitive.ObjectID) (*models.Operation, error)
+	StartOperationWithID(ctx context.Context, operationID string, operationType string, productID *primitive.ObjectID) (*models.Operation, error)
+	CompleteOperation(ctx context.Context, operationID string, product *models.Product) error
+	FailOperation(ctx context.Context, operationID string, cause error) error
+	GetOperationByID(ctx context.Context, operationID string) (*models.Operation, error)
+}
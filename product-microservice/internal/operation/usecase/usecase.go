@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/operation"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var completedOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "products_completed_operations_total",
+	Help: "Total number of completed asynchronous operations",
+}, []string{"type", "status"})
+
+type operationUC struct {
+	operationRepo operation.RedisRepository
+	log           logger.Logger
+}
+
+func NewOperationUC(operationRepo operation.RedisRepository, log logger.Logger) *operationUC {
+	return &operationUC{operationRepo: operationRepo, log: log}
+}
+
+// StartOperation records a pending operation, it is started before the write is published
+func (u *operationUC) StartOperation(ctx context.Context, operationType string, productID *primitive.ObjectID) (*models.Operation, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationUC.StartOperation")
+	defer span.Finish()
+
+	return u.StartOperationWithID(ctx, primitive.NewObjectID().Hex(), operationType, productID)
+}
+
+// StartOperationWithID records a pending operation with an id chosen by the caller beforehand
+func (u *operationUC) StartOperationWithID(ctx context.Context, operationID string, operationType string, productID *primitive.ObjectID) (*models.Operation, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationUC.StartOperationWithID")
+	defer span.Finish()
+
+	now := time.Now().UTC()
+	op := &models.Operation{
+		OperationID: operationID,
+		Type:        operationType,
+		Status:      models.OperationStatusPending,
+		ProductID:   productID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.operationRepo.SetOperation(ctx, op); err != nil {
+		return nil, errors.Wrap(err, "operationRepo.SetOperation")
+	}
+
+	return op, nil
+}
+
+// CompleteOperation records the resulting product, messages without an operation id are ignored
+func (u *operationUC) CompleteOperation(ctx context.Context, operationID string, product *models.Product) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationUC.CompleteOperation")
+	defer span.Finish()
+
+	return u.finish(ctx, operationID, func(op *models.Operation) {
+		op.Status = models.OperationStatusSucceeded
+		op.ProductID = &product.ProductID
+		op.Product = product
+		op.Error = ""
+	})
+}
+
+// FailOperation records the cause, messages without an operation id are ignored
+func (u *operationUC) FailOperation(ctx context.Context, operationID string, cause error) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationUC.FailOperation")
+	defer span.Finish()
+
+	return u.finish(ctx, operationID, func(op *models.Operation) {
+		op.Status = models.OperationStatusFailed
+		op.Error = cause.Error()
+	})
+}
+
+func (u *operationUC) GetOperationByID(ctx context.Context, operationID string) (*models.Operation, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "operationUC.GetOperationByID")
+	defer span.Finish()
+
+	return u.operationRepo.GetOperationByID(ctx, operationID)
+}
+
+func (u *operationUC) finish(ctx context.Context, operationID string, apply func(op *models.Operation)) error {
+	if operationID == "" {
+		return nil
+	}
+
+	op, err := u.operationRepo.GetOperationByID(ctx, operationID)
+	if err != nil {
+		return errors.Wrap(err, "operationRepo.GetOperationByID")
+	}
+
+	apply(op)
+	op.UpdatedAt = time.Now().UTC()
+	if err := u.operationRepo.SetOperation(ctx, op); err != nil {
+		return errors.Wrap(err, "operationRepo.SetOperation")
+	}
+
+	completedOperations.WithLabelValues(op.Type, op.Status).Inc()
+	return nil
+}
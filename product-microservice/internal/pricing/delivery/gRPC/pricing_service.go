@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/pricing"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PricingGRPCService gRPC service
+type PricingGRPCService struct {
+	productService.UnimplementedPricingServiceServer
+	pricingUC pricing.UseCase
+	log       logger.Logger
+}
+
+// PricingGRPCService constructor
+func NewPricingGRPCService(pricingUC pricing.UseCase, log logger.Logger) *PricingGRPCService {
+	return &PricingGRPCService{
+		pricingUC: pricingUC,
+		log:       log,
+	}
+}
+
+func (p *PricingGRPCService) GetPriceHistory(ctx context.Context, req *productService.GetPriceHistoryRequest) (*productService.GetPriceHistoryResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "PricingGRPCService.GetPriceHistory")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	history, err := p.pricingUC.GetPriceHistory(ctx, productID, utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage())))
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("pricingUC.GetPriceHistory: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	changes := make([]*productService.PriceChange, 0, len(history.PriceChanges))
+	for _, change := range history.PriceChanges {
+		changes = append(changes, change.ToProto())
+	}
+
+	successMessages.Inc()
+
+	return &productService.GetPriceHistoryResponse{
+		TotalCount:   history.TotalCount,
+		TotalPages:   history.TotalPages,
+		Page:         history.Page,
+		Size:         history.Size,
+		HasMore:      history.HasMore,
+		PriceChanges: changes,
+	}, nil
+}
+
+func (p *PricingGRPCService) SchedulePriceChange(ctx context.Context, req *productService.SchedulePriceChangeRequest) (*productService.SchedulePriceChangeResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "PricingGRPCService.SchedulePriceChange")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	schedule := &models.ScheduledPriceChange{
+		ProductID: productID,
+		Price:     models.MoneyFromProto(req.GetPrice()),
+		StartAt:   req.GetStartAt().AsTime(),
+	}
+	if req.GetEndAt() != nil {
+		endAt := req.GetEndAt().AsTime()
+		schedule.EndAt = &endAt
+	}
+
+	created, err := p.pricingUC.SchedulePriceChange(ctx, schedule)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("pricingUC.SchedulePriceChange: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.SchedulePriceChangeResponse{Schedule: created.ToProto()}, nil
+}
+
+func (p *PricingGRPCService) CancelScheduledPriceChange(ctx context.Context, req *productService.CancelScheduledPriceChangeRequest) (*productService.CancelScheduledPriceChangeResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "PricingGRPCService.CancelScheduledPriceChange")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	scheduleID, err := primitive.ObjectIDFromHex(req.GetScheduleId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	cancelled, err := p.pricingUC.CancelScheduledPriceChange(ctx, scheduleID)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("pricingUC.CancelScheduledPriceChange: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.CancelScheduledPriceChangeResponse{Schedule: cancelled.ToProto()}, nil
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/pricing"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type pricingHandlers struct {
+	log       logger.Logger
+	pricingUC pricing.UseCase
+	group     *echo.Group
+}
+
+func NewPricingHandlers(log logger.Logger, pricingUC pricing.UseCase, group *echo.Group) *pricingHandlers {
+	return &pricingHandlers{
+		log:       log,
+		pricingUC: pricingUC,
+		group:     group,
+	}
+}
+
+func (h *pricingHandlers) GetPriceHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "pricingHandlers.GetPriceHistory")
+		defer span.Finish()
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		pq := &utils.Pagination{}
+		if err := pq.SetSize(c.QueryParam("size")); err != nil {
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		if err := pq.SetPage(c.QueryParam("page")); err != nil {
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		history, err := h.pricingUC.GetPriceHistory(ctx, productID, pq)
+		if err != nil {
+			h.log.Errorf("pricingUC.GetPriceHistory: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, history)
+	}
+}
+
+func (h *pricingHandlers) SchedulePriceChange() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "pricingHandlers.SchedulePriceChange")
+		defer span.Finish()
+
+		var schedule models.ScheduledPriceChange
+		if err := c.Bind(&schedule); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		schedule.ProductID = productID
+
+		created, err := h.pricingUC.SchedulePriceChange(ctx, &schedule)
+		if err != nil {
+			h.log.Errorf("pricingUC.SchedulePriceChange: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created)
+	}
+}
+
+func (h *pricingHandlers) CancelScheduledPriceChange() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "pricingHandlers.CancelScheduledPriceChange")
+		defer span.Finish()
+
+		scheduleID, err := primitive.ObjectIDFromHex(c.Param("schedule_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		cancelled, err := h.pricingUC.CancelScheduledPriceChange(ctx, scheduleID)
+		if err != nil {
+			h.log.Errorf("pricingUC.CancelScheduledPriceChange: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, cancelled)
+	}
+}
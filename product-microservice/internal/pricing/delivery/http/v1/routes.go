@@ -0,0 +1,8 @@
+package v1
+
+// MapRoutes pricing routes
+func (h *pricingHandlers) MapRoutes() {
+	h.group.GET("/:product_id/price-history", h.GetPriceHistory())
+	h.group.POST("/:product_id/price-schedules", h.SchedulePriceChange())
+	h.group.DELETE("/price-schedules/:schedule_id", h.CancelScheduledPriceChange())
+}
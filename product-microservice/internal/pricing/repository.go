@@ -0,0 +1,23 @@
+package pricing
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Pricing repository interface
+type MongoRepository interface {
+	AppendPriceChange(ctx context.Context, change *models.PriceChange) error
+	GetPriceHistory(ctx context.Context, productID primitive.ObjectID, pagination *utils.Pagination) (*models.PriceHistory, error)
+	CreateSchedule(ctx context.Context, schedule *models.ScheduledPriceChange) (*models.ScheduledPriceChange, error)
+	GetScheduleByID(ctx context.Context, scheduleID primitive.ObjectID) (*models.ScheduledPriceChange, error)
+	HasOverlappingSchedule(ctx context.Context, productID primitive.ObjectID, startAt time.Time, endAt *time.Time) (bool, error)
+	FindDueStarts(ctx context.Context, now time.Time, limit int64) ([]*models.ScheduledPriceChange, error)
+	FindDueEnds(ctx context.Context, now time.Time, limit int64) ([]*models.ScheduledPriceChange, error)
+	Activate(ctx context.Context, scheduleID primitive.ObjectID, previousPrice models.Money) (bool, error)
+	End(ctx context.Context, scheduleID primitive.ObjectID, fromStatus, toStatus string) (*models.ScheduledPriceChange, error)
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	pricingDB                = "products"
+	priceHistoryCollection   = "price_history"
+	priceSchedulesCollection = "price_schedules"
+)
+
+type pricingMongoRepo struct {
+	mongoDB *mongo.Client
+}
+
+// PricingMongo Constructor
+func NewPricingMongoRepository(mongoDB *mongo.Client) *pricingMongoRepo {
+	return &pricingMongoRepo{
+		mongoDB: mongoDB,
+	}
+}
+
+func (p *pricingMongoRepo) history() *mongo.Collection {
+	return p.mongoDB.Database(pricingDB).Collection(priceHistoryCollection)
+}
+
+func (p *pricingMongoRepo) schedules() *mongo.Collection {
+	return p.mongoDB.Database(pricingDB).Collection(priceSchedulesCollection)
+}
+
+// CreateIndexes history listed per product newest first, due schedules found by status and time
+func (p *pricingMongoRepo) CreateIndexes(ctx context.Context) error {
+	if _, err := p.history().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "changed_at", Value: -1}},
+	}); err != nil {
+		return errors.Wrap(err, "history.Indexes.CreateOne")
+	}
+
+	if _, err := p.schedules().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "start_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "end_at", Value: 1}}},
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}}},
+	}); err != nil {
+		return errors.Wrap(err, "schedules.Indexes.CreateMany")
+	}
+
+	return nil
+}
+
+func (p *pricingMongoRepo) AppendPriceChange(ctx context.Context, change *models.PriceChange) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.AppendPriceChange")
+	defer span.Finish()
+
+	if change.ChangedAt.IsZero() {
+		change.ChangedAt = time.Now().UTC()
+	}
+
+	if _, err := p.history().InsertOne(ctx, change); err != nil {
+		return errors.Wrap(err, "InsertOne failed")
+	}
+
+	return nil
+}
+
+func (p *pricingMongoRepo) GetPriceHistory(ctx context.Context, productID primitive.ObjectID, pagination *utils.Pagination) (*models.PriceHistory, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.GetPriceHistory")
+	defer span.Finish()
+
+	filter := bson.M{"product_id": productID}
+
+	count, err := p.history().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "CountDocuments failed")
+	}
+
+	if count == 0 {
+		return &models.PriceHistory{PriceChanges: make([]*models.PriceChange, 0)}, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "changed_at", Value: -1}}).
+		SetLimit(int64(pagination.GetLimit())).
+		SetSkip(int64(pagination.GetOffset()))
+
+	cursor, err := p.history().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	changes := make([]*models.PriceChange, 0, pagination.GetSize())
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return &models.PriceHistory{
+		TotalCount:   count,
+		TotalPages:   int64(pagination.GetTotalPages(int(count))),
+		Page:         int64(pagination.GetPage()),
+		Size:         int64(pagination.GetSize()),
+		HasMore:      pagination.GetHasMore(int(count)),
+		PriceChanges: changes,
+	}, nil
+}
+
+func (p *pricingMongoRepo) CreateSchedule(ctx context.Context, schedule *models.ScheduledPriceChange) (*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.CreateSchedule")
+	defer span.Finish()
+
+	schedule.CreatedAt = time.Now().UTC()
+	schedule.UpdatedAt = time.Now().UTC()
+
+	result, err := p.schedules().InsertOne(ctx, schedule)
+	if err != nil {
+		return nil, errors.Wrap(err, "InsertOne failed")
+	}
+
+	if objectID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		schedule.ScheduleID = objectID
+	}
+
+	return schedule, nil
+}
+
+func (p *pricingMongoRepo) GetScheduleByID(ctx context.Context, scheduleID primitive.ObjectID) (*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.GetScheduleByID")
+	defer span.Finish()
+
+	var schedule models.ScheduledPriceChange
+	if err := p.schedules().FindOne(ctx, bson.M{"_id": scheduleID}).Decode(&schedule); err != nil {
+		return nil, errors.Wrap(err, "FindOne failed")
+	}
+
+	return &schedule, nil
+}
+
+// HasOverlappingSchedule pending or active schedules of the product intersecting [startAt, endAt), nil end is open
+func (p *pricingMongoRepo) HasOverlappingSchedule(ctx context.Context, productID primitive.ObjectID, startAt time.Time, endAt *time.Time) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.HasOverlappingSchedule")
+	defer span.Finish()
+
+	filter := bson.M{
+		"product_id": productID,
+		"status":     bson.M{"$in": bson.A{models.ScheduleStatusPending, models.ScheduleStatusActive}},
+		"$or": bson.A{
+			bson.M{"end_at": nil},
+			bson.M{"end_at": bson.M{"$gt": startAt}},
+		},
+	}
+	if endAt != nil {
+		filter["start_at"] = bson.M{"$lt": *endAt}
+	}
+
+	count, err := p.schedules().CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, errors.Wrap(err, "CountDocuments failed")
+	}
+
+	return count > 0, nil
+}
+
+func (p *pricingMongoRepo) FindDueStarts(ctx context.Context, now time.Time, limit int64) ([]*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.FindDueStarts")
+	defer span.Finish()
+
+	return p.findDue(ctx, bson.M{
+		"status":   models.ScheduleStatusPending,
+		"start_at": bson.M{"$lte": now},
+	}, "start_at", limit)
+}
+
+func (p *pricingMongoRepo) FindDueEnds(ctx context.Context, now time.Time, limit int64) ([]*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.FindDueEnds")
+	defer span.Finish()
+
+	return p.findDue(ctx, bson.M{
+		"status": models.ScheduleStatusActive,
+		"end_at": bson.M{"$ne": nil, "$lte": now},
+	}, "end_at", limit)
+}
+
+func (p *pricingMongoRepo) findDue(ctx context.Context, filter bson.M, sortBy string, limit int64) ([]*models.ScheduledPriceChange, error) {
+	cursor, err := p.schedules().Find(ctx, filter, options.Find().SetSort(bson.D{{Key: sortBy, Value: 1}}).SetLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	schedules := make([]*models.ScheduledPriceChange, 0)
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return schedules, nil
+}
+
+// Activate claims a pending schedule and stores the price it replaces, false when another replica claimed it
+func (p *pricingMongoRepo) Activate(ctx context.Context, scheduleID primitive.ObjectID, previousPrice models.Money) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.Activate")
+	defer span.Finish()
+
+	now := time.Now().UTC()
+	result, err := p.schedules().UpdateOne(
+		ctx,
+		bson.M{"_id": scheduleID, "status": models.ScheduleStatusPending},
+		bson.M{"$set": bson.M{
+			"status":         models.ScheduleStatusActive,
+			"previous_price": previousPrice,
+			"applied_at":     now,
+			"updated_at":     now,
+		}},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "UpdateOne failed")
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// End moves a schedule from one status to a final one, mongo.ErrNoDocuments when it is no longer in fromStatus
+func (p *pricingMongoRepo) End(ctx context.Context, scheduleID primitive.ObjectID, fromStatus, toStatus string) (*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingMongoRepo.End")
+	defer span.Finish()
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	now := time.Now().UTC()
+	var schedule models.ScheduledPriceChange
+	if err := p.schedules().FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": scheduleID, "status": fromStatus},
+		bson.M{"$set": bson.M{"status": toStatus, "ended_at": now, "updated_at": now}},
+		&opts,
+	).Decode(&schedule); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &schedule, nil
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/pricing"
+	"github.com/chuuch/product-microservice/pkg/logger"
+)
+
+const (
+	defaultInterval = 30 * time.Second
+)
+
+// PriceScheduler applies and reverts scheduled price changes, safe to run on every replica
+type PriceScheduler struct {
+	pricingUC pricing.UseCase
+	log       logger.Logger
+	interval  time.Duration
+}
+
+// PriceScheduler constructor
+func NewPriceScheduler(pricingUC pricing.UseCase, log logger.Logger, interval time.Duration) *PriceScheduler {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &PriceScheduler{
+		pricingUC: pricingUC,
+		log:       log,
+		interval:  interval,
+	}
+}
+
+// Run ticks until the context is cancelled
+func (s *PriceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.log.Infof("Price scheduler is running every %v", s.interval)
+
+	for {
+		if err := s.pricingUC.ApplyDueChanges(ctx, time.Now().UTC()); err != nil {
+			s.log.Errorf("pricingUC.ApplyDueChanges: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
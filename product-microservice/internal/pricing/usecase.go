@@ -0,0 +1,19 @@
+package pricing
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UseCase pricing
+type UseCase interface {
+	RecordPriceChange(ctx context.Context, previous *models.Product, updated *models.Product, source string) error
+	GetPriceHistory(ctx context.Context, productID primitive.ObjectID, pagination *utils.Pagination) (*models.PriceHistory, error)
+	SchedulePriceChange(ctx context.Context, schedule *models.ScheduledPriceChange) (*models.ScheduledPriceChange, error)
+	CancelScheduledPriceChange(ctx context.Context, scheduleID primitive.ObjectID) (*models.ScheduledPriceChange, error)
+	ApplyDueChanges(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/pricing"
+	"github.com/chuuch/product-microservice/internal/product"
+	"github.com/chuuch/product-microservice/internal/subscription"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	dueBatchSize = 100
+)
+
+var scheduledPriceChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "products_scheduled_price_changes_total",
+	Help: "Total number of scheduled price changes applied or reverted",
+}, []string{"action"})
+
+type pricingUC struct {
+	pricingRepo    pricing.MongoRepository
+	productRepo    product.MongoRepository
+	productCache   product.Cache
+	subscriptionUC subscription.UseCase
+	log            logger.Logger
+	validate       *validator.Validate
+}
+
+func NewPricingUC(
+	pricingRepo pricing.MongoRepository,
+	productRepo product.MongoRepository,
+	productCache product.Cache,
+	subscriptionUC subscription.UseCase,
+	log logger.Logger,
+	validate *validator.Validate,
+) *pricingUC {
+	return &pricingUC{
+		pricingRepo:    pricingRepo,
+		productRepo:    productRepo,
+		productCache:   productCache,
+		subscriptionUC: subscriptionUC,
+		log:            log,
+		validate:       validate,
+	}
+}
+
+// RecordPriceChange appends to the history when the price differs from the previous state, previous is nil for new products
+func (u *pricingUC) RecordPriceChange(ctx context.Context, previous *models.Product, updated *models.Product, source string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingUC.RecordPriceChange")
+	defer span.Finish()
+
+	if updated == nil || updated.Price.IsZero() {
+		return nil
+	}
+
+	var oldPrice *models.Money
+	if previous != nil && !previous.Price.IsZero() {
+		if previous.Price == updated.Price {
+			return nil
+		}
+		oldPrice = &previous.Price
+	}
+
+	return u.pricingRepo.AppendPriceChange(ctx, &models.PriceChange{
+		ProductID: updated.ProductID,
+		OldPrice:  oldPrice,
+		NewPrice:  updated.Price,
+		Source:    source,
+	})
+}
+
+func (u *pricingUC) GetPriceHistory(ctx context.Context, productID primitive.ObjectID, pagination *utils.Pagination) (*models.PriceHistory, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingUC.GetPriceHistory")
+	defer span.Finish()
+
+	return u.pricingRepo.GetPriceHistory(ctx, productID, pagination)
+}
+
+func (u *pricingUC) SchedulePriceChange(ctx context.Context, schedule *models.ScheduledPriceChange) (*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingUC.SchedulePriceChange")
+	defer span.Finish()
+
+	if err := u.validate.StructCtx(ctx, schedule); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx")
+	}
+
+	if schedule.EndAt != nil && !schedule.EndAt.After(schedule.StartAt) {
+		return nil, errors.Wrapf(productErrors.ErrInvalidSchedule, "start: %v, end: %v", schedule.StartAt, *schedule.EndAt)
+	}
+
+	prod, err := u.productRepo.GetProductByID(ctx, schedule.ProductID)
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.GetProductByID")
+	}
+
+	if _, err := schedule.Price.Compare(prod.Price); err != nil {
+		return nil, err
+	}
+
+	overlaps, err := u.pricingRepo.HasOverlappingSchedule(ctx, schedule.ProductID, schedule.StartAt, schedule.EndAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "pricingRepo.HasOverlappingSchedule")
+	}
+	if overlaps {
+		return nil, errors.Wrapf(productErrors.ErrScheduleOverlap, "product: %s", schedule.ProductID.Hex())
+	}
+
+	schedule.Status = models.ScheduleStatusPending
+	schedule.PreviousPrice = nil
+	schedule.AppliedAt = nil
+	schedule.EndedAt = nil
+
+	return u.pricingRepo.CreateSchedule(ctx, schedule)
+}
+
+// CancelScheduledPriceChange cancels a pending schedule, an active one is reverted to the price it replaced
+func (u *pricingUC) CancelScheduledPriceChange(ctx context.Context, scheduleID primitive.ObjectID) (*models.ScheduledPriceChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingUC.CancelScheduledPriceChange")
+	defer span.Finish()
+
+	schedule, err := u.pricingRepo.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "pricingRepo.GetScheduleByID")
+	}
+
+	if schedule.Status != models.ScheduleStatusPending && schedule.Status != models.ScheduleStatusActive {
+		return nil, errors.Wrapf(productErrors.ErrScheduleNotCancellable, "status: %s", schedule.Status)
+	}
+
+	cancelled, err := u.pricingRepo.End(ctx, scheduleID, schedule.Status, models.ScheduleStatusCancelled)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(productErrors.ErrScheduleNotCancellable, "schedule changed concurrently")
+		}
+		return nil, errors.Wrap(err, "pricingRepo.End")
+	}
+
+	if schedule.Status == models.ScheduleStatusActive {
+		if err := u.revert(ctx, cancelled); err != nil {
+			return nil, err
+		}
+	}
+
+	return cancelled, nil
+}
+
+// ApplyDueChanges reverts ended schedules before applying started ones so back to back windows hand over cleanly
+func (u *pricingUC) ApplyDueChanges(ctx context.Context, now time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pricingUC.ApplyDueChanges")
+	defer span.Finish()
+
+	ends, err := u.pricingRepo.FindDueEnds(ctx, now, dueBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "pricingRepo.FindDueEnds")
+	}
+
+	for _, due := range ends {
+		schedule, err := u.pricingRepo.End(ctx, due.ScheduleID, models.ScheduleStatusActive, models.ScheduleStatusCompleted)
+		if err != nil {
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				u.log.Errorf("pricingRepo.End: %v", err)
+			}
+			continue
+		}
+
+		if err := u.revert(ctx, schedule); err != nil {
+			u.log.Errorf("pricingUC.revert: %v", err)
+		}
+	}
+
+	starts, err := u.pricingRepo.FindDueStarts(ctx, now, dueBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "pricingRepo.FindDueStarts")
+	}
+
+	for _, schedule := range starts {
+		if err := u.apply(ctx, schedule); err != nil {
+			u.log.Errorf("pricingUC.apply: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *pricingUC) apply(ctx context.Context, schedule *models.ScheduledPriceChange) error {
+	previous, err := u.productRepo.GetProductByID(ctx, schedule.ProductID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			_, err := u.pricingRepo.End(ctx, schedule.ScheduleID, models.ScheduleStatusPending, models.ScheduleStatusCancelled)
+			return errors.Wrapf(err, "product %s no longer exists", schedule.ProductID.Hex())
+		}
+		return errors.Wrap(err, "productRepo.GetProductByID")
+	}
+
+	// Claiming with the current price lets only one replica apply it and keeps what to revert to
+	claimed, err := u.pricingRepo.Activate(ctx, schedule.ScheduleID, previous.Price)
+	if err != nil {
+		return errors.Wrap(err, "pricingRepo.Activate")
+	}
+	if !claimed {
+		return nil
+	}
+
+	updated, err := u.productRepo.SetPrice(ctx, schedule.ProductID, schedule.Price, nil)
+	if err != nil {
+		return errors.Wrap(err, "productRepo.SetPrice")
+	}
+	u.evictProduct(ctx, schedule.ProductID)
+
+	if err := u.appendScheduled(ctx, schedule, &previous.Price, schedule.Price, models.PriceChangeSourceSchedule); err != nil {
+		return err
+	}
+
+	scheduledPriceChanges.WithLabelValues("applied").Inc()
+	u.log.Infof("Applied scheduled price %s to product %s", schedule.Price, schedule.ProductID.Hex())
+
+	if err := u.subscriptionUC.NotifySubscribers(ctx, previous, updated); err != nil {
+		u.log.Errorf("subscriptionUC.NotifySubscribers: %v", err)
+	}
+
+	if schedule.EndAt == nil {
+		if _, err := u.pricingRepo.End(ctx, schedule.ScheduleID, models.ScheduleStatusActive, models.ScheduleStatusCompleted); err != nil {
+			return errors.Wrap(err, "pricingRepo.End")
+		}
+	}
+
+	return nil
+}
+
+// revert restores the replaced price unless the price was changed by hand while the schedule was active
+func (u *pricingUC) revert(ctx context.Context, schedule *models.ScheduledPriceChange) error {
+	if schedule.PreviousPrice == nil {
+		return nil
+	}
+
+	if _, err := u.productRepo.SetPrice(ctx, schedule.ProductID, *schedule.PreviousPrice, &schedule.Price); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			u.log.Infof("Not reverting schedule %s, price of product %s changed since it was applied", schedule.ScheduleID.Hex(), schedule.ProductID.Hex())
+			return nil
+		}
+		return errors.Wrap(err, "productRepo.SetPrice")
+	}
+	u.evictProduct(ctx, schedule.ProductID)
+
+	if err := u.appendScheduled(ctx, schedule, &schedule.Price, *schedule.PreviousPrice, models.PriceChangeSourceRevert); err != nil {
+		return err
+	}
+
+	scheduledPriceChanges.WithLabelValues("reverted").Inc()
+	u.log.Infof("Reverted scheduled price of product %s to %s", schedule.ProductID.Hex(), schedule.PreviousPrice)
+
+	return nil
+}
+
+func (u *pricingUC) appendScheduled(ctx context.Context, schedule *models.ScheduledPriceChange, oldPrice *models.Money, newPrice models.Money, source string) error {
+	if err := u.pricingRepo.AppendPriceChange(ctx, &models.PriceChange{
+		ProductID:  schedule.ProductID,
+		OldPrice:   oldPrice,
+		NewPrice:   newPrice,
+		Source:     source,
+		ScheduleID: &schedule.ScheduleID,
+	}); err != nil {
+		return errors.Wrap(err, "pricingRepo.AppendPriceChange")
+	}
+	return nil
+}
+
+// evictProduct drops the cached product after a price change, the next read caches the new price
+func (u *pricingUC) evictProduct(ctx context.Context, productID primitive.ObjectID) {
+	if err := u.productCache.InvalidateProduct(ctx, productID); err != nil {
+		u.log.Errorf("productCache.InvalidateProduct: %v", err)
+	}
+}
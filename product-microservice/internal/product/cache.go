@@ -0,0 +1,21 @@
+package product
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cache of products in front of the database, an in-process tier over the redis tier shared by every replica
+type Cache interface {
+	GetProduct(ctx context.Context, productID primitive.ObjectID, load func(ctx context.Context) (*models.Product, error)) (*models.Product, error)
+	GetProducts(ctx context.Context, productIDs []primitive.ObjectID, load func(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error)) (map[primitive.ObjectID]*models.Product, error)
+	SetProduct(ctx context.Context, product *models.Product) error
+	SetProducts(ctx context.Context, products []*models.Product) error
+	InvalidateProduct(ctx context.Context, productID primitive.ObjectID) error
+	InvalidateProducts(ctx context.Context, productIDs []primitive.ObjectID) error
+	GetSearch(ctx context.Context, key string, load func(ctx context.Context) (*models.ProductsList, error)) (*models.ProductsList, error)
+	InvalidateSearches(ctx context.Context, categoryIDs ...primitive.ObjectID) error
+	Run(ctx context.Context)
+}
@@ -0,0 +1,619 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/product"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/lru"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	resubscribeDelay = time.Second // wait before subscribing to invalidations again after the subscription failed
+	emptyTag         = "empty"     // tag of the search result pages without products, any new product may join them
+)
+
+var (
+	lookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "products_cache_lookups_total",
+		Help: "Total number of product lookups by the tier that answered, local, redis or database",
+	}, []string{"tier"})
+
+	searchLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "products_search_cache_lookups_total",
+		Help: "Total number of search result page lookups by result, hit or miss",
+	}, []string{"result"})
+)
+
+// entry of the local tier, a nil product caches that the product does not exist
+type entry struct {
+	product *models.Product
+}
+
+// generation of a product with loads in flight, evict bumps it and a load only caches what it read
+// when the generation did not change meanwhile. batches are the keys of the batch loads reading the product.
+type generation struct {
+	value   uint64
+	loads   int
+	batches []string
+}
+
+type productCache struct {
+	redisRepo   product.RedisRepository
+	log         logger.Logger
+	local       *lru.Cache[primitive.ObjectID, entry]
+	localTTL    time.Duration
+	negativeTTL time.Duration
+	searchTTL   time.Duration
+	loads       singleflight.Group
+
+	mu          sync.Mutex // guards generations and orders the local writes of loads with evict
+	generations map[primitive.ObjectID]*generation
+}
+
+// NewProductCache constructor, the local tier holds at most localSize products for localTTL.
+// Unknown product ids are cached in both tiers for negativeTTL, search result pages in redis for searchTTL,
+// a zero searchTTL disables the search cache.
+func NewProductCache(
+	redisRepo product.RedisRepository,
+	log logger.Logger,
+	localSize int,
+	localTTL time.Duration,
+	negativeTTL time.Duration,
+	searchTTL time.Duration,
+) *productCache {
+	return &productCache{
+		redisRepo:   redisRepo,
+		log:         log,
+		local:       lru.New[primitive.ObjectID, entry](localSize),
+		localTTL:    localTTL,
+		negativeTTL: negativeTTL,
+		searchTTL:   searchTTL,
+		generations: make(map[primitive.ObjectID]*generation),
+	}
+}
+
+// GetProduct cached product, concurrent misses of the same id share one redis read and one call of load.
+// A missing product fails with mongo.ErrNoDocuments like the database does.
+func (c *productCache) GetProduct(
+	ctx context.Context,
+	productID primitive.ObjectID,
+	load func(ctx context.Context) (*models.Product, error),
+) (*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.GetProduct")
+	defer span.Finish()
+
+	if cached, ok := c.local.Get(productID); ok {
+		lookups.WithLabelValues("local").Inc()
+		return copyProduct(cached.product)
+	}
+
+	// The shared load outlives a caller that gives up, the others still wait for it
+	loadCtx := context.WithoutCancel(ctx)
+	result := c.loads.DoChan(productID.Hex(), func() (interface{}, error) {
+		return c.load(loadCtx, productID, load)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return copyProduct(res.Val.(*models.Product))
+	}
+}
+
+// GetProducts cached products of the ids like GetProduct, the misses of both tiers are read with one call of load.
+// Concurrent batches missing the same ids share one redis read and one call of load, unknown ids are absent from the map.
+func (c *productCache) GetProducts(
+	ctx context.Context,
+	productIDs []primitive.ObjectID,
+	load func(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error),
+) (map[primitive.ObjectID]*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.GetProducts")
+	defer span.Finish()
+
+	products := make(map[primitive.ObjectID]*models.Product, len(productIDs))
+	misses := make([]primitive.ObjectID, 0, len(productIDs))
+	seen := make(map[primitive.ObjectID]bool, len(productIDs))
+	for _, productID := range productIDs {
+		if seen[productID] {
+			continue
+		}
+		seen[productID] = true
+
+		if cached, ok := c.local.Get(productID); ok {
+			lookups.WithLabelValues("local").Inc()
+			if cached.product != nil {
+				copied := *cached.product
+				products[productID] = &copied
+			}
+			continue
+		}
+		misses = append(misses, productID)
+	}
+	if len(misses) == 0 {
+		return products, nil
+	}
+
+	loadCtx := context.WithoutCancel(ctx)
+	key := batchKey(misses)
+	result := c.loads.DoChan(key, func() (interface{}, error) {
+		return c.loadBatch(loadCtx, key, misses, load)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		for productID, product := range res.Val.(map[primitive.ObjectID]*models.Product) {
+			if product != nil {
+				copied := *product
+				products[productID] = &copied
+			}
+		}
+		return products, nil
+	}
+}
+
+// SetProduct caches a written product and drops the older copies of every replica
+func (c *productCache) SetProduct(ctx context.Context, product *models.Product) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.SetProduct")
+	defer span.Finish()
+
+	if err := c.redisRepo.SetProduct(ctx, product); err != nil {
+		return errors.Wrap(err, "redisRepo.SetProduct")
+	}
+	return c.publishInvalidations(ctx, []primitive.ObjectID{product.ProductID})
+}
+
+// SetProducts caches written products with one redis pipeline, like SetProduct
+func (c *productCache) SetProducts(ctx context.Context, products []*models.Product) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.SetProducts")
+	defer span.Finish()
+
+	if err := c.redisRepo.SetProducts(ctx, products); err != nil {
+		return errors.Wrap(err, "redisRepo.SetProducts")
+	}
+
+	productIDs := make([]primitive.ObjectID, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ProductID)
+	}
+	return c.publishInvalidations(ctx, productIDs)
+}
+
+// InvalidateProduct drops the product from both tiers of every replica and the search result pages showing it,
+// the next read loads it again
+func (c *productCache) InvalidateProduct(ctx context.Context, productID primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.InvalidateProduct")
+	defer span.Finish()
+
+	return c.InvalidateProducts(ctx, []primitive.ObjectID{productID})
+}
+
+// InvalidateProducts drops the products like InvalidateProduct, with one call per redis operation
+func (c *productCache) InvalidateProducts(ctx context.Context, productIDs []primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.InvalidateProducts")
+	defer span.Finish()
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	if err := c.redisRepo.DeleteProducts(ctx, productIDs); err != nil {
+		return errors.Wrap(err, "redisRepo.DeleteProducts")
+	}
+	if err := c.publishInvalidations(ctx, productIDs); err != nil {
+		return err
+	}
+	if c.searchTTL > 0 {
+		tags := make([]string, 0, len(productIDs))
+		for _, productID := range productIDs {
+			tags = append(tags, productTag(productID))
+		}
+		if err := c.redisRepo.DeleteSearches(ctx, tags); err != nil {
+			return errors.Wrap(err, "redisRepo.DeleteSearches")
+		}
+	}
+	return nil
+}
+
+// GetSearch cached search result page of the key, concurrent misses of the same key share one call of load.
+// The page is tagged with the categories and products it shows.
+func (c *productCache) GetSearch(
+	ctx context.Context,
+	key string,
+	load func(ctx context.Context) (*models.ProductsList, error),
+) (*models.ProductsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.GetSearch")
+	defer span.Finish()
+
+	if c.searchTTL <= 0 {
+		return load(ctx)
+	}
+
+	loadCtx := context.WithoutCancel(ctx)
+	result := c.loads.DoChan("search:"+key, func() (interface{}, error) {
+		return c.loadSearch(loadCtx, key, load)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return copyProductsList(res.Val.(*models.ProductsList)), nil
+	}
+}
+
+// InvalidateSearches drops the search result pages showing products of the categories and the empty pages,
+// the pages a new product of the category joins are among them
+func (c *productCache) InvalidateSearches(ctx context.Context, categoryIDs ...primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productCache.InvalidateSearches")
+	defer span.Finish()
+
+	if c.searchTTL <= 0 {
+		return nil
+	}
+
+	tags := make([]string, 0, len(categoryIDs)+1)
+	tags = append(tags, emptyTag)
+	for _, categoryID := range categoryIDs {
+		tags = append(tags, categoryTag(categoryID))
+	}
+	if err := c.redisRepo.DeleteSearches(ctx, tags); err != nil {
+		return errors.Wrap(err, "redisRepo.DeleteSearches")
+	}
+	return nil
+}
+
+// Run drops the local copies of the products other replicas invalidate until the context is cancelled
+func (c *productCache) Run(ctx context.Context) {
+	c.log.Info("Product cache is listening for invalidations")
+
+	for {
+		if err := c.redisRepo.SubscribeInvalidations(ctx, c.evict); err != nil {
+			c.log.Errorf("redisRepo.SubscribeInvalidations: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (c *productCache) load(
+	ctx context.Context,
+	productID primitive.ObjectID,
+	load func(ctx context.Context) (*models.Product, error),
+) (*models.Product, error) {
+	gen := c.startLoad(productID)
+	defer c.finishLoad(productID)
+
+	cached, err := c.redisRepo.GetProductByID(ctx, productID)
+	if err == nil || errors.Is(err, mongo.ErrNoDocuments) {
+		lookups.WithLabelValues("redis").Inc()
+		c.setLocal(productID, gen, cached)
+		return cached, err
+	}
+	// A redis failure only costs the database read
+	if !errors.Is(err, redis.Nil) {
+		c.log.Errorf("redisRepo.GetProductByID: %v", err)
+	}
+
+	lookups.WithLabelValues("database").Inc()
+	loaded, err := load(ctx)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	// An invalidation during the read means it may be stale, it is returned but not cached
+	if !c.isCurrent(productID, gen) {
+		return loaded, err
+	}
+	if err != nil {
+		if err := c.redisRepo.SetProductMissing(ctx, productID, c.negativeTTL); err != nil {
+			c.log.Errorf("redisRepo.SetProductMissing: %v", err)
+		}
+		c.setLocal(productID, gen, nil)
+		return nil, err
+	}
+
+	if err := c.redisRepo.SetProduct(ctx, loaded); err != nil {
+		c.log.Errorf("redisRepo.SetProduct: %v", err)
+	}
+	c.setLocal(productID, gen, loaded)
+	return loaded, nil
+}
+
+// loadBatch reads the products like load, ids cached or loaded as missing map to nil
+func (c *productCache) loadBatch(
+	ctx context.Context,
+	key string,
+	productIDs []primitive.ObjectID,
+	load func(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error),
+) (map[primitive.ObjectID]*models.Product, error) {
+	gens := c.startBatch(key, productIDs)
+	defer c.finishBatch(key, productIDs)
+
+	// A redis failure only costs the database read
+	cached, err := c.redisRepo.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		c.log.Errorf("redisRepo.GetProductsByIDs: %v", err)
+	}
+
+	products := make(map[primitive.ObjectID]*models.Product, len(productIDs))
+	misses := make([]primitive.ObjectID, 0, len(productIDs))
+	for _, productID := range productIDs {
+		product, ok := cached[productID]
+		if !ok {
+			misses = append(misses, productID)
+			continue
+		}
+		lookups.WithLabelValues("redis").Inc()
+		c.setLocal(productID, gens[productID], product)
+		products[productID] = product
+	}
+	if len(misses) == 0 {
+		return products, nil
+	}
+
+	lookups.WithLabelValues("database").Add(float64(len(misses)))
+	loaded, err := load(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range loaded {
+		products[product.ProductID] = product
+	}
+
+	// The products invalidated during the read may be stale, they are returned but not cached
+	current := make([]*models.Product, 0, len(loaded))
+	missing := make([]primitive.ObjectID, 0)
+	for _, productID := range misses {
+		if !c.isCurrent(productID, gens[productID]) {
+			continue
+		}
+		if product, ok := products[productID]; ok {
+			current = append(current, product)
+		} else {
+			missing = append(missing, productID)
+		}
+	}
+
+	if err := c.redisRepo.SetProducts(ctx, current); err != nil {
+		c.log.Errorf("redisRepo.SetProducts: %v", err)
+	}
+	if err := c.redisRepo.SetProductsMissing(ctx, missing, c.negativeTTL); err != nil {
+		c.log.Errorf("redisRepo.SetProductsMissing: %v", err)
+	}
+	for _, product := range current {
+		c.setLocal(product.ProductID, gens[product.ProductID], product)
+	}
+	for _, productID := range missing {
+		c.setLocal(productID, gens[productID], nil)
+	}
+	return products, nil
+}
+
+func (c *productCache) loadSearch(
+	ctx context.Context,
+	key string,
+	load func(ctx context.Context) (*models.ProductsList, error),
+) (*models.ProductsList, error) {
+	cached, err := c.redisRepo.GetSearch(ctx, key)
+	if err == nil {
+		searchLookups.WithLabelValues("hit").Inc()
+		return cached, nil
+	}
+	// A redis failure only costs the database query
+	if !errors.Is(err, redis.Nil) {
+		c.log.Errorf("redisRepo.GetSearch: %v", err)
+	}
+
+	searchLookups.WithLabelValues("miss").Inc()
+	loaded, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.redisRepo.SetSearch(ctx, key, loaded, searchTags(loaded), c.searchTTL); err != nil {
+		c.log.Errorf("redisRepo.SetSearch: %v", err)
+	}
+	return loaded, nil
+}
+
+// setLocal caches the product read by the load of generation gen, unless the product was evicted since
+func (c *productCache) setLocal(productID primitive.ObjectID, gen uint64, product *models.Product) {
+	ttl := c.localTTL
+	if product == nil {
+		ttl = min(c.localTTL, c.negativeTTL)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[productID].value != gen {
+		return
+	}
+	c.local.Set(productID, entry{product: product}, ttl)
+}
+
+// evict drops the local copy, keeps loads in flight from caching what they read
+// and lets the next read start a new load instead of joining one that may be stale
+func (c *productCache) evict(productID primitive.ObjectID) {
+	var batches []string
+
+	c.mu.Lock()
+	if g, ok := c.generations[productID]; ok {
+		g.value++
+		batches = append(batches, g.batches...)
+	}
+	c.local.Delete(productID)
+	c.mu.Unlock()
+
+	c.loads.Forget(productID.Hex())
+	for _, key := range batches {
+		c.loads.Forget(key)
+	}
+}
+
+// startLoad registers a load of the product and returns its generation
+func (c *productCache) startLoad(productID primitive.ObjectID) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, ok := c.generations[productID]
+	if !ok {
+		g = &generation{}
+		c.generations[productID] = g
+	}
+	g.loads++
+	return g.value
+}
+
+// finishLoad forgets the generation once no load of the product is in flight
+func (c *productCache) finishLoad(productID primitive.ObjectID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g := c.generations[productID]
+	if g.loads--; g.loads == 0 {
+		delete(c.generations, productID)
+	}
+}
+
+// startBatch registers a batch load of the products like startLoad and returns their generations
+func (c *productCache) startBatch(key string, productIDs []primitive.ObjectID) map[primitive.ObjectID]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	gens := make(map[primitive.ObjectID]uint64, len(productIDs))
+	for _, productID := range productIDs {
+		g, ok := c.generations[productID]
+		if !ok {
+			g = &generation{}
+			c.generations[productID] = g
+		}
+		g.loads++
+		g.batches = append(g.batches, key)
+		gens[productID] = g.value
+	}
+	return gens
+}
+
+// finishBatch forgets the batch load of the products like finishLoad
+func (c *productCache) finishBatch(key string, productIDs []primitive.ObjectID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, productID := range productIDs {
+		g := c.generations[productID]
+		for i, batch := range g.batches {
+			if batch == key {
+				g.batches = append(g.batches[:i], g.batches[i+1:]...)
+				break
+			}
+		}
+		if g.loads--; g.loads == 0 {
+			delete(c.generations, productID)
+		}
+	}
+}
+
+// isCurrent whether the product was not evicted since the load of generation gen started
+func (c *productCache) isCurrent(productID primitive.ObjectID, gen uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generations[productID].value == gen
+}
+
+func (c *productCache) publishInvalidations(ctx context.Context, productIDs []primitive.ObjectID) error {
+	for _, productID := range productIDs {
+		c.evict(productID)
+	}
+	if err := c.redisRepo.PublishInvalidations(ctx, productIDs); err != nil {
+		return errors.Wrap(err, "redisRepo.PublishInvalidations")
+	}
+	return nil
+}
+
+// searchTags the categories and products of the page, an empty page has the empty tag
+func searchTags(list *models.ProductsList) []string {
+	if len(list.Products) == 0 {
+		return []string{emptyTag}
+	}
+
+	tags := make([]string, 0, 2*len(list.Products))
+	seen := make(map[primitive.ObjectID]bool, len(list.Products))
+	for _, product := range list.Products {
+		tags = append(tags, productTag(product.ProductID))
+		if !seen[product.CategoryID] {
+			seen[product.CategoryID] = true
+			tags = append(tags, categoryTag(product.CategoryID))
+		}
+	}
+	return tags
+}
+
+// batchKey singleflight key of a batch load, the same ids in any order share it
+func batchKey(productIDs []primitive.ObjectID) string {
+	hexes := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		hexes = append(hexes, productID.Hex())
+	}
+	sort.Strings(hexes)
+	return "batch:" + strings.Join(hexes, ",")
+}
+
+func categoryTag(categoryID primitive.ObjectID) string {
+	return "category:" + categoryID.Hex()
+}
+
+func productTag(productID primitive.ObjectID) string {
+	return "product:" + productID.Hex()
+}
+
+// copyProductsList copies the page and its products, like copyProduct
+func copyProductsList(list *models.ProductsList) *models.ProductsList {
+	copied := *list
+	copied.Products = make([]*models.Product, 0, len(list.Products))
+	for _, product := range list.Products {
+		p := *product
+		copied.Products = append(copied.Products, &p)
+	}
+	return &copied
+}
+
+// copyProduct callers change the returned product, so the cached one is never handed out
+func copyProduct(product *models.Product) (*models.Product, error) {
+	if product == nil {
+		return nil, errors.Wrap(mongo.ErrNoDocuments, "cached as missing")
+	}
+	copied := *product
+	return &copied, nil
+}
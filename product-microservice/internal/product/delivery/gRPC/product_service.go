@@ -2,18 +2,31 @@ package grpc
 
 import (
 	"context"
+	"io"
+	"sort"
 
+	"github.com/chuuch/product-microservice/internal/idempotency"
 	"github.com/chuuch/product-microservice/internal/models"
 	"github.com/chuuch/product-microservice/internal/product"
 	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
 	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
 	"github.com/chuuch/product-microservice/pkg/utils"
 	productService "github.com/chuuch/product-microservice/proto/product"
 	"github.com/go-playground/validator/v10"
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// Products of a BatchCreateProducts stream written per bulk write
+const batchCreateChunkSize = 500
+
 // ProductGRPCService gRPC service
 type ProductGRPCService struct {
 	productService.UnimplementedProductServiceServer
@@ -36,40 +49,84 @@ func (p *ProductGRPCService) CreateProduct(ctx context.Context, req *productServ
 	defer span.Finish()
 	incommingMessages.Inc()
 
-	catID, err := primitive.ObjectIDFromHex(req.GetCategoryId())
+	product, err := productFromCreateRequest(req)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	created, err := p.productUC.CreateProductOnce(ctx, idempotencyKey(ctx), product)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.CreateProductOnce: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.CreateResponse{
+		Product: created.ToProto(),
+	}, nil
+}
+
+func (p *ProductGRPCService) UpdateProduct(ctx context.Context, req *productService.UpdateRequest) (*productService.UpdateResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.UpdateProduct")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
 	if err != nil {
 		errorMessages.Inc()
 		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
 		return nil, grpcerrors.ErrorResponse(err, err.Error())
 	}
 
+	var catID primitive.ObjectID
+	if req.GetCategoryId() != "" {
+		catID, err = primitive.ObjectIDFromHex(req.GetCategoryId())
+		if err != nil {
+			p.log.Errorf("primitve.ObjectIDFromHex: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
+	}
+
 	product := &models.Product{
+		ProductID:   productID,
 		CategoryID:  catID,
 		Name:        req.GetName(),
 		Description: req.GetDescription(),
-		Price:       req.GetPrice(),
+		Price:       models.MoneyFromProto(req.GetPrice()),
+		Prices:      models.MoneyListFromProto(req.GetPrices()),
 		ImageURL:    &req.ImageUrl,
 		Photos:      req.GetPhotos(),
 		Quantity:    req.GetQuantity(),
-		Rating:      int64(req.GetRating()),
+		Options:     models.VariantOptionsFromProto(req.GetOptions()),
+		Version:     req.GetExpectedVersion(),
 	}
 
-	created, err := p.productUC.CreateProduct(ctx, product)
+	fields, err := updateMaskPaths(req)
 	if err != nil {
 		errorMessages.Inc()
-		p.log.Errorf("productUC.CreateProduct: %v", err)
+		p.log.Errorf("updateMaskPaths: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	update, err := p.productUC.UpdateProduct(ctx, product, fields)
+	if err != nil {
+		p.log.Errorf("productUC.UpdateProduct: %v", err)
 		return nil, grpcerrors.ErrorResponse(err, err.Error())
 	}
 
 	successMessages.Inc()
 
-	return &productService.CreateResponse{
-		Product: created.ToProto(),
+	return &productService.UpdateResponse{
+		Product: update.Updated.ToProto(),
 	}, nil
 }
 
-func (p *ProductGRPCService) UpdateProduct(ctx context.Context, req *productService.UpdateRequest) (*productService.UpdateResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.UpdateProduct")
+func (p *ProductGRPCService) UpsertProduct(ctx context.Context, req *productService.UpsertRequest) (*productService.UpsertResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.UpsertProduct")
 	defer span.Finish()
 	incommingMessages.Inc()
 
@@ -80,10 +137,14 @@ func (p *ProductGRPCService) UpdateProduct(ctx context.Context, req *productServ
 		return nil, grpcerrors.ErrorResponse(err, err.Error())
 	}
 
-	catID, err := primitive.ObjectIDFromHex(req.GetCategoryId())
-	if err != nil {
-		p.log.Errorf("primitve.ObjectIDFromHex: %v", err)
-		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	var catID primitive.ObjectID
+	if req.GetCategoryId() != "" {
+		catID, err = primitive.ObjectIDFromHex(req.GetCategoryId())
+		if err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("primitve.ObjectIDFromHex: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
 	}
 
 	product := &models.Product{
@@ -91,23 +152,33 @@ func (p *ProductGRPCService) UpdateProduct(ctx context.Context, req *productServ
 		CategoryID:  catID,
 		Name:        req.GetName(),
 		Description: req.GetDescription(),
-		Price:       req.GetPrice(),
+		Price:       models.MoneyFromProto(req.GetPrice()),
+		Prices:      models.MoneyListFromProto(req.GetPrices()),
 		ImageURL:    &req.ImageUrl,
 		Photos:      req.GetPhotos(),
 		Quantity:    req.GetQuantity(),
-		Rating:      int64(req.GetRating()),
+		Options:     models.VariantOptionsFromProto(req.GetOptions()),
 	}
 
-	updated, err := p.productUC.UpdateProduct(ctx, product)
+	fields, err := updateMaskPaths(req)
 	if err != nil {
-		p.log.Errorf("productUC.UpdateProduct: %v", err)
+		errorMessages.Inc()
+		p.log.Errorf("updateMaskPaths: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	upserted, inserted, err := p.productUC.UpsertProduct(ctx, product, fields)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.UpsertProduct: %v", err)
 		return nil, grpcerrors.ErrorResponse(err, err.Error())
 	}
 
 	successMessages.Inc()
 
-	return &productService.UpdateResponse{
-		Product: updated.ToProto(),
+	return &productService.UpsertResponse{
+		Product:  upserted.ToProto(),
+		Inserted: inserted,
 	}, nil
 }
 
@@ -130,6 +201,14 @@ func (p *ProductGRPCService) FindByID(ctx context.Context, req *productService.F
 		return nil, grpcerrors.ErrorResponse(err, err.Error())
 	}
 
+	if req.GetCurrency() != "" {
+		if err := p.productUC.LocalizePrices(ctx, []*models.Product{product}, req.GetCurrency()); err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("productUC.LocalizePrices: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
+	}
+
 	successMessages.Inc()
 
 	return &productService.FindByIDResponse{Product: product.ToProto()}, nil
@@ -147,6 +226,14 @@ func (p *ProductGRPCService) SearchProduct(ctx context.Context, req *productServ
 		return nil, grpcerrors.ErrorResponse(err, err.Error())
 	}
 
+	if req.GetCurrency() != "" {
+		if err := p.productUC.LocalizePrices(ctx, products.Products, req.GetCurrency()); err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("productUC.LocalizePrices: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
+	}
+
 	successMessages.Inc()
 
 	return &productService.SearchResponse{
@@ -158,3 +245,354 @@ func (p *ProductGRPCService) SearchProduct(ctx context.Context, req *productServ
 		Products:   products.ToProtoList(),
 	}, nil
 }
+
+func (p *ProductGRPCService) CreateSKU(ctx context.Context, req *productService.CreateSKURequest) (*productService.CreateSKUResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.CreateSKU")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	created, err := p.productUC.CreateSKU(ctx, productID, models.SKUFromProto(req.GetSku()))
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.CreateSKU: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.CreateSKUResponse{Sku: created.ToProto()}, nil
+}
+
+func (p *ProductGRPCService) UpdateSKU(ctx context.Context, req *productService.UpdateSKURequest) (*productService.UpdateSKUResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.UpdateSKU")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	skuID, err := primitive.ObjectIDFromHex(req.GetSkuId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	sku := models.SKUFromProto(req.GetSku())
+	sku.SKUID = skuID
+
+	updated, err := p.productUC.UpdateSKU(ctx, sku)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.UpdateSKU: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.UpdateSKUResponse{Sku: updated.ToProto()}, nil
+}
+
+func (p *ProductGRPCService) DeleteSKU(ctx context.Context, req *productService.DeleteSKURequest) (*productService.DeleteSKUResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.DeleteSKU")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	skuID, err := primitive.ObjectIDFromHex(req.GetSkuId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	if err := p.productUC.DeleteSKU(ctx, skuID); err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.DeleteSKU: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.DeleteSKUResponse{}, nil
+}
+
+func (p *ProductGRPCService) ListSKUs(ctx context.Context, req *productService.ListSKUsRequest) (*productService.ListSKUsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.ListSKUs")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	skus, err := p.productUC.GetSKUsByProductID(ctx, productID)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.GetSKUsByProductID: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.ListSKUsResponse{Skus: models.SKUsToProto(skus)}, nil
+}
+
+func (p *ProductGRPCService) AdjustStock(ctx context.Context, req *productService.AdjustStockRequest) (*productService.AdjustStockResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.AdjustStock")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	skuID, err := primitive.ObjectIDFromHex(req.GetSkuId())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	sku, err := p.productUC.AdjustStock(ctx, skuID, req.GetDelta())
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.AdjustStock: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.AdjustStockResponse{Sku: sku.ToProto()}, nil
+}
+
+func (p *ProductGRPCService) GetProductsByIDs(ctx context.Context, req *productService.GetProductsByIDsRequest) (*productService.GetProductsByIDsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductGRPCService.GetProductsByIDs")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productIDs := make([]primitive.ObjectID, 0, len(req.GetProductIds()))
+	for _, id := range req.GetProductIds() {
+		productID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	products, err := p.productUC.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.GetProductsByIDs: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	if req.GetCurrency() != "" {
+		if err := p.productUC.LocalizePrices(ctx, products, req.GetCurrency()); err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("productUC.LocalizePrices: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
+	}
+
+	found := make(map[primitive.ObjectID]bool, len(products))
+	protoProducts := make([]*productService.Product, 0, len(products))
+	for _, product := range products {
+		found[product.ProductID] = true
+		protoProducts = append(protoProducts, product.ToProto())
+	}
+
+	missingIDs := make([]string, 0)
+	for _, productID := range productIDs {
+		if !found[productID] {
+			found[productID] = true
+			missingIDs = append(missingIDs, productID.Hex())
+		}
+	}
+
+	successMessages.Inc()
+
+	return &productService.GetProductsByIDsResponse{
+		Products:   protoProducts,
+		MissingIds: missingIDs,
+	}, nil
+}
+
+func (p *ProductGRPCService) ListProducts(req *productService.ListProductsRequest, stream grpc.ServerStreamingServer[productService.Product]) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "ProductGRPCService.ListProducts")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	filter := &models.ProductFilter{}
+	if req.GetCategoryId() != "" {
+		categoryID, err := primitive.ObjectIDFromHex(req.GetCategoryId())
+		if err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return grpcerrors.ErrorResponse(err, err.Error())
+		}
+		filter.CategoryID = &categoryID
+	}
+	if req.GetAfterProductId() != "" {
+		afterID, err := primitive.ObjectIDFromHex(req.GetAfterProductId())
+		if err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return grpcerrors.ErrorResponse(err, err.Error())
+		}
+		filter.AfterID = &afterID
+	}
+
+	if err := p.productUC.ListProducts(ctx, filter, func(product *models.Product) error {
+		return stream.Send(product.ToProto())
+	}); err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.ListProducts: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return nil
+}
+
+// BatchCreateProducts receives the stream in chunks, each chunk is one bulk write
+func (p *ProductGRPCService) BatchCreateProducts(stream grpc.ClientStreamingServer[productService.CreateRequest, productService.BatchCreateProductsResponse]) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "ProductGRPCService.BatchCreateProducts")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	res := &productService.BatchCreateProductsResponse{Results: make([]*productService.BatchCreateResult, 0)}
+	chunk := make([]*models.Product, 0, batchCreateChunkSize)
+	indexes := make([]int64, 0, batchCreateChunkSize)
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		itemErrors, err := p.productUC.BatchCreateProducts(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for i, product := range chunk {
+			result := &productService.BatchCreateResult{Index: indexes[i], ProductId: product.ProductID.Hex()}
+			if itemErrors[i] != nil {
+				result.ProductId = ""
+				result.Error = itemErrors[i].Error()
+			}
+			res.Results = append(res.Results, result)
+		}
+		chunk = chunk[:0]
+		indexes = indexes[:0]
+		return nil
+	}
+
+	for index := int64(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errorMessages.Inc()
+			p.log.Errorf("stream.Recv: %v", err)
+			return grpcerrors.ErrorResponse(err, err.Error())
+		}
+
+		product, err := productFromCreateRequest(req)
+		if err != nil {
+			res.Results = append(res.Results, &productService.BatchCreateResult{Index: index, Error: err.Error()})
+			continue
+		}
+		chunk = append(chunk, product)
+		indexes = append(indexes, index)
+
+		if len(chunk) == batchCreateChunkSize {
+			if err := flush(); err != nil {
+				errorMessages.Inc()
+				p.log.Errorf("productUC.BatchCreateProducts: %v", err)
+				return grpcerrors.ErrorResponse(err, err.Error())
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		errorMessages.Inc()
+		p.log.Errorf("productUC.BatchCreateProducts: %v", err)
+		return grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	sort.Slice(res.Results, func(i, j int) bool { return res.Results[i].Index < res.Results[j].Index })
+	for _, result := range res.Results {
+		if result.Error == "" {
+			res.CreatedCount++
+		} else {
+			res.FailedCount++
+		}
+	}
+
+	successMessages.Inc()
+
+	return stream.SendAndClose(res)
+}
+
+// idempotencyKey from the request metadata, empty when the client sent none
+func idempotencyKey(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, idempotency.MetadataIdempotencyKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// maskedRequest request changing the fields of its update mask
+type maskedRequest interface {
+	proto.Message
+	GetUpdateMask() *fieldmaskpb.FieldMask
+}
+
+// updateMaskPaths fields to change following AIP-134, the populated fields of the request without a mask
+// and every updatable field for the wildcard mask
+func updateMaskPaths(req maskedRequest) ([]string, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 1 && paths[0] == "*" {
+		return models.ProductMaskPaths(), nil
+	}
+	if len(paths) > 0 {
+		return paths, nil
+	}
+
+	populated := make([]string, 0)
+	req.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if _, ok := models.ProductMaskField(string(fd.Name())); ok {
+			populated = append(populated, string(fd.Name()))
+		}
+		return true
+	})
+	if len(populated) == 0 {
+		return nil, errors.Wrap(productErrors.ErrInvalidUpdateMask, "no mask and no field to update")
+	}
+	sort.Strings(populated)
+	return populated, nil
+}
+
+func productFromCreateRequest(req *productService.CreateRequest) (*models.Product, error) {
+	catID, err := primitive.ObjectIDFromHex(req.GetCategoryId())
+	if err != nil {
+		return nil, errors.Wrap(err, "primitive.ObjectIDFromHex")
+	}
+
+	return &models.Product{
+		CategoryID:  catID,
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Price:       models.MoneyFromProto(req.GetPrice()),
+		Prices:      models.MoneyListFromProto(req.GetPrices()),
+		ImageURL:    &req.ImageUrl,
+		Photos:      req.GetPhotos(),
+		Quantity:    req.GetQuantity(),
+		Options:     models.VariantOptionsFromProto(req.GetOptions()),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdateMaskPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     maskedRequest
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "absent mask changes the populated fields",
+			req: &productService.UpdateRequest{
+				ProductId:       "64b7f0c2e1a4b5c6d7e8f901",
+				Name:            "Lamp",
+				Quantity:        3,
+				Price:           &productService.Money{Amount: 1999, CurrencyCode: "USD"},
+				ExpectedVersion: 2,
+			},
+			want: []string{"name", "price", "quantity"},
+		},
+		{
+			name: "empty mask changes the populated fields",
+			req: &productService.UpdateRequest{
+				Description: "Desk lamp",
+				UpdateMask:  &fieldmaskpb.FieldMask{},
+			},
+			want: []string{"description"},
+		},
+		{
+			name:    "absent mask without populated fields",
+			req:     &productService.UpdateRequest{ProductId: "64b7f0c2e1a4b5c6d7e8f901", ExpectedVersion: 2},
+			wantErr: productErrors.ErrInvalidUpdateMask,
+		},
+		{
+			name: "wildcard replaces every field",
+			req: &productService.UpdateRequest{
+				Name:       "Lamp",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+			want: models.ProductMaskPaths(),
+		},
+		{
+			name: "explicit mask",
+			req: &productService.UpdateRequest{
+				Name:       "Lamp",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+			},
+			want: []string{"description"},
+		},
+		{
+			name: "absent mask of an upsert",
+			req:  &productService.UpsertRequest{ProductId: "64b7f0c2e1a4b5c6d7e8f901", Photos: []string{"a.png"}},
+			want: []string{"photos"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updateMaskPaths(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("updateMaskPaths() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("updateMaskPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"strconv"
+	"strings"
+
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/pkg/errors"
+)
+
+// Conditional request headers, RFC 9110
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// etag strong entity tag of a product version
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion version required by an If-Match header, 0 when the header is missing or "*"
+func ifMatchVersion(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, errors.Wrapf(productErrors.ErrVersionConflict, "If-Match %s is not a single product ETag", header)
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errors.Wrapf(productErrors.ErrVersionConflict, "If-Match %s is not a product version", header)
+	}
+	return version, nil
+}
+
+// noneMatch true when an If-None-Match header lists the current ETag
+func noneMatch(header string, version int64) bool {
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
@@ -1,21 +1,34 @@
 package v1
 
 import (
+	"encoding/json"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/chuuch/product-microservice/internal/idempotency"
 	"github.com/chuuch/product-microservice/internal/middleware"
 	"github.com/chuuch/product-microservice/internal/models"
 	"github.com/chuuch/product-microservice/internal/product"
 	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
 	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
 	"github.com/chuuch/product-microservice/pkg/utils"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Media type of JSON Merge Patch bodies, RFC 7386
+const mergePatchMediaType = "application/merge-patch+json"
+
+// Path the operations of asynchronous writes are polled at
+const operationsPath = "/api/v1/operations/"
+
 type productHandlers struct {
 	log       logger.Logger
 	productUC product.UseCase
@@ -54,14 +67,15 @@ func (h *productHandlers) CreateProduct() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
-		if err := h.productUC.PublishCreate(ctx, &prod); err != nil {
-			h.log.Errorf("productUC.CreateProduct: %v", err)
+		op, err := h.productUC.PublishCreate(ctx, &prod, c.Request().Header.Get(idempotency.HeaderIdempotencyKey))
+		if err != nil {
+			h.log.Errorf("productUC.PublishCreate: %v", err)
 			errorRequests.Inc()
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
 		successRequests.Inc()
-		return c.NoContent(http.StatusCreated)
+		return accepted(c, op)
 	}
 }
 
@@ -87,20 +101,138 @@ func (h *productHandlers) UpdateProduct() echo.HandlerFunc {
 		}
 		prod.ProductID = productID
 
+		// The update is applied asynchronously, an unknown product or a stale If-Match fails now
+		// and a product changed meanwhile fails again in the consumer
+		prod.Version, err = ifMatchVersion(c.Request().Header.Get(headerIfMatch))
+		if err != nil {
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+		current, err := h.productUC.GetProductByID(ctx, productID)
+		if err != nil {
+			h.log.Errorf("productUC.GetProductByID: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+		if prod.Version > 0 && current.Version != prod.Version {
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, errors.Wrapf(productErrors.ErrVersionConflict, "current version %d", current.Version))
+		}
+
 		if err := h.validate.StructCtx(ctx, prod); err != nil {
 			h.log.Errorf("validate.StructCtx: %v", err)
 			errorRequests.Inc()
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
-		if err := h.productUC.PublishUpdate(ctx, &prod); err != nil {
+		op, err := h.productUC.PublishUpdate(ctx, &prod)
+		if err != nil {
+			h.log.Errorf("productUC.PublishUpdate: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		successRequests.Inc()
+		return accepted(c, op)
+	}
+}
+
+// PatchProduct applies a JSON Merge Patch, only the fields present in the patch are written and null clears a field
+func (h *productHandlers) PatchProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.PatchProduct")
+		defer span.Finish()
+
+		updateRequests.Inc()
+
+		mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		if mediaType != mergePatchMediaType && mediaType != echo.MIMEApplicationJSON {
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewRestError(http.StatusUnsupportedMediaType, httpErrors.ErrUnsupportedMedia, mediaType))
+		}
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		patch, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			h.log.Errorf("io.ReadAll: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		// The top level members of the patch are the update mask
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(patch, &members); err != nil {
+			h.log.Errorf("json.Unmarshal: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		fields := make([]string, 0, len(members))
+		for field := range members {
+			fields = append(fields, field)
+		}
+		if len(fields) == 0 {
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError("empty merge patch"))
+		}
+
+		ifMatch, err := ifMatchVersion(c.Request().Header.Get(headerIfMatch))
+		if err != nil {
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		current, err := h.productUC.GetProductByID(ctx, productID)
+		if err != nil {
+			h.log.Errorf("productUC.GetProductByID: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		if ifMatch > 0 && current.Version != ifMatch {
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, errors.Wrapf(productErrors.ErrVersionConflict, "current version %d", current.Version))
+		}
+
+		currentBytes, err := json.Marshal(current)
+		if err != nil {
+			h.log.Errorf("json.Marshal: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		patchedBytes, err := utils.MergePatch(currentBytes, patch)
+		if err != nil {
+			h.log.Errorf("utils.MergePatch: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		var patched models.Product
+		if err := json.Unmarshal(patchedBytes, &patched); err != nil {
+			h.log.Errorf("json.Unmarshal: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+		patched.ProductID = productID
+		// The patch was merged into this version, a concurrent write makes it conflict instead of being lost
+		patched.Version = current.Version
+
+		update, err := h.productUC.UpdateProduct(ctx, &patched, fields)
+		if err != nil {
 			h.log.Errorf("productUC.UpdateProduct: %v", err)
 			errorRequests.Inc()
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
 		successRequests.Inc()
-		return c.NoContent(http.StatusOK)
+		c.Response().Header().Set(headerETag, etag(update.Updated.Version))
+		return c.JSON(http.StatusOK, update.Updated)
 	}
 }
 
@@ -125,11 +257,85 @@ func (h *productHandlers) GetProductByID() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
+		c.Response().Header().Set(headerETag, etag(product.Version))
+		if noneMatch(c.Request().Header.Get(headerIfNoneMatch), product.Version) {
+			successRequests.Inc()
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		if currency := c.QueryParam("currency"); currency != "" {
+			if err := h.productUC.LocalizePrices(ctx, []*models.Product{product}, currency); err != nil {
+				h.log.Errorf("productUC.LocalizePrices: %v", err)
+				errorRequests.Inc()
+				return httpErrors.ErrorCtxResponse(c, err)
+			}
+		}
+
 		successRequests.Inc()
 		return c.JSON(http.StatusOK, product)
 	}
 }
 
+// productsByIDsResponse products in the order of the ids, without variants
+type productsByIDsResponse struct {
+	Products   []*models.Product `json:"products"`
+	MissingIDs []string          `json:"missing_ids"`
+}
+
+// GetProductsByIDs looks up the comma separated ids query param in one call
+func (h *productHandlers) GetProductsByIDs() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.GetProductsByIDs")
+		defer span.Finish()
+
+		getRequests.Inc()
+
+		productIDs := make([]primitive.ObjectID, 0)
+		for _, id := range strings.Split(c.QueryParam("ids"), ",") {
+			if id = strings.TrimSpace(id); id == "" {
+				continue
+			}
+			productID, err := primitive.ObjectIDFromHex(id)
+			if err != nil {
+				h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+				errorRequests.Inc()
+				return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+			}
+			productIDs = append(productIDs, productID)
+		}
+
+		products, err := h.productUC.GetProductsByIDs(ctx, productIDs)
+		if err != nil {
+			h.log.Errorf("productUC.GetProductsByIDs: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		if currency := c.QueryParam("currency"); currency != "" {
+			if err := h.productUC.LocalizePrices(ctx, products, currency); err != nil {
+				h.log.Errorf("productUC.LocalizePrices: %v", err)
+				errorRequests.Inc()
+				return httpErrors.ErrorCtxResponse(c, err)
+			}
+		}
+
+		found := make(map[primitive.ObjectID]bool, len(products))
+		for _, product := range products {
+			found[product.ProductID] = true
+		}
+		res := productsByIDsResponse{Products: products, MissingIDs: make([]string, 0)}
+		for _, productID := range productIDs {
+			if !found[productID] {
+				found[productID] = true
+				res.MissingIDs = append(res.MissingIDs, productID.Hex())
+			}
+		}
+
+		successRequests.Inc()
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
 func (h *productHandlers) SearchProducts() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.SearchProducts")
@@ -160,7 +366,21 @@ func (h *productHandlers) SearchProducts() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
+		if currency := c.QueryParam("currency"); currency != "" {
+			if err := h.productUC.LocalizePrices(ctx, result.Products, currency); err != nil {
+				h.log.Errorf("productUC.LocalizePrices: %v", err)
+				errorRequests.Inc()
+				return httpErrors.ErrorCtxResponse(c, err)
+			}
+		}
+
 		successRequests.Inc()
 		return c.JSON(http.StatusBadRequest, result)
 	}
 }
+
+// accepted responds to a write applied by a consumer with the operation to poll
+func accepted(c echo.Context, op *models.Operation) error {
+	c.Response().Header().Set(echo.HeaderLocation, operationsPath+op.OperationID)
+	return c.JSON(http.StatusAccepted, op)
+}
@@ -35,4 +35,14 @@ var (
 		Name: "products_search_http_requests_total",
 		Help: "Total number of search HTTP requests",
 	})
+
+	skuRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "products_sku_http_requests_total",
+		Help: "Total number of sku HTTP requests",
+	})
+
+	stockRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "products_stock_http_requests_total",
+		Help: "Total number of stock adjustment HTTP requests",
+	})
 )
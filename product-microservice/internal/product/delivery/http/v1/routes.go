@@ -4,6 +4,13 @@ package v1
 func (h *productHandlers) MapRoutes() {
 	h.group.POST("", h.CreateProduct())
 	h.group.PUT("/:product_id", h.UpdateProduct())
+	h.group.PATCH("/:product_id", h.PatchProduct())
 	h.group.GET("/:product_id", h.GetProductByID())
 	h.group.GET("/search", h.SearchProducts())
+	h.group.GET("/batch", h.GetProductsByIDs())
+	h.group.POST("/:product_id/skus", h.CreateSKU())
+	h.group.GET("/:product_id/skus", h.GetSKUsByProductID())
+	h.group.PUT("/skus/:sku_id", h.UpdateSKU())
+	h.group.DELETE("/skus/:sku_id", h.DeleteSKU())
+	h.group.POST("/skus/:sku_id/stock", h.AdjustStock())
 }
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type adjustStockRequest struct {
+	Delta int64 `json:"delta"`
+}
+
+func (h *productHandlers) CreateSKU() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.CreateSKU")
+		defer span.Finish()
+
+		skuRequests.Inc()
+
+		var sku models.SKU
+		if err := c.Bind(&sku); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		created, err := h.productUC.CreateSKU(ctx, productID, &sku)
+		if err != nil {
+			h.log.Errorf("productUC.CreateSKU: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		successRequests.Inc()
+		return c.JSON(http.StatusCreated, created)
+	}
+}
+
+func (h *productHandlers) GetSKUsByProductID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.GetSKUsByProductID")
+		defer span.Finish()
+
+		skuRequests.Inc()
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		skus, err := h.productUC.GetSKUsByProductID(ctx, productID)
+		if err != nil {
+			h.log.Errorf("productUC.GetSKUsByProductID: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		successRequests.Inc()
+		return c.JSON(http.StatusOK, skus)
+	}
+}
+
+func (h *productHandlers) UpdateSKU() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.UpdateSKU")
+		defer span.Finish()
+
+		skuRequests.Inc()
+
+		var sku models.SKU
+		if err := c.Bind(&sku); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		skuID, err := primitive.ObjectIDFromHex(c.Param("sku_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		sku.SKUID = skuID
+
+		updated, err := h.productUC.UpdateSKU(ctx, &sku)
+		if err != nil {
+			h.log.Errorf("productUC.UpdateSKU: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		successRequests.Inc()
+		return c.JSON(http.StatusOK, updated)
+	}
+}
+
+func (h *productHandlers) DeleteSKU() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.DeleteSKU")
+		defer span.Finish()
+
+		skuRequests.Inc()
+
+		skuID, err := primitive.ObjectIDFromHex(c.Param("sku_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		if err := h.productUC.DeleteSKU(ctx, skuID); err != nil {
+			h.log.Errorf("productUC.DeleteSKU: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		successRequests.Inc()
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func (h *productHandlers) AdjustStock() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "productHandlers.AdjustStock")
+		defer span.Finish()
+
+		stockRequests.Inc()
+
+		var req adjustStockRequest
+		if err := c.Bind(&req); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		skuID, err := primitive.ObjectIDFromHex(c.Param("sku_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		sku, err := h.productUC.AdjustStock(ctx, skuID, req.Delta)
+		if err != nil {
+			h.log.Errorf("productUC.AdjustStock: %v", err)
+			errorRequests.Inc()
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		successRequests.Inc()
+		return c.JSON(http.StatusOK, sku)
+	}
+}
@@ -0,0 +1,259 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// consumeBatches fetches up to BatchSize messages or for BatchTimeout milliseconds after the first one, hands them to
+// handle and then commits the whole batch. A crash before the commit redelivers the batch.
+// No new batch starts while the topic is paused. When handle fails the batch is not committed and consuming stops
+// like on a reader failure, a later batch would commit past it.
+func (c *ProductsConsumerGroup) consumeBatches(
+	ctx context.Context,
+	r *kafka.Reader,
+	control *topicControl,
+	handle func(ctx context.Context, batch []kafka.Message) error,
+) {
+	size := max(c.cfg.Kafka.BatchSize, 1)
+	timeout := time.Duration(c.cfg.Kafka.BatchTimeout) * time.Millisecond
+
+	for {
+		_, changed, err := control.wait(ctx)
+		if err != nil {
+			return
+		}
+
+		firstCtx, cancelFirst := fetchContext(ctx, changed)
+		m, err := r.FetchMessage(firstCtx)
+		changedWhileIdle := err != nil && ctx.Err() == nil && firstCtx.Err() != nil
+		cancelFirst()
+		if changedWhileIdle {
+			// The topic may be paused now
+			continue
+		}
+		if err != nil {
+			c.log.Errorf("r.FetchMessage: %v", err)
+			return
+		}
+
+		batch := append(make([]kafka.Message, 0, size), m)
+		fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+		for len(batch) < size {
+			m, err := r.FetchMessage(fetchCtx)
+			if err != nil {
+				break
+			}
+			batch = append(batch, m)
+		}
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		if err := handle(ctx, batch); err != nil {
+			c.log.Errorf("handle batch: %v", err)
+			return
+		}
+		processingDuration.WithLabelValues(control.topic, "batch").Observe(time.Since(start).Seconds())
+		for _, m := range batch {
+			messageDelay.WithLabelValues(m.Topic).Observe(time.Since(m.Time).Seconds())
+		}
+
+		if err := r.CommitMessages(ctx, batch...); err != nil {
+			c.log.Errorf("r.CommitMessages: %v", err)
+		}
+	}
+}
+
+// handleCreateBatch creates the products of the batch with one bulk write, failed messages go to the dead letter queue.
+// Messages carry the product id assigned by PublishCreate, a redelivered message fails with a duplicate key
+// and counts as created. An error means a message could not be dead lettered, the batch must be delivered again.
+func (c *ProductsConsumerGroup) handleCreateBatch(ctx context.Context, w *kafka.Writer, batch []kafka.Message) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductsConsumerGroup.handleCreateBatch")
+	defer span.Finish()
+
+	span.LogFields(log.Int("BatchSize", len(batch)))
+	incomingMessages.Add(float64(len(batch)))
+
+	messages := make([]kafka.Message, 0, len(batch))
+	products := make([]*models.Product, 0, len(batch))
+	for _, m := range batch {
+		prod, err := decodeProduct(m)
+		if err != nil {
+			c.log.Errorf("decodeProduct: %v", err)
+			if err := c.deadLetter(ctx, w, m, models.ErrorClassMalformed, err); err != nil {
+				return err
+			}
+			continue
+		}
+		messages = append(messages, m)
+		products = append(products, prod)
+	}
+	if len(products) == 0 {
+		return nil
+	}
+
+	var itemErrors []error
+	if err := retry.Do(func() error {
+		var err error
+		itemErrors, err = c.productsUC.BatchCreateProducts(ctx, products)
+		return err
+	}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx), retry.LastErrorOnly(true)); err != nil {
+		c.log.Errorf("retry.Do: %v", err)
+		return c.deadLetterAll(ctx, w, messages, errorClass(err), err)
+	}
+
+	var created int
+	var duplicates []kafka.Message
+	var duplicateIDs []primitive.ObjectID
+	for i, m := range messages {
+		switch err := itemErrors[i]; {
+		case err == nil:
+			created++
+			c.completeOperation(ctx, m, products[i])
+			successMessages.Inc()
+		case mongo.IsDuplicateKeyError(err):
+			duplicates = append(duplicates, m)
+			duplicateIDs = append(duplicateIDs, products[i].ProductID)
+		default:
+			c.log.Errorf("productsUC.BatchCreateProducts: %v", err)
+			if err := c.deadLetter(ctx, w, m, errorClass(err), err); err != nil {
+				return err
+			}
+		}
+	}
+	c.log.Infof("Created %d products of a batch of %d messages", created, len(batch))
+
+	if len(duplicates) > 0 {
+		return c.completeDuplicates(ctx, w, duplicates, duplicateIDs)
+	}
+	return nil
+}
+
+// completeDuplicates completes the operations of the messages whose products an earlier delivery inserted
+func (c *ProductsConsumerGroup) completeDuplicates(ctx context.Context, w *kafka.Writer, messages []kafka.Message, productIDs []primitive.ObjectID) error {
+	existing, err := c.productsUC.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		c.log.Errorf("productsUC.GetProductsByIDs: %v", err)
+		return c.deadLetterAll(ctx, w, messages, models.ErrorClassTransient, err)
+	}
+	byID := make(map[primitive.ObjectID]*models.Product, len(existing))
+	for _, product := range existing {
+		byID[product.ProductID] = product
+	}
+
+	for i, m := range messages {
+		product, ok := byID[productIDs[i]]
+		if !ok {
+			if err := c.deadLetter(ctx, w, m, models.ErrorClassTransient, errors.Wrapf(mongo.ErrNoDocuments, "duplicate product %s", productIDs[i].Hex())); err != nil {
+				return err
+			}
+			continue
+		}
+		c.completeOperation(ctx, m, product)
+		successMessages.Inc()
+	}
+	return nil
+}
+
+// deadLetterAll sends every message to the dead letter queue, stopping at the first one that cannot be sent
+func (c *ProductsConsumerGroup) deadLetterAll(ctx context.Context, w *kafka.Writer, messages []kafka.Message, errorClass string, cause error) error {
+	for _, m := range messages {
+		if err := c.deadLetter(ctx, w, m, errorClass, cause); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleUpdateBatch updates the products of the batch with one bulk write per round and notifies their subscribers,
+// failed updates go to the dead letter queue. A round holds at most one message of a product,
+// so several updates of one product are applied one after another in partition order.
+// An error means a message could not be dead lettered, the batch must be delivered again.
+func (c *ProductsConsumerGroup) handleUpdateBatch(ctx context.Context, w *kafka.Writer, batch []kafka.Message) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductsConsumerGroup.handleUpdateBatch")
+	defer span.Finish()
+
+	span.LogFields(log.Int("BatchSize", len(batch)))
+	incomingMessages.Add(float64(len(batch)))
+
+	type round struct {
+		messages []kafka.Message
+		products []*models.Product
+	}
+	var rounds []*round
+	seen := make(map[primitive.ObjectID]int, len(batch))
+	for _, m := range batch {
+		prod, err := decodeProduct(m)
+		if err != nil {
+			c.log.Errorf("decodeProduct: %v", err)
+			if err := c.deadLetter(ctx, w, m, models.ErrorClassMalformed, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		i := seen[prod.ProductID]
+		seen[prod.ProductID]++
+		if i == len(rounds) {
+			rounds = append(rounds, &round{})
+		}
+		rounds[i].messages = append(rounds[i].messages, m)
+		rounds[i].products = append(rounds[i].products, prod)
+	}
+
+	for _, r := range rounds {
+		if err := c.updateRound(ctx, w, r.messages, r.products); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateRound applies the updates of products that appear once
+func (c *ProductsConsumerGroup) updateRound(ctx context.Context, w *kafka.Writer, messages []kafka.Message, products []*models.Product) error {
+	var updates []*models.ProductUpdate
+	var itemErrors []error
+	if err := retry.Do(func() error {
+		var err error
+		updates, itemErrors, err = c.productsUC.BatchUpdateProducts(ctx, products)
+		return err
+	}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx), retry.LastErrorOnly(true)); err != nil {
+		c.log.Errorf("retry.Do: %v", err)
+		return c.deadLetterAll(ctx, w, messages, errorClass(err), err)
+	}
+
+	for i, m := range messages {
+		if err := itemErrors[i]; err != nil {
+			c.log.Errorf("productsUC.BatchUpdateProducts: %v", err)
+			if err := c.deadLetter(ctx, w, m, errorClass(err), err); err != nil {
+				return err
+			}
+			continue
+		}
+		c.completeOperation(ctx, m, updates[i].Updated)
+
+		if err := retry.Do(func() error {
+			return c.subsUC.NotifySubscribers(ctx, updates[i].Previous, updates[i].Updated)
+		}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx)); err != nil {
+			errorMessages.Inc()
+			c.log.Errorf("subsUC.NotifySubscribers: %v", err)
+		}
+
+		successMessages.Inc()
+	}
+	c.log.Infof("Updated products of a batch round of %d messages", len(messages))
+	return nil
+}
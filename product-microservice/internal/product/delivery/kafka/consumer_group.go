@@ -3,12 +3,18 @@ package kafka
 import (
 	"context"
 	"encoding/json"
-	"sync"
+	"fmt"
+	"time"
 
+	"github.com/avast/retry-go"
 	"github.com/chuuch/product-microservice/config"
+	"github.com/chuuch/product-microservice/internal/idempotency"
 	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/operation"
 	"github.com/chuuch/product-microservice/internal/product"
+	"github.com/chuuch/product-microservice/internal/subscription"
 	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
 	"github.com/segmentio/kafka-go"
@@ -22,15 +28,21 @@ type ProductsConsumerGroup struct {
 	log        logger.Logger
 	cfg        *config.Config
 	productsUC product.UseCase
+	subsUC     subscription.UseCase
+	opsUC      operation.UseCase
 	validate   *validator.Validate
+	create     *topicControl
+	update     *topicControl
 }
 
-// NewProductsConsumerGroup constructor
+// NewProductsConsumerGroup constructor, the topics and their workers come from the kafka config
 func NewProductsConsumerGroup(
 	brokers []string,
 	groupID string,
 	cfg *config.Config,
 	productsUC product.UseCase,
+	subsUC subscription.UseCase,
+	opsUC operation.UseCase,
 	log logger.Logger,
 	validate *validator.Validate,
 ) *ProductsConsumerGroup {
@@ -39,8 +51,12 @@ func NewProductsConsumerGroup(
 		GroupID:    groupID,
 		cfg:        cfg,
 		productsUC: productsUC,
+		subsUC:     subsUC,
+		opsUC:      opsUC,
 		log:        log,
 		validate:   validate,
+		create:     newTopicControl(cfg.Kafka.CreateProductTopic, cfg.Kafka.CreateProductWorkers),
+		update:     newTopicControl(cfg.Kafka.UpdateProductTopic, cfg.Kafka.UpdateProductWorkers),
 	}
 }
 
@@ -83,8 +99,8 @@ func (c *ProductsConsumerGroup) getNewWriter(topic string) *kafka.Writer {
 	return w
 }
 
-func (c *ProductsConsumerGroup) consumeCreateProduct(ctx context.Context, cancel context.CancelFunc, groupID string, topic string, workerNum int) {
-	r := c.getNewReader(c.Brokers, topic, groupID)
+func (c *ProductsConsumerGroup) consumeCreateProduct(ctx context.Context, cancel context.CancelFunc, control *topicControl) {
+	r := c.getNewReader(c.Brokers, control.topic, c.GroupID)
 	defer cancel()
 	defer func() {
 		if err := r.Close(); err != nil {
@@ -93,7 +109,11 @@ func (c *ProductsConsumerGroup) consumeCreateProduct(ctx context.Context, cancel
 		}
 	}()
 
-	w := c.getNewWriter(deadLetterQueueTopic)
+	lagCtx, stopLag := context.WithCancel(ctx)
+	defer stopLag()
+	go watchLag(lagCtx, r)
+
+	w := c.getNewWriter(c.cfg.Kafka.DeadLetterTopic)
 	defer func() {
 		if err := w.Close(); err != nil {
 			c.log.Errorf("w.Close: %v", err)
@@ -101,18 +121,22 @@ func (c *ProductsConsumerGroup) consumeCreateProduct(ctx context.Context, cancel
 		}
 	}()
 
-	c.log.Infof("Starting consume create product from topic: %s", topic)
+	c.log.Infof("Starting consume create product from topic: %s", control.topic)
 
-	wg := &sync.WaitGroup{}
-	for i := range workerNum {
-		wg.Add(1)
-		go c.createProductWorker(ctx, cancel, r, w, wg, i)
+	if c.cfg.Kafka.BatchEnabled {
+		c.consumeBatches(ctx, r, control, func(ctx context.Context, batch []kafka.Message) error {
+			return c.handleCreateBatch(ctx, w, batch)
+		})
+		return
 	}
-	wg.Wait()
+
+	c.consumeOrdered(ctx, r, control, func(ctx context.Context, m kafka.Message) error {
+		return c.handleCreateProduct(ctx, w, m)
+	})
 }
 
-func (c *ProductsConsumerGroup) consumeUpdateProduct(ctx context.Context, cancel context.CancelFunc, groupID string, topic string, workerNum int) {
-	r := c.getNewReader(c.Brokers, topic, groupID)
+func (c *ProductsConsumerGroup) consumeUpdateProduct(ctx context.Context, cancel context.CancelFunc, control *topicControl) {
+	r := c.getNewReader(c.Brokers, control.topic, c.GroupID)
 	defer cancel()
 	defer func() {
 		if err := r.Close(); err != nil {
@@ -121,7 +145,11 @@ func (c *ProductsConsumerGroup) consumeUpdateProduct(ctx context.Context, cancel
 		}
 	}()
 
-	w := c.getNewWriter(deadLetterQueueTopic)
+	lagCtx, stopLag := context.WithCancel(ctx)
+	defer stopLag()
+	go watchLag(lagCtx, r)
+
+	w := c.getNewWriter(c.cfg.Kafka.DeadLetterTopic)
 	defer func() {
 		if err := w.Close(); err != nil {
 			c.log.Errorf("w.Close: %v", err)
@@ -129,23 +157,39 @@ func (c *ProductsConsumerGroup) consumeUpdateProduct(ctx context.Context, cancel
 		}
 	}()
 
-	c.log.Infof("Starting consume update product from topic: %s", topic)
+	c.log.Infof("Starting consume update product from topic: %s", control.topic)
 
-	wg := &sync.WaitGroup{}
-	for i := range workerNum {
-		wg.Add(1)
-		go c.updateProductWorker(ctx, cancel, r, w, wg, i)
+	if c.cfg.Kafka.BatchEnabled {
+		c.consumeBatches(ctx, r, control, func(ctx context.Context, batch []kafka.Message) error {
+			return c.handleUpdateBatch(ctx, w, batch)
+		})
+		return
 	}
-	wg.Wait()
+
+	c.consumeOrdered(ctx, r, control, func(ctx context.Context, m kafka.Message) error {
+		return c.handleUpdateProduct(ctx, w, m)
+	})
 }
 
-func (c *ProductsConsumerGroup) publishErrorMessage(ctx context.Context, w *kafka.Writer, m kafka.Message, err error) error {
+// publishErrorMessage sends the whole message with the error to the dead letter queue, keyed by its message id
+func (c *ProductsConsumerGroup) publishErrorMessage(ctx context.Context, w *kafka.Writer, m kafka.Message, errorClass string, err error) error {
+	headers := make([]models.MessageHeader, 0, len(m.Headers))
+	for _, header := range m.Headers {
+		headers = append(headers, models.MessageHeader{Key: header.Key, Value: header.Value})
+	}
+
 	errMsg := models.ErrorMessage{
-		Offset:    m.Offset,
-		Topic:     m.Topic,
-		Partition: m.Partition,
-		Error:     err.Error(),
-		Time:      m.Time.UTC(),
+		MessageID:  fmt.Sprintf("%s-%d-%d", m.Topic, m.Partition, m.Offset),
+		Offset:     m.Offset,
+		Topic:      m.Topic,
+		Partition:  m.Partition,
+		Key:        m.Key,
+		Value:      m.Value,
+		Headers:    headers,
+		Error:      err.Error(),
+		ErrorClass: errorClass,
+		Time:       m.Time.UTC(),
+		FailedAt:   time.Now().UTC(),
 	}
 
 	errMsgBytes, err := json.Marshal(errMsg)
@@ -154,11 +198,105 @@ func (c *ProductsConsumerGroup) publishErrorMessage(ctx context.Context, w *kafk
 	}
 
 	return w.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(errMsg.MessageID),
 		Value: errMsgBytes,
 	})
 }
 
+// deadLetter records the failure on the message operation and sends the message to the dead letter queue.
+// An error means the message is in no queue, its offset must not be committed so it is delivered again.
+func (c *ProductsConsumerGroup) deadLetter(ctx context.Context, w *kafka.Writer, m kafka.Message, errorClass string, cause error) error {
+	errorMessages.Inc()
+	c.failOperation(ctx, m, cause)
+	if err := retry.Do(func() error {
+		return c.publishErrorMessage(ctx, w, m, errorClass, cause)
+	}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx), retry.LastErrorOnly(true)); err != nil {
+		errorMessages.Inc()
+		c.log.Errorf("publishErrorMessage: %v", err)
+		return errors.Wrapf(err, "publishErrorMessage %s/%d/%d", m.Topic, m.Partition, m.Offset)
+	}
+	return nil
+}
+
+// completeOperation records the product as the outcome of the message operation
+func (c *ProductsConsumerGroup) completeOperation(ctx context.Context, m kafka.Message, product *models.Product) {
+	if err := c.opsUC.CompleteOperation(ctx, operationID(m), product); err != nil {
+		c.log.Errorf("opsUC.CompleteOperation: %v", err)
+	}
+}
+
+// failOperation records the error as the outcome of the message operation
+func (c *ProductsConsumerGroup) failOperation(ctx context.Context, m kafka.Message, cause error) {
+	if err := c.opsUC.FailOperation(ctx, operationID(m), cause); err != nil {
+		c.log.Errorf("opsUC.FailOperation: %v", err)
+	}
+}
+
+// idempotencyKey of the message, empty for messages published without one
+func idempotencyKey(m kafka.Message) string {
+	return header(m, idempotency.KafkaHeaderIdempotencyKey)
+}
+
+// operationID of the message, empty for messages published without one
+func operationID(m kafka.Message) string {
+	return header(m, operation.HeaderOperationID)
+}
+
 func (c *ProductsConsumerGroup) RunConsumers(ctx context.Context, cancel context.CancelFunc) {
-	go c.consumeCreateProduct(ctx, cancel, productsGropupID, createProductTopic, createProductWorkers)
-	go c.consumeUpdateProduct(ctx, cancel, productsGropupID, updateProductTopic, updateProductWorkers)
+	go c.consumeCreateProduct(ctx, cancel, c.create)
+	go c.consumeUpdateProduct(ctx, cancel, c.update)
+}
+
+// ConsumerStates of the create and update product topics
+func (c *ProductsConsumerGroup) ConsumerStates() []*models.ConsumerState {
+	return []*models.ConsumerState{
+		c.create.state(c.GroupID, c.cfg.Kafka.BatchEnabled),
+		c.update.state(c.GroupID, c.cfg.Kafka.BatchEnabled),
+	}
+}
+
+// PauseTopic stops fetching the topic after the messages in flight, the consumers keep their partitions
+func (c *ProductsConsumerGroup) PauseTopic(topic string) (*models.ConsumerState, error) {
+	control, err := c.control(topic)
+	if err != nil {
+		return nil, err
+	}
+	control.setPaused(true)
+	c.log.Infof("Paused consumers of topic: %s", topic)
+	return control.state(c.GroupID, c.cfg.Kafka.BatchEnabled), nil
+}
+
+// ResumeTopic fetches the paused topic again
+func (c *ProductsConsumerGroup) ResumeTopic(topic string) (*models.ConsumerState, error) {
+	control, err := c.control(topic)
+	if err != nil {
+		return nil, err
+	}
+	control.setPaused(false)
+	c.log.Infof("Resumed consumers of topic: %s", topic)
+	return control.state(c.GroupID, c.cfg.Kafka.BatchEnabled), nil
+}
+
+// SetTopicWorkers restarts the workers of the topic with the new number once the messages in flight are handled
+func (c *ProductsConsumerGroup) SetTopicWorkers(topic string, workers int) (*models.ConsumerState, error) {
+	if workers < 1 || workers > maxWorkers {
+		return nil, errors.Wrapf(productErrors.ErrInvalidWorkers, "%d workers, 1 to %d", workers, maxWorkers)
+	}
+	control, err := c.control(topic)
+	if err != nil {
+		return nil, err
+	}
+	control.setWorkers(workers)
+	c.log.Infof("Set %d workers for topic: %s", workers, topic)
+	return control.state(c.GroupID, c.cfg.Kafka.BatchEnabled), nil
+}
+
+func (c *ProductsConsumerGroup) control(topic string) (*topicControl, error) {
+	switch topic {
+	case c.create.topic:
+		return c.create, nil
+	case c.update.topic:
+		return c.update, nil
+	}
+	return nil, errors.Wrapf(productErrors.ErrConsumerNotFound, "topic %s", topic)
 }
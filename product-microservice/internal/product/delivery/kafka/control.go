@@ -0,0 +1,152 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	consumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "products_kafka_consumer_lag",
+		Help: "Messages of the partition behind the last fetched one, as the reader reports it",
+	}, []string{"topic", "partition"})
+
+	consumerPaused = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "products_kafka_consumer_paused",
+		Help: "1 when the consumers of the topic are paused",
+	}, []string{"topic"})
+
+	consumerWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "products_kafka_consumer_workers",
+		Help: "Number of workers consuming the topic",
+	}, []string{"topic"})
+
+	processingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "products_kafka_processing_duration_seconds",
+		Help:    "Time to handle a message, or a whole batch in batch mode, by topic and mode",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic", "mode"})
+
+	messageDelay = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "products_kafka_message_delay_seconds",
+		Help:    "Time from producing a message until it is handled, by topic",
+		Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+	}, []string{"topic"})
+)
+
+// topicControl runtime state of the consumers of one topic, the admin endpoints pause them and change their workers
+type topicControl struct {
+	topic string
+
+	mu      sync.Mutex
+	paused  bool
+	workers int
+	changed chan struct{} // closed and replaced on every change
+}
+
+func newTopicControl(topic string, workers int) *topicControl {
+	consumerWorkers.WithLabelValues(topic).Set(float64(workers))
+	consumerPaused.WithLabelValues(topic).Set(0)
+	return &topicControl{
+		topic:   topic,
+		workers: workers,
+		changed: make(chan struct{}),
+	}
+}
+
+// wait blocks while the topic is paused, then returns its workers and a channel closed on the next change
+func (t *topicControl) wait(ctx context.Context) (int, <-chan struct{}, error) {
+	for {
+		t.mu.Lock()
+		paused, workers, changed := t.paused, t.workers, t.changed
+		t.mu.Unlock()
+
+		if !paused {
+			return workers, changed, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// setPaused pauses or resumes the topic
+func (t *topicControl) setPaused(paused bool) {
+	t.update(func() {
+		t.paused = paused
+	})
+	value := 0.0
+	if paused {
+		value = 1
+	}
+	consumerPaused.WithLabelValues(t.topic).Set(value)
+}
+
+// setWorkers changes the workers of the topic
+func (t *topicControl) setWorkers(workers int) {
+	t.update(func() {
+		t.workers = workers
+	})
+	consumerWorkers.WithLabelValues(t.topic).Set(float64(workers))
+}
+
+func (t *topicControl) update(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fn()
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// state of the topic for the admin endpoints
+func (t *topicControl) state(groupID string, batch bool) *models.ConsumerState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return &models.ConsumerState{
+		Topic:   t.topic,
+		GroupID: groupID,
+		Paused:  t.paused,
+		Workers: t.workers,
+		Batch:   batch,
+	}
+}
+
+// fetchContext is cancelled when the topic changes, a fetch blocked on an idle topic returns to apply the change
+func fetchContext(ctx context.Context, changed <-chan struct{}) (context.Context, context.CancelFunc) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-changed:
+			cancel()
+		case <-fetchCtx.Done():
+		}
+	}()
+	return fetchCtx, cancel
+}
+
+// watchLag records the lag the reader reports until the context is done,
+// so the gauge stays current while the topic is idle or paused
+func watchLag(ctx context.Context, r *kafka.Reader) {
+	ticker := time.NewTicker(lagInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := r.Stats()
+			consumerLag.WithLabelValues(stats.Topic, stats.Partition).Set(float64(stats.Lag))
+		}
+	}
+}
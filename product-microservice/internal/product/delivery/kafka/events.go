@@ -0,0 +1,158 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	HeaderContentType = "content-type" // format of the message value
+	HeaderEventType   = "event_type"   // type of the event, readable without decoding the value
+
+	ContentTypeProtobuf = "application/x-protobuf" // a ProductEvent envelope
+	ContentTypeJSON     = "application/json"       // a bare product, the legacy format
+
+	EventFormatProtobuf = "protobuf"
+	EventFormatJSON     = "json"
+
+	productEventVersion = 1 // newest ProductEvent schema version this service reads and writes
+)
+
+// ErrUnsupportedEvent the message is of a format or schema version this service cannot read
+var ErrUnsupportedEvent = errors.New("unsupported product event")
+
+// NewProductMessage message of the product event keyed by the product id, in the configured format.
+// Legacy JSON unless the format is protobuf, until every consumer reads ProductEvent envelopes.
+// The trace of the context travels in the headers.
+func (p *productsProducer) NewProductMessage(ctx context.Context, eventType string, product *models.Product) (kafka.Message, error) {
+	now := time.Now().UTC()
+	m := kafka.Message{
+		Key:  []byte(product.ProductID.Hex()),
+		Time: now,
+		Headers: []kafka.Header{
+			{Key: HeaderEventType, Value: []byte(eventType)},
+		},
+	}
+
+	if p.cfg.Kafka.EventFormat != EventFormatProtobuf {
+		value, err := json.Marshal(product)
+		if err != nil {
+			return m, errors.Wrap(err, "json.Marshal")
+		}
+		m.Value = value
+		m.Headers = append(m.Headers, kafka.Header{Key: HeaderContentType, Value: []byte(ContentTypeJSON)})
+	} else {
+		payload := product.ToProto()
+		payload.ProductId = product.ProductID.Hex()
+		payload.CategoryId = product.CategoryID.Hex()
+
+		value, err := proto.Marshal(&productService.ProductEvent{
+			EventId:     primitive.NewObjectID().Hex(),
+			Type:        eventType,
+			Version:     productEventVersion,
+			OccurredAt:  timestamppb.New(now),
+			AggregateId: product.ProductID.Hex(),
+			Producer:    p.producer,
+			Payload:     &productService.ProductEvent_Product{Product: payload},
+		})
+		if err != nil {
+			return m, errors.Wrap(err, "proto.Marshal")
+		}
+		m.Value = value
+		m.Headers = append(m.Headers, kafka.Header{Key: HeaderContentType, Value: []byte(ContentTypeProtobuf)})
+	}
+
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		carrier := headersCarrier(m.Headers)
+		if err := opentracing.GlobalTracer().Inject(span.Context(), opentracing.TextMap, &carrier); err != nil {
+			p.log.Errorf("tracer.Inject: %v", err)
+		}
+		m.Headers = carrier
+	}
+
+	return m, nil
+}
+
+// decodeProduct product of a ProductEvent envelope or of a legacy JSON message, messages without a content type are legacy
+func decodeProduct(m kafka.Message) (*models.Product, error) {
+	if header(m, HeaderContentType) != ContentTypeProtobuf {
+		var product models.Product
+		if err := json.Unmarshal(m.Value, &product); err != nil {
+			return nil, errors.Wrap(err, "json.Unmarshal")
+		}
+		return &product, nil
+	}
+
+	var event productService.ProductEvent
+	if err := proto.Unmarshal(m.Value, &event); err != nil {
+		return nil, errors.Wrap(err, "proto.Unmarshal")
+	}
+	if event.GetVersion() > productEventVersion {
+		return nil, errors.Wrapf(ErrUnsupportedEvent, "version %d of event %s", event.GetVersion(), event.GetEventId())
+	}
+	if event.GetProduct() == nil {
+		return nil, errors.Wrapf(ErrUnsupportedEvent, "event %s has no product payload", event.GetEventId())
+	}
+
+	product, err := models.ProductFromProto(event.GetProduct())
+	if err != nil {
+		return nil, errors.Wrap(err, "models.ProductFromProto")
+	}
+	if event.GetProduct().GetImageUrl() == "" {
+		product.ImageURL = nil
+	}
+	return product, nil
+}
+
+// startMessageSpan span of handling the message, a child of the producer span when the message carries its trace
+func startMessageSpan(ctx context.Context, operationName string, m kafka.Message) (opentracing.Span, context.Context) {
+	carrier := headersCarrier(m.Headers)
+	if spanContext, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, &carrier); err == nil {
+		return opentracing.StartSpanFromContext(ctx, operationName, opentracing.FollowsFrom(spanContext))
+	}
+	return opentracing.StartSpanFromContext(ctx, operationName)
+}
+
+// header value of the message header, empty when the message does not have it
+func header(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// headersCarrier kafka headers as an opentracing text map
+type headersCarrier []kafka.Header
+
+// Set replaces the header of the key
+func (c *headersCarrier) Set(key, value string) {
+	for i, h := range *c {
+		if h.Key == key {
+			(*c)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c = append(*c, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// ForeachKey calls handler with every header
+func (c *headersCarrier) ForeachKey(handler func(key, value string) error) error {
+	for _, h := range *c {
+		if err := handler(h.Key, string(h.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chuuch/product-microservice/config"
+	"github.com/chuuch/product-microservice/internal/models"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+)
+
+func testProduct() *models.Product {
+	imageURL := "https://example.com/image.jpg"
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &models.Product{
+		ProductID:   primitive.NewObjectID(),
+		CategoryID:  primitive.NewObjectID(),
+		Name:        "Test Product",
+		Description: "Test Description",
+		Price:       models.Money{Amount: 1999, Currency: "USD"},
+		Prices:      []models.Money{{Amount: 1799, Currency: "EUR"}},
+		ImageURL:    &imageURL,
+		Photos:      []string{"https://example.com/photo.jpg"},
+		Quantity:    7,
+		Options:     []models.VariantOption{{Name: "size", Values: []string{"S", "M"}}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     3,
+	}
+}
+
+func newTestProducer(eventFormat string) *productsProducer {
+	return &productsProducer{
+		cfg:      &config.Config{Kafka: config.KafkaConfig{EventFormat: eventFormat}},
+		producer: "products_test@host",
+	}
+}
+
+func TestNewProductMessage_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		eventFormat string
+		contentType string
+	}{
+		{name: "default is json", eventFormat: "", contentType: ContentTypeJSON},
+		{name: "json", eventFormat: EventFormatJSON, contentType: ContentTypeJSON},
+		{name: "protobuf", eventFormat: EventFormatProtobuf, contentType: ContentTypeProtobuf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			product := testProduct()
+			m, err := newTestProducer(tt.eventFormat).NewProductMessage(context.Background(), models.OperationTypeUpdateProduct, product)
+			if err != nil {
+				t.Fatalf("NewProductMessage: %v", err)
+			}
+
+			if got := string(m.Key); got != product.ProductID.Hex() {
+				t.Errorf("key = %s, want %s", got, product.ProductID.Hex())
+			}
+			if got := header(m, HeaderContentType); got != tt.contentType {
+				t.Errorf("content type = %s, want %s", got, tt.contentType)
+			}
+			if got := header(m, HeaderEventType); got != models.OperationTypeUpdateProduct {
+				t.Errorf("event type = %s, want %s", got, models.OperationTypeUpdateProduct)
+			}
+
+			decoded, err := decodeProduct(m)
+			if err != nil {
+				t.Fatalf("decodeProduct: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, product) {
+				t.Errorf("decoded = %+v, want %+v", decoded, product)
+			}
+		})
+	}
+}
+
+func TestDecodeProduct_LegacyJSONWithoutHeaders(t *testing.T) {
+	t.Parallel()
+
+	product := testProduct()
+	value, err := json.Marshal(product)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := decodeProduct(kafka.Message{Value: value})
+	if err != nil {
+		t.Fatalf("decodeProduct: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, product) {
+		t.Errorf("decoded = %+v, want %+v", decoded, product)
+	}
+}
+
+func TestDecodeProduct_ProtobufWithoutImage(t *testing.T) {
+	t.Parallel()
+
+	product := testProduct()
+	product.ImageURL = nil
+	m, err := newTestProducer(EventFormatProtobuf).NewProductMessage(context.Background(), models.OperationTypeCreateProduct, product)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := decodeProduct(m)
+	if err != nil {
+		t.Fatalf("decodeProduct: %v", err)
+	}
+	if decoded.ImageURL != nil {
+		t.Errorf("image url = %q, want nil", *decoded.ImageURL)
+	}
+}
+
+func TestDecodeProduct_Errors(t *testing.T) {
+	t.Parallel()
+
+	protobufMessage := func(event *productService.ProductEvent) kafka.Message {
+		value, err := proto.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return kafka.Message{
+			Value:   value,
+			Headers: []kafka.Header{{Key: HeaderContentType, Value: []byte(ContentTypeProtobuf)}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		m           kafka.Message
+		unsupported bool
+	}{
+		{
+			name: "malformed json",
+			m:    kafka.Message{Value: []byte("{")},
+		},
+		{
+			name: "malformed protobuf",
+			m: kafka.Message{
+				Value:   []byte{0xff, 0xff},
+				Headers: []kafka.Header{{Key: HeaderContentType, Value: []byte(ContentTypeProtobuf)}},
+			},
+		},
+		{
+			name:        "newer schema version",
+			m:           protobufMessage(&productService.ProductEvent{EventId: "1", Version: productEventVersion + 1}),
+			unsupported: true,
+		},
+		{
+			name:        "no product payload",
+			m:           protobufMessage(&productService.ProductEvent{EventId: "1", Version: productEventVersion}),
+			unsupported: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := decodeProduct(tt.m)
+			if err == nil {
+				t.Fatal("decodeProduct succeeded, want an error")
+			}
+			if got := errors.Is(err, ErrUnsupportedEvent); got != tt.unsupported {
+				t.Errorf("errors.Is(err, ErrUnsupportedEvent) = %v, want %v: %v", got, tt.unsupported, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// partitionOffsets offsets of one partition that are fetched and not committed yet
+type partitionOffsets struct {
+	pending   []int64 // in fetch order, which is offset order
+	processed map[int64]bool
+}
+
+// offsetTracker finds the offsets that are safe to commit while messages of a partition are handled out of order
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[string]map[int]*partitionOffsets
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[string]map[int]*partitionOffsets)}
+}
+
+// fetched records the message as in flight. An offset at or below the last fetched one means the partition was
+// assigned again after a rebalance and rewound to its committed offset, the older offsets are forgotten.
+func (t *offsetTracker) fetched(m kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	partitions, ok := t.partitions[m.Topic]
+	if !ok {
+		partitions = make(map[int]*partitionOffsets)
+		t.partitions[m.Topic] = partitions
+	}
+
+	offsets, ok := partitions[m.Partition]
+	if !ok || (len(offsets.pending) > 0 && m.Offset <= offsets.pending[len(offsets.pending)-1]) {
+		offsets = &partitionOffsets{processed: make(map[int64]bool)}
+		partitions[m.Partition] = offsets
+	}
+	offsets.pending = append(offsets.pending, m.Offset)
+}
+
+// processed marks the message as handled and returns the highest offset of its partition below which every
+// fetched message is handled, ok is false when an earlier message of the partition is still in flight
+func (t *offsetTracker) processed(m kafka.Message) (kafka.Message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	offsets, ok := t.partitions[m.Topic][m.Partition]
+	if !ok {
+		return kafka.Message{}, false
+	}
+	offsets.processed[m.Offset] = true
+
+	committed := int64(-1)
+	for len(offsets.pending) > 0 && offsets.processed[offsets.pending[0]] {
+		committed = offsets.pending[0]
+		delete(offsets.processed, committed)
+		offsets.pending = offsets.pending[1:]
+	}
+	if committed < 0 {
+		return kafka.Message{}, false
+	}
+
+	return kafka.Message{Topic: m.Topic, Partition: m.Partition, Offset: committed}, true
+}
+
+// consumeOrdered fetches the topic and hands each message to the worker of its key, so the messages of one product
+// are handled one after another in partition order. Messages without a key are spread by partition.
+// Offsets are committed by one goroutine and only up to the highest contiguous handled offset of each partition,
+// a crash redelivers every message that was not handled yet. A message handle fails on is never marked handled,
+// no offset of its partition at or after it is committed until a rebalance or restart delivers it again.
+// Fetching stops while the topic is paused.
+func (c *ProductsConsumerGroup) consumeOrdered(
+	ctx context.Context,
+	r *kafka.Reader,
+	control *topicControl,
+	handle func(ctx context.Context, m kafka.Message) error,
+) {
+	tracker := newOffsetTracker()
+	processed := make(chan kafka.Message, queueCapacity)
+
+	committerDone := make(chan struct{})
+	go func() {
+		defer close(committerDone)
+		c.commitProcessed(ctx, r, tracker, processed)
+	}()
+
+	for {
+		workerNum, changed, err := control.wait(ctx)
+		if err != nil {
+			break
+		}
+		if !c.runWorkers(ctx, r, tracker, processed, workerNum, changed, handle) {
+			break
+		}
+	}
+
+	close(processed)
+	<-committerDone
+}
+
+// runWorkers fetches with workerNum workers until the topic changes, false when the context is cancelled or
+// the reader fails. The workers finish their messages first, so a key never has messages on two workers at once.
+// Once the context is cancelled the workers drop their queued messages instead of handling them.
+func (c *ProductsConsumerGroup) runWorkers(
+	ctx context.Context,
+	r *kafka.Reader,
+	tracker *offsetTracker,
+	processed chan<- kafka.Message,
+	workerNum int,
+	changed <-chan struct{},
+	handle func(ctx context.Context, m kafka.Message) error,
+) bool {
+	wg := &sync.WaitGroup{}
+	workers := make([]chan kafka.Message, workerNum)
+	for i := range workerNum {
+		workers[i] = make(chan kafka.Message, queueCapacity/workerNum+1)
+		wg.Add(1)
+		go func(workerID int, messages <-chan kafka.Message) {
+			defer wg.Done()
+			for m := range messages {
+				if ctx.Err() != nil {
+					// Shutting down, the queued messages stay uncommitted and are delivered again
+					continue
+				}
+				c.log.Infof(
+					"WORKER: %v, message at topic/partition/offset: %v/%v/%v: %s = %s",
+					workerID,
+					m.Topic,
+					m.Partition,
+					m.Offset,
+					string(m.Key),
+					string(m.Value),
+				)
+				start := time.Now()
+				err := handle(ctx, m)
+				processingDuration.WithLabelValues(m.Topic, "message").Observe(time.Since(start).Seconds())
+				messageDelay.WithLabelValues(m.Topic).Observe(time.Since(m.Time).Seconds())
+				if err != nil {
+					c.log.Errorf("handle message: %v", err)
+					continue
+				}
+				processed <- m
+			}
+		}(i, workers[i])
+	}
+
+	fetchCtx, cancel := fetchContext(ctx, changed)
+	defer cancel()
+
+	running := true
+fetch:
+	for {
+		m, err := r.FetchMessage(fetchCtx)
+		if err != nil {
+			if ctx.Err() == nil && fetchCtx.Err() != nil {
+				// The topic changed, the caller applies it
+				break
+			}
+			c.log.Errorf("r.FetchMessage: %v", err)
+			running = false
+			break
+		}
+
+		tracker.fetched(m)
+		select {
+		case workers[workerIndex(m, workerNum)] <- m:
+		case <-ctx.Done():
+			running = false
+			break fetch
+		}
+	}
+
+	for _, messages := range workers {
+		close(messages)
+	}
+	wg.Wait()
+	return running
+}
+
+// commitProcessed commits the offsets the tracker releases, one goroutine keeps the commits of a partition increasing
+func (c *ProductsConsumerGroup) commitProcessed(ctx context.Context, r *kafka.Reader, tracker *offsetTracker, processed <-chan kafka.Message) {
+	for m := range processed {
+		commit, ok := tracker.processed(m)
+		if !ok {
+			continue
+		}
+		if err := r.CommitMessages(ctx, commit); err != nil {
+			errorMessages.Inc()
+			c.log.Errorf("r.CommitMessages: %v", err)
+		}
+	}
+}
+
+// workerIndex worker of the message key, or of its partition when the message has no key
+func workerIndex(m kafka.Message, workerNum int) int {
+	h := fnv.New32a()
+	if len(m.Key) > 0 {
+		h.Write(m.Key)
+	} else {
+		h.Write([]byte{byte(m.Partition >> 24), byte(m.Partition >> 16), byte(m.Partition >> 8), byte(m.Partition)})
+	}
+	return int(h.Sum32() % uint32(workerNum))
+}
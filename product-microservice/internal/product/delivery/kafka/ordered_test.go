@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func message(partition int, offset int64) kafka.Message {
+	return kafka.Message{Topic: "update_product", Partition: partition, Offset: offset}
+}
+
+func TestOffsetTracker_Processed(t *testing.T) {
+	tests := []struct {
+		name      string
+		fetched   []int64
+		processed []int64
+		commits   []int64 // offset committed after each processed message, -1 for none
+	}{
+		{
+			name:      "in order",
+			fetched:   []int64{1, 2, 3},
+			processed: []int64{1, 2, 3},
+			commits:   []int64{1, 2, 3},
+		},
+		{
+			name:      "out of order waits for the earliest",
+			fetched:   []int64{1, 2, 3},
+			processed: []int64{3, 2, 1},
+			commits:   []int64{-1, -1, 3},
+		},
+		{
+			name:      "gap in the middle",
+			fetched:   []int64{1, 2, 3, 4},
+			processed: []int64{1, 3, 4, 2},
+			commits:   []int64{1, -1, -1, 4},
+		},
+		{
+			name:      "offsets with holes",
+			fetched:   []int64{10, 15, 40},
+			processed: []int64{15, 10, 40},
+			commits:   []int64{-1, 15, 40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			for _, offset := range tt.fetched {
+				tracker.fetched(message(0, offset))
+			}
+			for i, offset := range tt.processed {
+				commit, ok := tracker.processed(message(0, offset))
+				if tt.commits[i] < 0 {
+					if ok {
+						t.Fatalf("processed(%d) commits %d, want no commit", offset, commit.Offset)
+					}
+					continue
+				}
+				if !ok || commit.Offset != tt.commits[i] {
+					t.Fatalf("processed(%d) = %d, %v, want %d", offset, commit.Offset, ok, tt.commits[i])
+				}
+				if commit.Topic != "update_product" || commit.Partition != 0 {
+					t.Fatalf("processed(%d) commits %s/%d, want update_product/0", offset, commit.Topic, commit.Partition)
+				}
+			}
+		})
+	}
+}
+
+func TestOffsetTracker_PartitionsAreIndependent(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.fetched(message(0, 1))
+	tracker.fetched(message(1, 1))
+	tracker.fetched(message(0, 2))
+
+	if _, ok := tracker.processed(message(0, 2)); ok {
+		t.Fatal("partition 0 commits while its offset 1 is in flight")
+	}
+	commit, ok := tracker.processed(message(1, 1))
+	if !ok || commit.Partition != 1 || commit.Offset != 1 {
+		t.Fatalf("processed(1/1) = %d/%d, %v, want 1/1", commit.Partition, commit.Offset, ok)
+	}
+	commit, ok = tracker.processed(message(0, 1))
+	if !ok || commit.Partition != 0 || commit.Offset != 2 {
+		t.Fatalf("processed(0/1) = %d/%d, %v, want 0/2", commit.Partition, commit.Offset, ok)
+	}
+}
+
+func TestOffsetTracker_Rewind(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.fetched(message(0, 5))
+	tracker.fetched(message(0, 6))
+
+	// A rebalance rewinds the partition to its committed offset, the offsets in flight before are forgotten
+	tracker.fetched(message(0, 5))
+	if _, ok := tracker.processed(message(0, 6)); ok {
+		t.Fatal("offset 6 of before the rewind commits")
+	}
+	commit, ok := tracker.processed(message(0, 5))
+	if !ok || commit.Offset != 5 {
+		t.Fatalf("processed(5) = %d, %v, want 5", commit.Offset, ok)
+	}
+}
+
+func TestOffsetTracker_UnknownPartition(t *testing.T) {
+	tracker := newOffsetTracker()
+	if _, ok := tracker.processed(message(3, 1)); ok {
+		t.Fatal("a message that was never fetched commits")
+	}
+}
+
+func TestWorkerIndex(t *testing.T) {
+	for _, workerNum := range []int{1, 2, 16} {
+		keyed := kafka.Message{Key: []byte("product"), Partition: 4}
+		if workerIndex(keyed, workerNum) != workerIndex(kafka.Message{Key: []byte("product"), Partition: 7}, workerNum) {
+			t.Fatalf("%d workers: one key goes to two workers", workerNum)
+		}
+		for partition := 0; partition < 32; partition++ {
+			if i := workerIndex(message(partition, 0), workerNum); i < 0 || i >= workerNum {
+				t.Fatalf("%d workers: partition %d goes to worker %d", workerNum, partition, i)
+			}
+		}
+	}
+}
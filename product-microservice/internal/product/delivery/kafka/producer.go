@@ -2,8 +2,10 @@ package kafka
 
 import (
 	"context"
+	"os"
 
 	"github.com/chuuch/product-microservice/config"
+	"github.com/chuuch/product-microservice/internal/models"
 	"github.com/chuuch/product-microservice/pkg/logger"
 	"github.com/segmentio/kafka-go"
 	"github.com/segmentio/kafka-go/compress"
@@ -13,6 +15,9 @@ import (
 type ProductsProducer interface {
 	PublishCreate(ctx context.Context, msgs ...kafka.Message) error
 	PublishUpdate(ctx context.Context, msgs ...kafka.Message) error
+	PublishNotification(ctx context.Context, msgs ...kafka.Message) error
+	NewProductMessage(ctx context.Context, eventType string, product *models.Product) (kafka.Message, error)
+	Republish(ctx context.Context, msgs ...kafka.Message) error
 	Close()
 	Run()
 	GetNewWriter(topic string) *kafka.Writer
@@ -21,23 +26,31 @@ type ProductsProducer interface {
 type productsProducer struct {
 	log          logger.Logger
 	cfg          *config.Config
+	producer     string // service and host written to the events
 	createWriter *kafka.Writer
 	updateWriter *kafka.Writer
+	notifyWriter *kafka.Writer
+	replayWriter *kafka.Writer
 }
 
 func NewProductsProducer(log logger.Logger, cfg *config.Config) *productsProducer {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
 	return &productsProducer{
-		log: log,
-		cfg: cfg,
+		log:      log,
+		cfg:      cfg,
+		producer: cfg.Jaeger.ServiceName + "@" + hostname,
 	}
 }
 
-// GetNewKafkaWriter Create new kafka writer
+// GetNewKafkaWriter Create new kafka writer, messages with the same key go to the same partition
 func (p *productsProducer) GetNewWriter(topic string) *kafka.Writer {
 	w := &kafka.Writer{
 		Addr:         kafka.TCP(p.cfg.Kafka.Brokers...),
 		Topic:        topic,
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     &kafka.Hash{},
 		RequiredAcks: -1,
 		MaxAttempts:  maxAttempts,
 		ReadTimeout:  writerReadTimeout,
@@ -50,13 +63,18 @@ func (p *productsProducer) GetNewWriter(topic string) *kafka.Writer {
 }
 
 func (p *productsProducer) Run() {
-	p.createWriter = p.GetNewWriter(createProductTopic)
-	p.updateWriter = p.GetNewWriter(updateProductTopic)
+	p.createWriter = p.GetNewWriter(p.cfg.Kafka.CreateProductTopic)
+	p.updateWriter = p.GetNewWriter(p.cfg.Kafka.UpdateProductTopic)
+	p.notifyWriter = p.GetNewWriter(p.cfg.Kafka.NotificationsTopic)
+	// Replayed messages name their own topic
+	p.replayWriter = p.GetNewWriter("")
 }
 
 func (p *productsProducer) Close() {
 	p.createWriter.Close()
 	p.updateWriter.Close()
+	p.notifyWriter.Close()
+	p.replayWriter.Close()
 }
 
 func (p *productsProducer) PublishCreate(ctx context.Context, msgs ...kafka.Message) error {
@@ -66,3 +84,12 @@ func (p *productsProducer) PublishCreate(ctx context.Context, msgs ...kafka.Mess
 func (p *productsProducer) PublishUpdate(ctx context.Context, msgs ...kafka.Message) error {
 	return p.updateWriter.WriteMessages(ctx, msgs...)
 }
+
+func (p *productsProducer) PublishNotification(ctx context.Context, msgs ...kafka.Message) error {
+	return p.notifyWriter.WriteMessages(ctx, msgs...)
+}
+
+// Republish writes each message to its own topic
+func (p *productsProducer) Republish(ctx context.Context, msgs ...kafka.Message) error {
+	return p.replayWriter.WriteMessages(ctx, msgs...)
+}
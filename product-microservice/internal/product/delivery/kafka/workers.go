@@ -2,15 +2,16 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
-	"sync"
 	"time"
 
 	"github.com/avast/retry-go"
 	"github.com/chuuch/product-microservice/internal/models"
-	"github.com/opentracing/opentracing-go"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/go-playground/validator/v10"
 	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
 	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
@@ -18,126 +19,100 @@ const (
 	retryDelay    = 1 * time.Second
 )
 
-func (c *ProductsConsumerGroup) createProductWorker(ctx context.Context, cancel context.CancelFunc, r *kafka.Reader, w *kafka.Writer, wg *sync.WaitGroup, workerID int) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductsConsumerGroup.createProductWorker")
+// handleCreateProduct creates the product of the message, failed messages go to the dead letter queue.
+// An error means the message could not be dead lettered either.
+func (c *ProductsConsumerGroup) handleCreateProduct(ctx context.Context, w *kafka.Writer, m kafka.Message) error {
+	span, ctx := startMessageSpan(ctx, "ProductsConsumerGroup.handleCreateProduct", m)
 	defer span.Finish()
 
-	span.LogFields(log.String("ConsumerGroup", r.Config().GroupID))
+	span.LogFields(log.String("Topic", m.Topic), log.Int("Partition", m.Partition), log.Int64("Offset", m.Offset))
+	incomingMessages.Inc()
 
-	defer wg.Done()
-
-	for {
-		m, err := r.FetchMessage(ctx)
-		if err != nil {
-			c.log.Errorf("r.FetchMessage: %v", err)
-			return
-		}
-
-		c.log.Infof(
-			"WORKER: %v, message at topic/partition/offset: %v/%v/%v: %s = %s",
-			workerID,
-			m.Topic,
-			m.Partition,
-			m.Offset,
-			string(m.Key),
-			string(m.Value),
-		)
-		incomingMessages.Inc()
-
-		var prod models.Product
-		if err := json.Unmarshal(m.Value, &prod); err != nil {
-			c.log.Errorf("json.Unmarshal: %v", err)
-			errorMessages.Inc()
-			continue
-		}
-
-		if err := c.validate.StructCtx(ctx, &prod); err != nil {
-			errorMessages.Inc()
-			c.log.Errorf("validate.StructCtx: %v", err)
-			continue
-		}
+	prod, err := decodeProduct(m)
+	if err != nil {
+		c.log.Errorf("decodeProduct: %v", err)
+		return c.deadLetter(ctx, w, m, models.ErrorClassMalformed, err)
+	}
 
-		if err := retry.Do(func() error {
-			created, err := c.productsUC.CreateProduct(ctx, &prod)
-			if err != nil {
-				return err
-			}
-			c.log.Infof("Created product: %v", created.ProductID)
-			return nil
-		}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx)); err != nil {
-			errorMessages.Inc()
-			c.log.Errorf("retry.Do: %v", err)
-			continue
-		}
+	if err := c.validate.StructCtx(ctx, prod); err != nil {
+		c.log.Errorf("validate.StructCtx: %v", err)
+		return c.deadLetter(ctx, w, m, models.ErrorClassInvalid, err)
+	}
 
-		if err := r.CommitMessages(ctx, m); err != nil {
-			errorMessages.Inc()
-			c.log.Errorf("r.CommitMessages: %v", err)
-			continue
+	var created *models.Product
+	if err := retry.Do(func() error {
+		var err error
+		created, err = c.productsUC.CreateProductOnce(ctx, idempotencyKey(m), prod)
+		if err != nil {
+			return err
 		}
-
-		successMessages.Inc()
+		c.log.Infof("Created product: %v", created.ProductID)
+		return nil
+	}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx), retry.LastErrorOnly(true)); err != nil {
+		c.log.Errorf("retry.Do: %v", err)
+		return c.deadLetter(ctx, w, m, errorClass(err), err)
 	}
+	c.completeOperation(ctx, m, created)
+
+	successMessages.Inc()
+	return nil
 }
 
-func (c *ProductsConsumerGroup) updateProductWorker(ctx context.Context, cancel context.CancelFunc, r *kafka.Reader, w *kafka.Writer, wg *sync.WaitGroup, workerID int) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ProductsConsumerGroup.updateProductWorker")
+// handleUpdateProduct updates the product of the message and notifies its subscribers,
+// failed updates go to the dead letter queue. An error means the message could not be dead lettered either.
+func (c *ProductsConsumerGroup) handleUpdateProduct(ctx context.Context, w *kafka.Writer, m kafka.Message) error {
+	span, ctx := startMessageSpan(ctx, "ProductsConsumerGroup.handleUpdateProduct", m)
 	defer span.Finish()
 
-	span.LogFields(log.String("ConsumerGroup", r.Config().GroupID))
+	span.LogFields(log.String("Topic", m.Topic), log.Int("Partition", m.Partition), log.Int64("Offset", m.Offset))
+	incomingMessages.Inc()
 
-	defer wg.Done()
-	defer cancel()
+	prod, err := decodeProduct(m)
+	if err != nil {
+		c.log.Errorf("decodeProduct: %v", err)
+		return c.deadLetter(ctx, w, m, models.ErrorClassMalformed, err)
+	}
 
-	for {
-		m, err := r.FetchMessage(ctx)
+	// The update returns the state it replaced, subscribers fire on the difference
+	var update *models.ProductUpdate
+	if err := retry.Do(func() error {
+		var err error
+		update, err = c.productsUC.UpdateProduct(ctx, prod, nil)
 		if err != nil {
-			c.log.Errorf("r.FetchMessage: %v", err)
-			return
-		}
-
-		c.log.Infof(
-			"WORKER: %v, message at topic/partition/offset: %v/%v/%v: %s = %s",
-			workerID,
-			m.Topic,
-			m.Partition,
-			m.Offset,
-			string(m.Key),
-			string(m.Value),
-		)
-		incomingMessages.Inc()
-
-		var prod models.Product
-		if err := json.Unmarshal(m.Value, &prod); err != nil {
-			c.log.Errorf("json.Unmarshal: %v", err)
-			errorMessages.Inc()
-			continue
+			return err
 		}
+		c.log.Infof("Updated product: %v", update.Updated.ProductID)
+		return nil
+	}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx), retry.LastErrorOnly(true), retry.RetryIf(func(err error) bool {
+		// Another update won or the product does not exist, retrying cannot succeed
+		return !errors.Is(err, productErrors.ErrVersionConflict) && !errors.Is(err, mongo.ErrNoDocuments)
+	})); err != nil {
+		c.log.Errorf("retry.Do: %v", err)
+		return c.deadLetter(ctx, w, m, errorClass(err), err)
+	}
+	c.completeOperation(ctx, m, update.Updated)
 
-		if err := retry.Do(func() error {
-			updated, err := c.productsUC.UpdateProduct(ctx, &prod)
-			if err != nil {
-				return err
-			}
-			c.log.Infof("Updated product: %v", updated.ProductID)
-			return nil
-		}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx)); err != nil {
-			errorMessages.Inc()
-			c.log.Errorf("retry.Do: %v", err)
-			if err := c.publishErrorMessage(ctx, w, m, err); err != nil {
-				errorMessages.Inc()
-				c.log.Errorf("productsConsumerGroup.updateProductWorker.publishErrorMessage: %v", err)
-				continue
-			}
-			continue
-		}
+	if err := retry.Do(func() error {
+		return c.subsUC.NotifySubscribers(ctx, update.Previous, update.Updated)
+	}, retry.Attempts(retryAttempts), retry.Delay(retryDelay), retry.Context(ctx)); err != nil {
+		errorMessages.Inc()
+		c.log.Errorf("subsUC.NotifySubscribers: %v", err)
+	}
 
-		if err := r.CommitMessages(ctx, m); err != nil {
-			errorMessages.Inc()
-			c.log.Errorf("r.CommitMessages: %v", err)
-			continue
-		}
+	successMessages.Inc()
+	return nil
+}
 
-		successMessages.Inc()
+// errorClass of a write that failed on every retry, the retries keep only the last error so it unwraps
+func errorClass(err error) string {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		return models.ErrorClassInvalid
+	case errors.Is(err, productErrors.ErrVersionConflict),
+		errors.Is(err, productErrors.ErrInvalidUpdateMask),
+		errors.Is(err, mongo.ErrNoDocuments):
+		return models.ErrorClassRejected
 	}
+	return models.ErrorClassTransient
 }
@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"time"
 
 	"github.com/chuuch/product-microservice/internal/models"
 	"github.com/chuuch/product-microservice/pkg/utils"
@@ -11,14 +12,41 @@ import (
 // Product repository interface
 type MongoRepository interface {
 	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
-	UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
+	UpdateProduct(ctx context.Context, product *models.Product, fields []string) (*models.ProductUpdate, error)
 	GetProductByID(ctx context.Context, productID primitive.ObjectID) (*models.Product, error)
-	SearchProducts(ctx context.Context, query string, pagination *utils.Pagination) (*models.ProductsList, error)
+	SetPrice(ctx context.Context, productID primitive.ObjectID, price models.Money, expected *models.Money) (*models.Product, error)
+	SetRating(ctx context.Context, productID primitive.ObjectID, sum, count, version int64) (*models.Product, error)
+	GetProductsByIDs(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error)
+	ListProducts(ctx context.Context, filter *models.ProductFilter, fn func(product *models.Product) error) error
+	CreateProducts(ctx context.Context, products []*models.Product) ([]error, error)
+	UpdateProducts(ctx context.Context, products []*models.Product, fields []string) ([]*models.Product, []error, error)
+	SearchProducts(ctx context.Context, query string, skuProductIDs []primitive.ObjectID, pagination *utils.Pagination) (*models.ProductsList, error)
+}
+
+// SKU repository interface
+type SKURepository interface {
+	CreateSKU(ctx context.Context, sku *models.SKU) (*models.SKU, error)
+	UpdateSKU(ctx context.Context, sku *models.SKU) (*models.SKU, error)
+	DeleteSKU(ctx context.Context, skuID primitive.ObjectID) (*models.SKU, error)
+	GetSKUByID(ctx context.Context, skuID primitive.ObjectID) (*models.SKU, error)
+	GetSKUsByProductID(ctx context.Context, productID primitive.ObjectID) ([]*models.SKU, error)
+	GetSKUsByProductIDs(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.SKU, error)
+	FindProductIDsBySKU(ctx context.Context, query string) ([]primitive.ObjectID, error)
+	AdjustStock(ctx context.Context, skuID primitive.ObjectID, delta int64) (*models.SKU, error)
 }
 
 // RedisRepository Product
 type RedisRepository interface {
 	SetProduct(ctx context.Context, product *models.Product) error
 	GetProductByID(ctx context.Context, productID primitive.ObjectID) (*models.Product, error)
-	DeleteProduct(ctx context.Context, productID primitive.ObjectID) error
+	DeleteProducts(ctx context.Context, productIDs []primitive.ObjectID) error
+	GetProductsByIDs(ctx context.Context, productIDs []primitive.ObjectID) (map[primitive.ObjectID]*models.Product, error)
+	SetProducts(ctx context.Context, products []*models.Product) error
+	SetProductMissing(ctx context.Context, productID primitive.ObjectID, ttl time.Duration) error
+	SetProductsMissing(ctx context.Context, productIDs []primitive.ObjectID, ttl time.Duration) error
+	PublishInvalidations(ctx context.Context, productIDs []primitive.ObjectID) error
+	SubscribeInvalidations(ctx context.Context, fn func(productID primitive.ObjectID)) error
+	GetSearch(ctx context.Context, key string) (*models.ProductsList, error)
+	SetSearch(ctx context.Context, key string, list *models.ProductsList, tags []string, ttl time.Duration) error
+	DeleteSearches(ctx context.Context, tags []string) error
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"log"
+	"math"
 	"time"
 
 	"github.com/chuuch/product-microservice/internal/models"
@@ -19,6 +20,7 @@ import (
 const (
 	productsDB         = "products"
 	productsCollection = "products"
+	listBatchSize      = 500
 )
 
 // ProductMongoRepo
@@ -43,6 +45,7 @@ func (p *productMongoRepo) CreateProduct(ctx context.Context, product *models.Pr
 
 	product.CreatedAt = time.Now().UTC()
 	product.UpdatedAt = time.Now().UTC()
+	product.Version = 1
 
 	result, err := collection.InsertOne(ctx, product, &options.InsertOneOptions{})
 	if err != nil {
@@ -60,29 +63,45 @@ func (p *productMongoRepo) CreateProduct(ctx context.Context, product *models.Pr
 	return product, nil
 }
 
-func (p *productMongoRepo) UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
+// UpdateProduct writes only the update mask fields of an existing product, empty values are unset.
+// A product version makes the update conditional, ErrVersionConflict when the stored version differs.
+// The previous product is the document the update replaced, the updated one applies the fields to it.
+func (p *productMongoRepo) UpdateProduct(ctx context.Context, product *models.Product, fields []string) (*models.ProductUpdate, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.UpdateProduct")
 	defer span.Finish()
 
 	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
 
-	upsert := true
-	after := options.After
+	before := options.Before
 	opts := options.FindOneAndUpdateOptions{
-		ReturnDocument: &after,
-		Upsert:         &upsert,
+		ReturnDocument: &before,
 	}
 
-	product.UpdatedAt = time.Now().UTC()
+	// Mongo stores milliseconds, the updated product carries the stored time
+	product.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
 
-	var prod models.Product
-	if err := collection.FindOneAndUpdate(ctx, bson.M{"_id": product.ProductID}, bson.M{"$set": product}, &opts).Decode(&prod); err != nil {
+	update, err := updateDocument(product, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": product.ProductID}
+	if product.Version > 0 {
+		filter["version"] = product.Version
+	}
+
+	var previous models.Product
+	if err := collection.FindOneAndUpdate(ctx, filter, update, &opts).Decode(&previous); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) && product.Version > 0 {
+			return nil, p.versionError(ctx, product.ProductID, product.Version)
+		}
 		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
 	}
+	updated := applyUpdate(&previous, product, fields)
 
-	log.Printf("UPDATE PRODUCT: %+v", prod)
+	log.Printf("UPDATE PRODUCT: %+v", updated)
 
-	return &prod, nil
+	return &models.ProductUpdate{Previous: &previous, Updated: updated}, nil
 }
 
 func (p *productMongoRepo) GetProductByID(ctx context.Context, productID primitive.ObjectID) (*models.Product, error) {
@@ -101,17 +120,292 @@ func (p *productMongoRepo) GetProductByID(ctx context.Context, productID primiti
 	return &prod, nil
 }
 
-func (p *productMongoRepo) SearchProducts(ctx context.Context, query string, pagination *utils.Pagination) (*models.ProductsList, error) {
+// SetPrice changes only the price, when expected is set the current price must still match it
+func (p *productMongoRepo) SetPrice(ctx context.Context, productID primitive.ObjectID, price models.Money, expected *models.Money) (*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.SetPrice")
+	defer span.Finish()
+
+	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
+
+	filter := bson.M{"_id": productID}
+	if expected != nil {
+		filter["price.amount"] = expected.Amount
+		filter["price.currency"] = expected.Currency
+	}
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	update := bson.M{
+		"$set": bson.M{"price": price, "updated_at": time.Now().UTC()},
+		"$inc": bson.M{"version": 1},
+	}
+
+	var prod models.Product
+	if err := collection.FindOneAndUpdate(ctx, filter, update, &opts).Decode(&prod); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &prod, nil
+}
+
+// SetRating replaces the rating totals and average if the product is still at version, ErrVersionConflict when it changed
+func (p *productMongoRepo) SetRating(ctx context.Context, productID primitive.ObjectID, sum, count, version int64) (*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.SetRating")
+	defer span.Finish()
+
+	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
+
+	var average float64
+	if count > 0 {
+		average = math.Round(float64(sum)/float64(count)*100) / 100
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"rating_sum":     sum,
+			"rating_count":   count,
+			"rating_average": average,
+			"updated_at":     time.Now().UTC(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	// Products written before versions have none
+	filter := bson.M{"_id": productID, "version": version}
+	if version == 0 {
+		filter["version"] = bson.M{"$exists": false}
+	}
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	var prod models.Product
+	if err := collection.FindOneAndUpdate(ctx, filter, update, &opts).Decode(&prod); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, p.versionError(ctx, productID, version)
+		}
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &prod, nil
+}
+
+// GetProductsByIDs products with the ids in one $in query, unknown ids are skipped
+func (p *productMongoRepo) GetProductsByIDs(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.GetProductsByIDs")
+	defer span.Finish()
+
+	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": productIDs}})
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	products := make([]*models.Product, 0, len(productIDs))
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return products, nil
+}
+
+// ListProducts calls fn for every product of the filter in id order, an error from fn stops the scan
+func (p *productMongoRepo) ListProducts(ctx context.Context, filter *models.ProductFilter, fn func(product *models.Product) error) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.ListProducts")
+	defer span.Finish()
+
+	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
+
+	f := bson.M{}
+	if filter.CategoryID != nil {
+		f["category_id"] = *filter.CategoryID
+	}
+	if filter.AfterID != nil {
+		f["_id"] = bson.M{"$gt": *filter.AfterID}
+	}
+
+	cursor, err := collection.Find(ctx, f, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetBatchSize(listBatchSize))
+	if err != nil {
+		return errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var prod models.Product
+		if err := cursor.Decode(&prod); err != nil {
+			return errors.Wrap(err, "cursor.Decode failed")
+		}
+		if err := fn(&prod); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "cursor.Err")
+	}
+
+	return nil
+}
+
+// CreateProducts inserts the products with one unordered bulk write, the returned errors are per product and nil for inserted ones
+func (p *productMongoRepo) CreateProducts(ctx context.Context, products []*models.Product) ([]error, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.CreateProducts")
+	defer span.Finish()
+
+	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
+
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(products))
+	for _, product := range products {
+		if product.ProductID.IsZero() {
+			product.ProductID = primitive.NewObjectID()
+		}
+		product.CreatedAt = now
+		product.UpdatedAt = now
+		product.Version = 1
+		writes = append(writes, mongo.NewInsertOneModel().SetDocument(product))
+	}
+
+	itemErrors := make([]error, len(products))
+	if len(writes) == 0 {
+		return itemErrors, nil
+	}
+
+	_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			itemErrors[writeErr.Index] = errors.Wrap(writeErr, "BulkWrite")
+		}
+		return itemErrors, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "BulkWrite failed")
+	}
+
+	return itemErrors, nil
+}
+
+// UpdateProducts writes the update mask fields of the products like UpdateProduct and returns the updated products.
+// Unversioned products are written with one unordered bulk write, versioned ones afterwards with one conditional
+// FindOneAndUpdate each, so a failed call never leaves a versioned update applied that a retry would see as a conflict.
+// The returned products and errors are per product, exactly one of them is set. A product appears at most once.
+func (p *productMongoRepo) UpdateProducts(ctx context.Context, products []*models.Product, fields []string) ([]*models.Product, []error, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.UpdateProducts")
+	defer span.Finish()
+
+	updated := make([]*models.Product, len(products))
+	itemErrors := make([]error, len(products))
+
+	unversioned := make([]int, 0, len(products))
+	for i, product := range products {
+		if product.Version == 0 {
+			unversioned = append(unversioned, i)
+		}
+	}
+	if err := p.bulkUpdate(ctx, products, unversioned, fields, updated, itemErrors); err != nil {
+		return nil, nil, err
+	}
+
+	for i, product := range products {
+		if product.Version == 0 {
+			continue
+		}
+		update, err := p.UpdateProduct(ctx, product, fields)
+		if err != nil {
+			itemErrors[i] = err
+			continue
+		}
+		updated[i] = update.Updated
+	}
+
+	return updated, itemErrors, nil
+}
+
+// bulkUpdate writes the unversioned products at positions with one unordered bulk write and reads them back
+// into updated, a product the write did not match gets mongo.ErrNoDocuments
+func (p *productMongoRepo) bulkUpdate(ctx context.Context, products []*models.Product, positions []int, fields []string, updated []*models.Product, itemErrors []error) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
+
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(positions))
+	for _, i := range positions {
+		products[i].UpdatedAt = now
+		update, err := updateDocument(products[i], fields)
+		if err != nil {
+			return err
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": products[i].ProductID}).SetUpdate(update))
+	}
+
+	_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			itemErrors[positions[writeErr.Index]] = errors.Wrap(writeErr, "BulkWrite")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "BulkWrite failed")
+	}
+
+	productIDs := make([]primitive.ObjectID, 0, len(positions))
+	for _, i := range positions {
+		if itemErrors[i] == nil {
+			productIDs = append(productIDs, products[i].ProductID)
+		}
+	}
+	stored, err := p.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	byID := make(map[primitive.ObjectID]*models.Product, len(stored))
+	for _, product := range stored {
+		byID[product.ProductID] = product
+	}
+
+	for _, i := range positions {
+		if itemErrors[i] != nil {
+			continue
+		}
+		product, ok := byID[products[i].ProductID]
+		if !ok {
+			itemErrors[i] = errors.Wrap(mongo.ErrNoDocuments, "BulkWrite")
+			continue
+		}
+		updated[i] = product
+	}
+
+	return nil
+}
+
+// SearchProducts by name or description, skuProductIDs adds the products whose variants matched the query
+func (p *productMongoRepo) SearchProducts(ctx context.Context, query string, skuProductIDs []primitive.ObjectID, pagination *utils.Pagination) (*models.ProductsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productMongoRepo.SearchProducts")
 	defer span.Finish()
 
 	collection := p.mongoDB.Database(productsDB).Collection(productsCollection)
 
+	or := bson.A{
+		bson.D{{Key: "name", Value: primitive.Regex{Pattern: query, Options: "i"}}},
+		bson.D{{Key: "description", Value: primitive.Regex{Pattern: query, Options: "i"}}},
+	}
+	if len(skuProductIDs) > 0 {
+		or = append(or, bson.D{{Key: "_id", Value: bson.M{"$in": skuProductIDs}}})
+	}
+
 	f := bson.D{
-		{Key: "$or", Value: bson.A{
-			bson.D{{Key: "name", Value: primitive.Regex{Pattern: query, Options: "i"}}},
-			bson.D{{Key: "description", Value: primitive.Regex{Pattern: query, Options: "i"}}},
-		}},
+		{Key: "$or", Value: or},
 	}
 
 	count, err := collection.CountDocuments(ctx, f)
@@ -165,3 +459,102 @@ func (p *productMongoRepo) SearchProducts(ctx context.Context, query string, pag
 		Products:   products,
 	}, nil
 }
+
+// updateDocument $set of the mask fields with a value and $unset of the cleared ones
+func updateDocument(product *models.Product, fields []string) (bson.M, error) {
+	set := bson.M{"updated_at": product.UpdatedAt}
+	unset := bson.M{}
+
+	setOrUnset := func(key string, value any, empty bool) {
+		if empty {
+			unset[key] = ""
+			return
+		}
+		set[key] = value
+	}
+
+	for _, field := range fields {
+		switch field {
+		case "category_id":
+			setOrUnset(field, product.CategoryID, product.CategoryID.IsZero())
+		case "name":
+			set[field] = product.Name
+		case "description":
+			set[field] = product.Description
+		case "price":
+			set[field] = product.Price
+		case "prices":
+			setOrUnset(field, product.Prices, len(product.Prices) == 0)
+		case "image_url":
+			setOrUnset(field, product.ImageURL, product.GetImageURL() == "")
+		case "photos":
+			setOrUnset(field, product.Photos, len(product.Photos) == 0)
+		case "quantity":
+			set[field] = product.Quantity
+		case "options":
+			setOrUnset(field, product.Options, len(product.Options) == 0)
+		default:
+			return nil, errors.Wrapf(productErrors.ErrInvalidUpdateMask, "field %s", field)
+		}
+	}
+
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+// applyUpdate the product stored after updateDocument of the fields replaced the previous product
+func applyUpdate(previous *models.Product, product *models.Product, fields []string) *models.Product {
+	updated := *previous
+	for _, field := range fields {
+		switch field {
+		case "category_id":
+			updated.CategoryID = product.CategoryID
+		case "name":
+			updated.Name = product.Name
+		case "description":
+			updated.Description = product.Description
+		case "price":
+			updated.Price = product.Price
+		case "prices":
+			updated.Prices = nil
+			if len(product.Prices) > 0 {
+				updated.Prices = product.Prices
+			}
+		case "image_url":
+			updated.ImageURL = nil
+			if product.GetImageURL() != "" {
+				updated.ImageURL = product.ImageURL
+			}
+		case "photos":
+			updated.Photos = nil
+			if len(product.Photos) > 0 {
+				updated.Photos = product.Photos
+			}
+		case "quantity":
+			updated.Quantity = product.Quantity
+		case "options":
+			updated.Options = nil
+			if len(product.Options) > 0 {
+				updated.Options = product.Options
+			}
+		}
+	}
+	updated.UpdatedAt = product.UpdatedAt
+	updated.Version = previous.Version + 1
+	return &updated
+}
+
+// versionError tells a stale version from a missing product after a conditional update matched nothing
+func (p *productMongoRepo) versionError(ctx context.Context, productID primitive.ObjectID, version int64) error {
+	count, err := p.mongoDB.Database(productsDB).Collection(productsCollection).CountDocuments(ctx, bson.M{"_id": productID}, options.Count().SetLimit(1))
+	if err != nil {
+		return errors.Wrap(err, "CountDocuments failed")
+	}
+	if count == 0 {
+		return errors.Wrap(mongo.ErrNoDocuments, "FindOneAndUpdate")
+	}
+	return errors.Wrapf(productErrors.ErrVersionConflict, "expected version %d", version)
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/chuuch/product-microservice/internal/models"
@@ -11,23 +14,42 @@ import (
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
-	prefix     = "products"
-	expiration = time.Second * 3600
+	prefix       = "products"
+	searchPrefix = "products:search"
+
+	invalidationChannel = "products:invalidations" // product ids changed by any replica
+	missingMarker       = "-"                      // cached value of an unknown product id
 )
 
+// deleteSearchesScript deletes the pages of the tag sets and the sets in one step, a page cached meanwhile
+// is never added to a set that is deleted right after. DEL is called in batches to stay below the unpack limit.
+var deleteSearchesScript = redis.NewScript(`
+local keys = redis.call('SUNION', unpack(KEYS))
+for i = 1, #keys, 1000 do
+	redis.call('DEL', unpack(keys, i, math.min(i + 999, #keys)))
+end
+return redis.call('DEL', unpack(KEYS))
+`)
+
 type productRedisRepo struct {
 	prefix string
 	redis  *redis.Client
+	ttl    time.Duration
+	jitter time.Duration
 }
 
-// NewProductRedisRepository constructor
-func NewProductRedisRepository(redis *redis.Client) *productRedisRepo {
+// NewProductRedisRepository constructor, products are kept for ttl plus a random part of jitter
+// so entries written together do not expire together
+func NewProductRedisRepository(redis *redis.Client, ttl time.Duration, jitter time.Duration) *productRedisRepo {
 	return &productRedisRepo{
 		prefix: prefix,
 		redis:  redis,
+		ttl:    ttl,
+		jitter: jitter,
 	}
 }
 
@@ -41,7 +63,35 @@ func (r *productRedisRepo) SetProduct(ctx context.Context, product *models.Produ
 		return errors.Wrap(err, "json.Marshal failed")
 	}
 
-	return r.redis.Set(ctx, r.createKey(product.ProductID), string(prodBytes), expiration).Err()
+	return r.redis.Set(ctx, r.createKey(product.ProductID), string(prodBytes), r.expiration()).Err()
+}
+
+// SetProductMissing caches that the product does not exist for ttl
+func (r *productRedisRepo) SetProductMissing(ctx context.Context, productID primitive.ObjectID, ttl time.Duration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.SetProductMissing")
+	defer span.Finish()
+
+	return r.redis.Set(ctx, r.createKey(productID), missingMarker, ttl).Err()
+}
+
+// SetProductsMissing caches that the products do not exist for ttl in one pipeline
+func (r *productRedisRepo) SetProductsMissing(ctx context.Context, productIDs []primitive.ObjectID, ttl time.Duration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.SetProductsMissing")
+	defer span.Finish()
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, productID := range productIDs {
+		pipe.Set(ctx, r.createKey(productID), missingMarker, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "pipe.Exec failed")
+	}
+	return nil
 }
 
 // GetProductByID get product by id from redis
@@ -53,6 +103,9 @@ func (r *productRedisRepo) GetProductByID(ctx context.Context, productID primiti
 	if err != nil {
 		return nil, errors.Wrap(err, "redis.Get failed")
 	}
+	if string(result) == missingMarker {
+		return nil, errors.Wrap(mongo.ErrNoDocuments, "cached as missing")
+	}
 
 	var res models.Product
 	if err := json.Unmarshal(result, &res); err != nil {
@@ -62,14 +115,215 @@ func (r *productRedisRepo) GetProductByID(ctx context.Context, productID primiti
 	return &res, nil
 }
 
-// DeleteProduct delete product by id from redis
-func (r *productRedisRepo) DeleteProduct(ctx context.Context, productID primitive.ObjectID) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.DeleteProduct")
+// DeleteProducts delete products by id from redis with a single DEL
+func (r *productRedisRepo) DeleteProducts(ctx context.Context, productIDs []primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.DeleteProducts")
+	defer span.Finish()
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		keys = append(keys, r.createKey(productID))
+	}
+
+	return r.redis.Del(ctx, keys...).Err()
+}
+
+// GetProductsByIDs cached products by id with a single MGET, misses are absent from the map
+// and the ids cached as missing map to nil
+func (r *productRedisRepo) GetProductsByIDs(ctx context.Context, productIDs []primitive.ObjectID) (map[primitive.ObjectID]*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.GetProductsByIDs")
+	defer span.Finish()
+
+	keys := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		keys = append(keys, r.createKey(productID))
+	}
+
+	values, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "redis.MGet failed")
+	}
+
+	products := make(map[primitive.ObjectID]*models.Product, len(values))
+	for i, value := range values {
+		cached, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if cached == missingMarker {
+			products[productIDs[i]] = nil
+			continue
+		}
+
+		var res models.Product
+		if err := json.Unmarshal([]byte(cached), &res); err != nil {
+			return nil, errors.Wrap(err, "json.Unmarshal failed")
+		}
+		products[productIDs[i]] = &res
+	}
+
+	return products, nil
+}
+
+// SetProducts caches the products in one pipeline
+func (r *productRedisRepo) SetProducts(ctx context.Context, products []*models.Product) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.SetProducts")
+	defer span.Finish()
+
+	if len(products) == 0 {
+		return nil
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, product := range products {
+		prodBytes, err := json.Marshal(product)
+		if err != nil {
+			return errors.Wrap(err, "json.Marshal failed")
+		}
+		pipe.Set(ctx, r.createKey(product.ProductID), string(prodBytes), r.expiration())
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "pipe.Exec failed")
+	}
+
+	return nil
+}
+
+// PublishInvalidations tells every replica the products changed, in one pipeline
+func (r *productRedisRepo) PublishInvalidations(ctx context.Context, productIDs []primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.PublishInvalidations")
+	defer span.Finish()
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, productID := range productIDs {
+		pipe.Publish(ctx, invalidationChannel, productID.Hex())
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "pipe.Exec failed")
+	}
+
+	return nil
+}
+
+// SubscribeInvalidations calls fn with the ids of changed products until ctx is done
+func (r *productRedisRepo) SubscribeInvalidations(ctx context.Context, fn func(productID primitive.ObjectID)) error {
+	pubsub := r.redis.Subscribe(ctx, invalidationChannel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return errors.Wrap(err, "pubsub.Receive failed")
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return errors.New("invalidation subscription closed")
+			}
+			productID, err := primitive.ObjectIDFromHex(message.Payload)
+			if err != nil {
+				continue
+			}
+			fn(productID)
+		}
+	}
+}
+
+// GetSearch cached search result page of the key, redis.Nil when it is not cached
+func (r *productRedisRepo) GetSearch(ctx context.Context, key string) (*models.ProductsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.GetSearch")
+	defer span.Finish()
+
+	result, err := r.redis.Get(ctx, r.createSearchKey(key)).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "redis.Get failed")
+	}
+
+	var res models.ProductsList
+	if err := json.Unmarshal(result, &res); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal failed")
+	}
+
+	return &res, nil
+}
+
+// SetSearch caches the search result page for ttl and adds it to the set of each tag
+func (r *productRedisRepo) SetSearch(ctx context.Context, key string, list *models.ProductsList, tags []string, ttl time.Duration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.SetSearch")
 	defer span.Finish()
 
-	return r.redis.Del(ctx, r.createKey(productID)).Err()
+	listBytes, err := json.Marshal(list)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal failed")
+	}
+
+	searchKey := r.createSearchKey(key)
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, searchKey, string(listBytes), ttl)
+	for _, tag := range tags {
+		// Members outlive their entries at most by ttl, deleting an expired entry is a no-op
+		pipe.SAdd(ctx, r.createTagKey(tag), searchKey)
+		pipe.Expire(ctx, r.createTagKey(tag), ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "pipe.Exec failed")
+	}
+
+	return nil
+}
+
+// DeleteSearches deletes the search result pages of the tags atomically
+func (r *productRedisRepo) DeleteSearches(ctx context.Context, tags []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productRedisRepo.DeleteSearches")
+	defer span.Finish()
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagKeys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagKeys = append(tagKeys, r.createTagKey(tag))
+	}
+
+	if err := deleteSearchesScript.Run(ctx, r.redis, tagKeys).Err(); err != nil {
+		return errors.Wrap(err, "deleteSearchesScript.Run failed")
+	}
+
+	return nil
+}
+
+func (r *productRedisRepo) expiration() time.Duration {
+	if r.jitter <= 0 {
+		return r.ttl
+	}
+	return r.ttl + rand.N(r.jitter)
 }
 
 func (r *productRedisRepo) createKey(productID primitive.ObjectID) string {
 	return fmt.Sprintf("%s:%s", r.prefix, productID.String())
 }
+
+// createSearchKey hashes the key, search queries are user input of any length
+func (r *productRedisRepo) createSearchKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s:%s", searchPrefix, hex.EncodeToString(sum[:]))
+}
+
+func (r *productRedisRepo) createTagKey(tag string) string {
+	return fmt.Sprintf("%s:tag:%s", searchPrefix, tag)
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	skusCollection = "skus"
+)
+
+type skuMongoRepo struct {
+	mongoDB *mongo.Client
+}
+
+// SKUMongo Constructor
+func NewSKUMongoRepository(mongoDB *mongo.Client) *skuMongoRepo {
+	return &skuMongoRepo{
+		mongoDB: mongoDB,
+	}
+}
+
+func (s *skuMongoRepo) collection() *mongo.Collection {
+	return s.mongoDB.Database(productsDB).Collection(skusCollection)
+}
+
+// CreateIndexes unique sku codes, variants listed by product
+func (s *skuMongoRepo) CreateIndexes(ctx context.Context) error {
+	_, err := s.collection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "barcode", Value: 1}},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "Indexes.CreateMany")
+	}
+	return nil
+}
+
+func (s *skuMongoRepo) CreateSKU(ctx context.Context, sku *models.SKU) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.CreateSKU")
+	defer span.Finish()
+
+	sku.CreatedAt = time.Now().UTC()
+	sku.UpdatedAt = time.Now().UTC()
+
+	result, err := s.collection().InsertOne(ctx, sku)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.Wrapf(productErrors.ErrSKUCodeExists, "code: %s", sku.Code)
+		}
+		return nil, errors.Wrap(err, "InsertOne.Collection")
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, errors.Wrap(productErrors.ErrObjectIDTypeConversion, "InsertOne.Collection")
+	}
+
+	sku.SKUID = objectID
+
+	if err := s.touchProduct(ctx, sku.ProductID); err != nil {
+		return nil, err
+	}
+
+	return sku, nil
+}
+
+// UpdateSKU replaces the sku fields, stock is only changed through AdjustStock
+func (s *skuMongoRepo) UpdateSKU(ctx context.Context, sku *models.SKU) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.UpdateSKU")
+	defer span.Finish()
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	set := bson.M{
+		"code":       sku.Code,
+		"options":    sku.Options,
+		"barcode":    sku.Barcode,
+		"images":     sku.Images,
+		"updated_at": time.Now().UTC(),
+	}
+	update := bson.M{"$set": set}
+	if sku.Price != nil {
+		set["price"] = *sku.Price
+	} else {
+		update["$unset"] = bson.M{"price": ""}
+	}
+
+	var updated models.SKU
+	if err := s.collection().FindOneAndUpdate(ctx, bson.M{"_id": sku.SKUID}, update, &opts).Decode(&updated); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.Wrapf(productErrors.ErrSKUCodeExists, "code: %s", sku.Code)
+		}
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	if err := s.touchProduct(ctx, updated.ProductID); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteSKU deletes the sku and returns it
+func (s *skuMongoRepo) DeleteSKU(ctx context.Context, skuID primitive.ObjectID) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.DeleteSKU")
+	defer span.Finish()
+
+	var deleted models.SKU
+	if err := s.collection().FindOneAndDelete(ctx, bson.M{"_id": skuID}).Decode(&deleted); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndDelete failed")
+	}
+
+	if err := s.touchProduct(ctx, deleted.ProductID); err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+func (s *skuMongoRepo) GetSKUByID(ctx context.Context, skuID primitive.ObjectID) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.GetSKUByID")
+	defer span.Finish()
+
+	var sku models.SKU
+	if err := s.collection().FindOne(ctx, bson.M{"_id": skuID}).Decode(&sku); err != nil {
+		return nil, errors.Wrap(err, "FindOne failed")
+	}
+
+	return &sku, nil
+}
+
+func (s *skuMongoRepo) GetSKUsByProductID(ctx context.Context, productID primitive.ObjectID) ([]*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.GetSKUsByProductID")
+	defer span.Finish()
+
+	cursor, err := s.collection().Find(ctx, bson.M{"product_id": productID}, options.Find().SetSort(bson.D{{Key: "code", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	skus := make([]*models.SKU, 0)
+	if err := cursor.All(ctx, &skus); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return skus, nil
+}
+
+// GetSKUsByProductIDs skus of all the products with one query, by product then code
+func (s *skuMongoRepo) GetSKUsByProductIDs(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.GetSKUsByProductIDs")
+	defer span.Finish()
+
+	f := bson.M{"product_id": bson.M{"$in": productIDs}}
+	cursor, err := s.collection().Find(ctx, f, options.Find().SetSort(bson.D{{Key: "product_id", Value: 1}, {Key: "code", Value: 1}}))
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	skus := make([]*models.SKU, 0)
+	if err := cursor.All(ctx, &skus); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return skus, nil
+}
+
+// FindProductIDsBySKU products having a sku whose code or barcode matches the query
+func (s *skuMongoRepo) FindProductIDsBySKU(ctx context.Context, query string) ([]primitive.ObjectID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.FindProductIDsBySKU")
+	defer span.Finish()
+
+	f := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "code", Value: primitive.Regex{Pattern: query, Options: "i"}}},
+			bson.D{{Key: "barcode", Value: query}},
+		}},
+	}
+
+	values, err := s.collection().Distinct(ctx, "product_id", f)
+	if err != nil {
+		return nil, errors.Wrap(err, "Distinct failed")
+	}
+
+	productIDs := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if productID, ok := value.(primitive.ObjectID); ok {
+			productIDs = append(productIDs, productID)
+		}
+	}
+
+	return productIDs, nil
+}
+
+// AdjustStock atomically changes the sku quantity, never below zero
+func (s *skuMongoRepo) AdjustStock(ctx context.Context, skuID primitive.ObjectID, delta int64) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "skuMongoRepo.AdjustStock")
+	defer span.Finish()
+
+	filter := bson.M{"_id": skuID}
+	if delta < 0 {
+		filter["quantity"] = bson.M{"$gte": -delta}
+	}
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	var sku models.SKU
+	err := s.collection().FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$inc": bson.M{"quantity": delta}, "$set": bson.M{"updated_at": time.Now().UTC()}},
+		&opts,
+	).Decode(&sku)
+	if err == nil {
+		if err := s.touchProduct(ctx, sku.ProductID); err != nil {
+			return nil, err
+		}
+		return &sku, nil
+	}
+
+	if !errors.Is(err, mongo.ErrNoDocuments) || delta >= 0 {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	// Tell a missing sku from one without enough stock
+	if _, err := s.GetSKUByID(ctx, skuID); err != nil {
+		return nil, err
+	}
+
+	return nil, errors.Wrapf(productErrors.ErrInsufficientStock, "sku: %s, delta: %d", skuID.Hex(), delta)
+}
+
+// touchProduct increments the version of the product of a changed sku, its variants are part of the product and its ETag
+func (s *skuMongoRepo) touchProduct(ctx context.Context, productID primitive.ObjectID) error {
+	if _, err := s.mongoDB.Database(productsDB).Collection(productsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": productID},
+		bson.M{"$inc": bson.M{"version": 1}, "$set": bson.M{"updated_at": time.Now().UTC()}},
+	); err != nil {
+		return errors.Wrap(err, "UpdateOne failed")
+	}
+	return nil
+}
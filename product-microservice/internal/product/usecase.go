@@ -11,9 +11,21 @@ import (
 // UseCase product
 type UseCase interface {
 	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
-	UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
+	CreateProductOnce(ctx context.Context, idempotencyKey string, product *models.Product) (*models.Product, error)
+	UpdateProduct(ctx context.Context, product *models.Product, fields []string) (*models.ProductUpdate, error)
+	UpsertProduct(ctx context.Context, product *models.Product, fields []string) (*models.Product, bool, error)
 	GetProductByID(ctx context.Context, productID primitive.ObjectID) (*models.Product, error)
 	SearchProducts(ctx context.Context, query string, pagination *utils.Pagination) (*models.ProductsList, error)
-	PublishCreate(ctx context.Context, product *models.Product) error
-	PublishUpdate(ctx context.Context, product *models.Product) error
+	GetProductsByIDs(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error)
+	ListProducts(ctx context.Context, filter *models.ProductFilter, fn func(product *models.Product) error) error
+	BatchCreateProducts(ctx context.Context, products []*models.Product) ([]error, error)
+	BatchUpdateProducts(ctx context.Context, products []*models.Product) ([]*models.ProductUpdate, []error, error)
+	PublishCreate(ctx context.Context, product *models.Product, idempotencyKey string) (*models.Operation, error)
+	PublishUpdate(ctx context.Context, product *models.Product) (*models.Operation, error)
+	LocalizePrices(ctx context.Context, products []*models.Product, currency string) error
+	CreateSKU(ctx context.Context, productID primitive.ObjectID, sku *models.SKU) (*models.SKU, error)
+	UpdateSKU(ctx context.Context, sku *models.SKU) (*models.SKU, error)
+	DeleteSKU(ctx context.Context, skuID primitive.ObjectID) error
+	GetSKUsByProductID(ctx context.Context, productID primitive.ObjectID) ([]*models.SKU, error)
+	AdjustStock(ctx context.Context, skuID primitive.ObjectID, delta int64) (*models.SKU, error)
 }
@@ -1,44 +1,83 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
-	"time"
+	"fmt"
+	"strings"
 
+	"github.com/chuuch/product-microservice/internal/currency"
+	"github.com/chuuch/product-microservice/internal/idempotency"
 	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/operation"
+	"github.com/chuuch/product-microservice/internal/pricing"
 	"github.com/chuuch/product-microservice/internal/product"
 
 	productKafka "github.com/chuuch/product-microservice/internal/product/delivery/kafka"
 	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
 	"github.com/chuuch/product-microservice/pkg/utils"
 	"github.com/go-playground/validator/v10"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/segmentio/kafka-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Most product ids of one GetProductsByIDs call
+const maxProductIDs = 500
+
+var (
+	upsertedProducts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "products_upserted_total",
+		Help: "Total number of upserted products by operation, insert or update",
+	}, []string{"operation"})
+
+	idempotentReplays = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "products_idempotent_replays_total",
+		Help: "Total number of requests answered with the result stored for their idempotency key",
+	}, []string{"operation"})
 )
 
 type productUC struct {
 	productRepo      product.MongoRepository
+	skuRepo          product.SKURepository
 	log              logger.Logger
 	validate         *validator.Validate
-	redisRepo        product.RedisRepository
+	productCache     product.Cache
 	productsProducer productKafka.ProductsProducer
+	currencyUC       currency.UseCase
+	pricingUC        pricing.UseCase
+	operationUC      operation.UseCase
+	idempotencyRepo  idempotency.RedisRepository
 }
 
 func NewProductUC(
 	productRepo product.MongoRepository,
+	skuRepo product.SKURepository,
 	log logger.Logger,
 	validate *validator.Validate,
-	redisRepo product.RedisRepository,
+	productCache product.Cache,
 	productsProducer productKafka.ProductsProducer,
+	currencyUC currency.UseCase,
+	pricingUC pricing.UseCase,
+	operationUC operation.UseCase,
+	idempotencyRepo idempotency.RedisRepository,
 ) *productUC {
 	return &productUC{
 		productRepo:      productRepo,
+		skuRepo:          skuRepo,
 		log:              log,
 		validate:         validate,
-		redisRepo:        redisRepo,
+		productCache:     productCache,
 		productsProducer: productsProducer,
+		currencyUC:       currencyUC,
+		pricingUC:        pricingUC,
+		operationUC:      operationUC,
+		idempotencyRepo:  idempotencyRepo,
 	}
 }
 
@@ -46,83 +85,683 @@ func (u *productUC) CreateProduct(ctx context.Context, product *models.Product)
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.CreateProduct")
 	defer span.Finish()
 
+	clearRating(product)
 	product, err := u.productRepo.CreateProduct(ctx, product)
 	if err != nil {
 		return nil, errors.Wrap(err, "productRepo.CreateProduct failed")
 	}
 
-	if err := u.redisRepo.SetProduct(ctx, product); err != nil {
-		return nil, errors.Wrap(err, "redisRepo.SetProduct failed")
+	if err := u.pricingUC.RecordPriceChange(ctx, nil, product, models.PriceChangeSourceCreate); err != nil {
+		return nil, errors.Wrap(err, "pricingUC.RecordPriceChange failed")
+	}
+
+	// The product is created, a cache failure only costs a database read
+	if err := u.productCache.SetProduct(ctx, product); err != nil {
+		u.log.Errorf("productCache.SetProduct: %v", err)
+	}
+	if err := u.productCache.InvalidateSearches(ctx, product.CategoryID); err != nil {
+		u.log.Errorf("productCache.InvalidateSearches: %v", err)
 	}
 
 	return product, nil
 }
 
-func (u *productUC) UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.UpdateProduct")
+// CreateProductOnce creates the product once per idempotency key, a replay returns the product created for the key.
+// The key is reserved with the product id, so a retry of the same product finds the product it inserted.
+func (u *productUC) CreateProductOnce(ctx context.Context, idempotencyKey string, product *models.Product) (*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.CreateProductOnce")
 	defer span.Finish()
 
-	return u.productRepo.UpdateProduct(ctx, product)
+	if idempotencyKey == "" {
+		return u.CreateProduct(ctx, product)
+	}
+	if err := idempotency.ValidateKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	if product.ProductID.IsZero() {
+		product.ProductID = primitive.NewObjectID()
+	}
+	stored, reserved, err := u.idempotencyRepo.Reserve(ctx, createProductKey(idempotencyKey), product.ProductID.Hex())
+	if err != nil {
+		return nil, errors.Wrap(err, "idempotencyRepo.Reserve")
+	}
+	if !reserved && stored != product.ProductID.Hex() {
+		productID, err := primitive.ObjectIDFromHex(stored)
+		if err != nil {
+			return nil, errors.Wrap(err, "primitive.ObjectIDFromHex")
+		}
+		idempotentReplays.WithLabelValues(models.OperationTypeCreateProduct).Inc()
+		existing, err := u.GetProductByID(ctx, productID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// The first request reserved the key and has not inserted the product yet
+			return nil, errors.Wrapf(productErrors.ErrRequestInProgress, "product %s", stored)
+		}
+		return existing, err
+	}
+
+	created, err := u.CreateProduct(ctx, product)
+	if mongo.IsDuplicateKeyError(err) {
+		// Inserted by an earlier attempt that failed after the insert
+		if existing, getErr := u.productRepo.GetProductByID(ctx, product.ProductID); getErr == nil {
+			idempotentReplays.WithLabelValues(models.OperationTypeCreateProduct).Inc()
+			return existing, nil
+		}
+	}
+	if err != nil {
+		if reserved {
+			if err := u.idempotencyRepo.Release(ctx, createProductKey(idempotencyKey)); err != nil {
+				u.log.Errorf("idempotencyRepo.Release: %v", err)
+			}
+		}
+		return nil, err
+	}
+
+	return created, nil
 }
 
-func (u *productUC) GetProductByID(ctx context.Context, productID primitive.ObjectID) (*models.Product, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.GetProductByID")
+// UpdateProduct changes the fields of the update mask, an empty mask replaces every updatable field.
+// It returns the product before and after the update.
+func (u *productUC) UpdateProduct(ctx context.Context, product *models.Product, fields []string) (*models.ProductUpdate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.UpdateProduct")
 	defer span.Finish()
 
-	cached, err := u.productRepo.GetProductByID(ctx, productID)
+	if len(fields) == 0 {
+		fields = models.ProductMaskPaths()
+	}
+	if err := u.validateUpdate(ctx, product, fields); err != nil {
+		return nil, err
+	}
+
+	update, err := u.productRepo.UpdateProduct(ctx, product, fields)
 	if err != nil {
-		return nil, errors.Wrap(err, "redisRepo.GetProductByID failed")
+		return nil, errors.Wrap(err, "productRepo.UpdateProduct failed")
 	}
+	updated := update.Updated
 
-	if cached != nil {
-		return cached, nil
+	if err := u.pricingUC.RecordPriceChange(ctx, update.Previous, updated, models.PriceChangeSourceUpdate); err != nil {
+		return nil, errors.Wrap(err, "pricingUC.RecordPriceChange failed")
 	}
 
-	product, err := u.productRepo.GetProductByID(ctx, productID)
+	if err := u.productCache.InvalidateProduct(ctx, updated.ProductID); err != nil {
+		u.log.Errorf("productCache.InvalidateProduct: %v", err)
+	}
+	// Searches of the new category may show the product now
+	if err := u.productCache.InvalidateSearches(ctx, updated.CategoryID); err != nil {
+		u.log.Errorf("productCache.InvalidateSearches: %v", err)
+	}
+
+	return update, nil
+}
+
+// UpsertProduct updates the product or inserts it with its id, inserted is true when it did not exist.
+// Only an insert needs the whole product, an update changes the fields of the mask.
+func (u *productUC) UpsertProduct(ctx context.Context, product *models.Product, fields []string) (*models.Product, bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.UpsertProduct")
+	defer span.Finish()
+
+	if product.ProductID.IsZero() {
+		return nil, false, errors.Wrap(productErrors.ErrProductIDRequired, "UpsertProduct")
+	}
+	// Sync jobs write the latest catalog state, they do not hold a version
+	product.Version = 0
+
+	// A concurrent insert of the same id turns the insert into an update on the second attempt
+	for attempt := 0; attempt < 2; attempt++ {
+		update, err := u.UpdateProduct(ctx, product, fields)
+		if err == nil {
+			upsertedProducts.WithLabelValues("update").Inc()
+			return update.Updated, false, nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, false, err
+		}
+
+		if err := u.validate.StructCtx(ctx, product); err != nil {
+			return nil, false, errors.Wrap(err, "validate.StructCtx insert needs the whole product")
+		}
+
+		created, err := u.CreateProduct(ctx, product)
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		upsertedProducts.WithLabelValues("insert").Inc()
+		return created, true, nil
+	}
+
+	return nil, false, errors.Wrap(mongo.ErrNoDocuments, "productUC.UpsertProduct")
+}
+
+// GetProductByID product through the cache, the variants are always read from the database
+func (u *productUC) GetProductByID(ctx context.Context, productID primitive.ObjectID) (*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.GetProductByID")
+	defer span.Finish()
+
+	product, err := u.productCache.GetProduct(ctx, productID, func(ctx context.Context) (*models.Product, error) {
+		return u.productRepo.GetProductByID(ctx, productID)
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "productRepo.GetProductByID failed")
+		return nil, errors.Wrap(err, "productCache.GetProduct failed")
 	}
 
-	if err := u.redisRepo.SetProduct(ctx, product); err != nil {
-		return nil, errors.Wrap(err, "redisRepo.SetProduct failed")
+	variants, err := u.skuRepo.GetSKUsByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "skuRepo.GetSKUsByProductID failed")
 	}
+	product.Variants = variants
 
 	return product, nil
 }
 
+// SearchProducts result page through the cache, runs of whitespace in the query count as one space
 func (u *productUC) SearchProducts(ctx context.Context, query string, pagination *utils.Pagination) (*models.ProductsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.SearchProducts")
 	defer span.Finish()
 
-	return u.productRepo.SearchProducts(ctx, query, pagination)
+	query = strings.Join(strings.Fields(query), " ")
+	key := fmt.Sprintf("query=%s|size=%d|page=%d", query, pagination.GetSize(), pagination.GetPage())
+
+	return u.productCache.GetSearch(ctx, key, func(ctx context.Context) (*models.ProductsList, error) {
+		skuProductIDs, err := u.skuRepo.FindProductIDsBySKU(ctx, query)
+		if err != nil {
+			return nil, errors.Wrap(err, "skuRepo.FindProductIDsBySKU failed")
+		}
+
+		return u.productRepo.SearchProducts(ctx, query, skuProductIDs, pagination)
+	})
+}
+
+// GetProductsByIDs products in the order of the ids through the cache, one query for the misses, unknown ids are skipped.
+// The variants of all the products are read from the database with one more query.
+func (u *productUC) GetProductsByIDs(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.GetProductsByIDs")
+	defer span.Finish()
+
+	if len(productIDs) > maxProductIDs {
+		return nil, errors.Wrapf(productErrors.ErrTooManyProductIDs, "%d ids, at most %d", len(productIDs), maxProductIDs)
+	}
+
+	unique := make([]primitive.ObjectID, 0, len(productIDs))
+	seen := make(map[primitive.ObjectID]bool, len(productIDs))
+	for _, productID := range productIDs {
+		if !seen[productID] {
+			seen[productID] = true
+			unique = append(unique, productID)
+		}
+	}
+	if len(unique) == 0 {
+		return make([]*models.Product, 0), nil
+	}
+
+	found, err := u.productCache.GetProducts(ctx, unique, func(ctx context.Context, productIDs []primitive.ObjectID) ([]*models.Product, error) {
+		return u.productRepo.GetProductsByIDs(ctx, productIDs)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "productCache.GetProducts failed")
+	}
+
+	products := make([]*models.Product, 0, len(unique))
+	for _, productID := range unique {
+		if product, ok := found[productID]; ok {
+			products = append(products, product)
+		}
+	}
+	if len(products) == 0 {
+		return products, nil
+	}
+
+	foundIDs := make([]primitive.ObjectID, 0, len(products))
+	for _, product := range products {
+		foundIDs = append(foundIDs, product.ProductID)
+	}
+	skus, err := u.skuRepo.GetSKUsByProductIDs(ctx, foundIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "skuRepo.GetSKUsByProductIDs failed")
+	}
+	variants := make(map[primitive.ObjectID][]*models.SKU, len(products))
+	for _, sku := range skus {
+		variants[sku.ProductID] = append(variants[sku.ProductID], sku)
+	}
+	for _, product := range products {
+		product.Variants = variants[product.ProductID]
+		if product.Variants == nil {
+			product.Variants = make([]*models.SKU, 0)
+		}
+	}
+
+	return products, nil
+}
+
+// ListProducts streams the products of the filter to fn in id order
+func (u *productUC) ListProducts(ctx context.Context, filter *models.ProductFilter, fn func(product *models.Product) error) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.ListProducts")
+	defer span.Finish()
+
+	return u.productRepo.ListProducts(ctx, filter, fn)
+}
+
+// BatchCreateProducts validates and bulk inserts the products, the returned errors are per product and nil for created ones.
+// A product whose price history cannot be recorded has an error like it does in CreateProduct.
+func (u *productUC) BatchCreateProducts(ctx context.Context, products []*models.Product) ([]error, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.BatchCreateProducts")
+	defer span.Finish()
+
+	itemErrors := make([]error, len(products))
+	valid := make([]*models.Product, 0, len(products))
+	positions := make([]int, 0, len(products))
+	for i, product := range products {
+		clearRating(product)
+		if err := u.validate.StructCtx(ctx, product); err != nil {
+			itemErrors[i] = errors.Wrap(err, "validate.StructCtx failed")
+			continue
+		}
+		valid = append(valid, product)
+		positions = append(positions, i)
+	}
+
+	insertErrors, err := u.productRepo.CreateProducts(ctx, valid)
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.CreateProducts failed")
+	}
+
+	created := make([]*models.Product, 0, len(valid))
+	categoryIDs := make([]primitive.ObjectID, 0)
+	seen := make(map[primitive.ObjectID]bool)
+	for j, product := range valid {
+		if insertErrors[j] != nil {
+			itemErrors[positions[j]] = insertErrors[j]
+			continue
+		}
+		if !seen[product.CategoryID] {
+			seen[product.CategoryID] = true
+			categoryIDs = append(categoryIDs, product.CategoryID)
+		}
+		// The product is inserted, but it fails like CreateProduct without its price history
+		if err := u.pricingUC.RecordPriceChange(ctx, nil, product, models.PriceChangeSourceCreate); err != nil {
+			itemErrors[positions[j]] = errors.Wrap(err, "pricingUC.RecordPriceChange failed")
+			continue
+		}
+		created = append(created, product)
+	}
+
+	// The products are created, a cache failure only costs database reads
+	if err := u.productCache.SetProducts(ctx, created); err != nil {
+		u.log.Errorf("productCache.SetProducts: %v", err)
+	}
+	if err := u.productCache.InvalidateSearches(ctx, categoryIDs...); err != nil {
+		u.log.Errorf("productCache.InvalidateSearches: %v", err)
+	}
+
+	return itemErrors, nil
+}
+
+// BatchUpdateProducts validates the products and replaces every updatable field like UpdateProduct with an empty mask,
+// unversioned products with one bulk write. A product appears at most once.
+// An error of the whole call happens before any versioned update is applied, so the batch can be retried.
+// The returned updates and errors are per product, exactly one of them is set.
+func (u *productUC) BatchUpdateProducts(ctx context.Context, products []*models.Product) ([]*models.ProductUpdate, []error, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.BatchUpdateProducts")
+	defer span.Finish()
+
+	fields := models.ProductMaskPaths()
+	updates := make([]*models.ProductUpdate, len(products))
+	itemErrors := make([]error, len(products))
+	valid := make([]*models.Product, 0, len(products))
+	positions := make([]int, 0, len(products))
+	productIDs := make([]primitive.ObjectID, 0, len(products))
+	for i, product := range products {
+		if err := u.validateUpdate(ctx, product, fields); err != nil {
+			itemErrors[i] = err
+			continue
+		}
+		valid = append(valid, product)
+		positions = append(positions, i)
+		productIDs = append(productIDs, product.ProductID)
+	}
+	if len(valid) == 0 {
+		return updates, itemErrors, nil
+	}
+
+	previous, err := u.productRepo.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "productRepo.GetProductsByIDs failed")
+	}
+
+	updated, updateErrors, err := u.productRepo.UpdateProducts(ctx, valid, fields)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "productRepo.UpdateProducts failed")
+	}
+
+	previousByID := make(map[primitive.ObjectID]*models.Product, len(previous))
+	for _, product := range previous {
+		previousByID[product.ProductID] = product
+	}
+
+	updatedIDs := make([]primitive.ObjectID, 0, len(valid))
+	categoryIDs := make([]primitive.ObjectID, 0)
+	seen := make(map[primitive.ObjectID]bool)
+	for j, product := range valid {
+		if updateErrors[j] != nil {
+			itemErrors[positions[j]] = errors.Wrap(updateErrors[j], "productRepo.UpdateProducts failed")
+			continue
+		}
+		current := updated[j]
+		updatedIDs = append(updatedIDs, product.ProductID)
+		if !seen[current.CategoryID] {
+			seen[current.CategoryID] = true
+			categoryIDs = append(categoryIDs, current.CategoryID)
+		}
+		// The update is applied and invalidated, but it fails like UpdateProduct without its price history
+		if err := u.pricingUC.RecordPriceChange(ctx, previousByID[product.ProductID], current, models.PriceChangeSourceUpdate); err != nil {
+			itemErrors[positions[j]] = errors.Wrap(err, "pricingUC.RecordPriceChange failed")
+			continue
+		}
+		updates[positions[j]] = &models.ProductUpdate{Previous: previousByID[product.ProductID], Updated: current}
+	}
+
+	if err := u.productCache.InvalidateProducts(ctx, updatedIDs); err != nil {
+		u.log.Errorf("productCache.InvalidateProducts: %v", err)
+	}
+	// Searches of the new categories may show the products now
+	if err := u.productCache.InvalidateSearches(ctx, categoryIDs...); err != nil {
+		u.log.Errorf("productCache.InvalidateSearches: %v", err)
+	}
+
+	return updates, itemErrors, nil
 }
 
-func (u *productUC) PublishCreate(ctx context.Context, product *models.Product) error {
+// PublishCreate publishes the product for the create consumer, the returned operation tracks the outcome.
+// A replayed idempotency key returns the operation of the first request without publishing again.
+func (u *productUC) PublishCreate(ctx context.Context, product *models.Product, idempotencyKey string) (*models.Operation, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.PublishCreate")
 	defer span.Finish()
 
-	productBytes, err := json.Marshal(&product)
+	// The id is chosen now, it keys the message and redeliveries of the message insert the same product
+	if product.ProductID.IsZero() {
+		product.ProductID = primitive.NewObjectID()
+	}
+	if idempotencyKey == "" {
+		return u.publish(ctx, product, models.OperationTypeCreateProduct, &product.ProductID, u.productsProducer.PublishCreate)
+	}
+	if err := idempotency.ValidateKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	// The key is reserved first, a replay never leaves an operation behind that nothing publishes
+	operationID := primitive.NewObjectID().Hex()
+	stored, reserved, err := u.idempotencyRepo.Reserve(ctx, publishCreateKey(idempotencyKey), operationID)
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal failed")
+		return nil, errors.Wrap(err, "idempotencyRepo.Reserve")
+	}
+	if !reserved {
+		idempotentReplays.WithLabelValues(models.OperationTypeCreateProduct).Inc()
+		op, err := u.operationUC.GetOperationByID(ctx, stored)
+		if errors.Is(err, productErrors.ErrOperationNotFound) {
+			// The first request reserved the key and has not recorded its operation yet
+			return nil, errors.Wrapf(productErrors.ErrRequestInProgress, "operation %s", stored)
+		}
+		return op, err
 	}
 
-	return u.productsProducer.PublishCreate(ctx, kafka.Message{
-		Value: productBytes,
-		Time:  time.Now().UTC(),
-	})
+	op, err := u.operationUC.StartOperationWithID(ctx, operationID, models.OperationTypeCreateProduct, &product.ProductID)
+	if err != nil {
+		if err := u.idempotencyRepo.Release(ctx, publishCreateKey(idempotencyKey)); err != nil {
+			u.log.Errorf("idempotencyRepo.Release: %v", err)
+		}
+		return nil, errors.Wrap(err, "operationUC.StartOperationWithID")
+	}
+
+	if err := u.write(ctx, product, op, u.productsProducer.PublishCreate, kafka.Header{
+		Key:   idempotency.KafkaHeaderIdempotencyKey,
+		Value: []byte(idempotencyKey),
+	}); err != nil {
+		if err := u.idempotencyRepo.Release(ctx, publishCreateKey(idempotencyKey)); err != nil {
+			u.log.Errorf("idempotencyRepo.Release: %v", err)
+		}
+		return nil, err
+	}
+
+	return op, nil
 }
 
-func (u *productUC) PublishUpdate(ctx context.Context, product *models.Product) error {
+// PublishUpdate publishes the product for the update consumer, the returned operation tracks the outcome
+func (u *productUC) PublishUpdate(ctx context.Context, product *models.Product) (*models.Operation, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.PublishUpdate")
 	defer span.Finish()
 
-	productBytes, err := json.Marshal(&product)
+	return u.publish(ctx, product, models.OperationTypeUpdateProduct, &product.ProductID, u.productsProducer.PublishUpdate)
+}
+
+// publish records the pending operation before the message is written, so the consumer always finds it
+func (u *productUC) publish(
+	ctx context.Context,
+	product *models.Product,
+	operationType string,
+	productID *primitive.ObjectID,
+	publish func(ctx context.Context, msgs ...kafka.Message) error,
+) (*models.Operation, error) {
+	op, err := u.operationUC.StartOperation(ctx, operationType, productID)
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal failed")
+		return nil, errors.Wrap(err, "operationUC.StartOperation")
 	}
 
-	return u.productsProducer.PublishUpdate(ctx, kafka.Message{
-		Value: productBytes,
-		Time:  time.Now().UTC(),
-	})
+	if err := u.write(ctx, product, op, publish); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// write publishes the product keyed by its id with the operation id header, a failed publish fails the operation.
+// The key puts every message of a product on one partition, so consumers see them in publish order.
+func (u *productUC) write(
+	ctx context.Context,
+	product *models.Product,
+	op *models.Operation,
+	publish func(ctx context.Context, msgs ...kafka.Message) error,
+	headers ...kafka.Header,
+) error {
+	m, err := u.productsProducer.NewProductMessage(ctx, op.Type, product)
+	if err != nil {
+		return errors.Wrap(err, "productsProducer.NewProductMessage failed")
+	}
+	m.Headers = append(m.Headers, kafka.Header{Key: operation.HeaderOperationID, Value: []byte(op.OperationID)})
+	m.Headers = append(m.Headers, headers...)
+
+	if err := publish(ctx, m); err != nil {
+		if err := u.operationUC.FailOperation(ctx, op.OperationID, err); err != nil {
+			u.log.Errorf("operationUC.FailOperation: %v", err)
+		}
+		return errors.Wrap(err, "productsProducer.Publish")
+	}
+
+	return nil
+}
+
+// LocalizePrices sets the product and variant prices in the currency, explicit prices win over converted ones
+func (u *productUC) LocalizePrices(ctx context.Context, products []*models.Product, currency string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.LocalizePrices")
+	defer span.Finish()
+
+	for _, product := range products {
+		price, ok := product.PriceIn(currency)
+		if !ok {
+			converted, err := u.currencyUC.Convert(ctx, product.Price, currency)
+			if err != nil {
+				return errors.Wrap(err, "currencyUC.Convert failed")
+			}
+			price = converted
+		}
+		product.Price = price
+
+		for _, variant := range product.Variants {
+			if variant.Price == nil {
+				continue
+			}
+			converted, err := u.currencyUC.Convert(ctx, *variant.Price, currency)
+			if err != nil {
+				return errors.Wrap(err, "currencyUC.Convert failed")
+			}
+			variant.Price = &converted
+		}
+	}
+
+	return nil
+}
+
+func (u *productUC) CreateSKU(ctx context.Context, productID primitive.ObjectID, sku *models.SKU) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.CreateSKU")
+	defer span.Finish()
+
+	product, err := u.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.GetProductByID failed")
+	}
+
+	sku.ProductID = productID
+	if err := u.validateSKU(ctx, product, sku); err != nil {
+		return nil, err
+	}
+
+	created, err := u.skuRepo.CreateSKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	u.invalidateVariants(ctx, created.ProductID, product.CategoryID)
+
+	return created, nil
+}
+
+func (u *productUC) UpdateSKU(ctx context.Context, sku *models.SKU) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.UpdateSKU")
+	defer span.Finish()
+
+	existing, err := u.skuRepo.GetSKUByID(ctx, sku.SKUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "skuRepo.GetSKUByID failed")
+	}
+
+	product, err := u.productRepo.GetProductByID(ctx, existing.ProductID)
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.GetProductByID failed")
+	}
+
+	sku.ProductID = existing.ProductID
+	if err := u.validateSKU(ctx, product, sku); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.skuRepo.UpdateSKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	u.invalidateVariants(ctx, updated.ProductID, product.CategoryID)
+
+	return updated, nil
+}
+
+func (u *productUC) DeleteSKU(ctx context.Context, skuID primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.DeleteSKU")
+	defer span.Finish()
+
+	deleted, err := u.skuRepo.DeleteSKU(ctx, skuID)
+	if err != nil {
+		return err
+	}
+	u.invalidateVariants(ctx, deleted.ProductID)
+
+	return nil
+}
+
+func (u *productUC) GetSKUsByProductID(ctx context.Context, productID primitive.ObjectID) ([]*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.GetSKUsByProductID")
+	defer span.Finish()
+
+	return u.skuRepo.GetSKUsByProductID(ctx, productID)
+}
+
+// AdjustStock adds delta to the sku quantity, a negative delta fails with ErrInsufficientStock instead of going below zero
+func (u *productUC) AdjustStock(ctx context.Context, skuID primitive.ObjectID, delta int64) (*models.SKU, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productUC.AdjustStock")
+	defer span.Finish()
+
+	sku, err := u.skuRepo.AdjustStock(ctx, skuID, delta)
+	if err != nil {
+		return nil, err
+	}
+	u.invalidateVariants(ctx, sku.ProductID)
+
+	return sku, nil
+}
+
+// invalidateVariants drops the cached product after a sku write bumped its version, with the search result pages
+// showing it. A new sku code makes the product match other searches, the pages of the categories are dropped too.
+func (u *productUC) invalidateVariants(ctx context.Context, productID primitive.ObjectID, categoryIDs ...primitive.ObjectID) {
+	if err := u.productCache.InvalidateProduct(ctx, productID); err != nil {
+		u.log.Errorf("productCache.InvalidateProduct: %v", err)
+	}
+	if len(categoryIDs) == 0 {
+		return
+	}
+	if err := u.productCache.InvalidateSearches(ctx, categoryIDs...); err != nil {
+		u.log.Errorf("productCache.InvalidateSearches: %v", err)
+	}
+}
+
+func (u *productUC) validateSKU(ctx context.Context, product *models.Product, sku *models.SKU) error {
+	if err := u.validate.StructCtx(ctx, sku); err != nil {
+		return errors.Wrap(err, "validate.StructCtx failed")
+	}
+
+	if !sku.MatchesOptions(product.Options) {
+		return errors.Wrapf(productErrors.ErrInvalidVariantOptions, "sku: %s", sku.Code)
+	}
+
+	return nil
+}
+
+// validateUpdate checks the mask paths and validates only the fields of the mask
+func (u *productUC) validateUpdate(ctx context.Context, product *models.Product, fields []string) error {
+	prefixes := make([]string, 0, len(fields))
+	for _, field := range fields {
+		structField, ok := models.ProductMaskField(field)
+		if !ok {
+			return errors.Wrapf(productErrors.ErrInvalidUpdateMask, "field %s", field)
+		}
+		prefixes = append(prefixes, "Product."+structField)
+	}
+
+	if err := u.validate.StructFilteredCtx(ctx, product, func(ns []byte) bool {
+		for _, prefix := range prefixes {
+			if rest, ok := bytes.CutPrefix(ns, []byte(prefix)); ok && (len(rest) == 0 || rest[0] == '.' || rest[0] == '[') {
+				return false
+			}
+		}
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "validate.StructFilteredCtx failed")
+	}
+
+	return nil
+}
+
+// clearRating drops client supplied rating totals, only accepted reviews change them
+func clearRating(product *models.Product) {
+	product.RatingAverage = 0
+	product.RatingCount = 0
+	product.RatingSum = 0
+}
+
+// createProductKey idempotency key of a product creation, the value is the product id
+func createProductKey(idempotencyKey string) string {
+	return "create_product:" + idempotencyKey
+}
+
+// publishCreateKey idempotency key of a published product creation, the value is the operation id
+func publishCreateKey(idempotencyKey string) string {
+	return "publish_create:" + idempotencyKey
 }
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/review"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReviewGRPCService gRPC service
+type ReviewGRPCService struct {
+	productService.UnimplementedReviewServiceServer
+	reviewUC review.UseCase
+	log      logger.Logger
+}
+
+// ReviewGRPCService constructor
+func NewReviewGRPCService(reviewUC review.UseCase, log logger.Logger) *ReviewGRPCService {
+	return &ReviewGRPCService{
+		reviewUC: reviewUC,
+		log:      log,
+	}
+}
+
+func (s *ReviewGRPCService) CreateReview(ctx context.Context, req *productService.CreateReviewRequest) (*productService.CreateReviewResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ReviewGRPCService.CreateReview")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	created, err := s.reviewUC.CreateReview(ctx, &models.Review{
+		ProductID: productID,
+		Rating:    req.GetRating(),
+		Title:     req.GetTitle(),
+		Body:      req.GetBody(),
+	})
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("reviewUC.CreateReview: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.CreateReviewResponse{Review: created.ToProto()}, nil
+}
+
+func (s *ReviewGRPCService) ListReviews(ctx context.Context, req *productService.ListReviewsRequest) (*productService.ListReviewsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ReviewGRPCService.ListReviews")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	query := &models.ReviewQuery{
+		Status: req.GetStatus(),
+		Sort:   req.GetSort(),
+	}
+	if req.GetProductId() != "" {
+		productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+		if err != nil {
+			errorMessages.Inc()
+			s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return nil, grpcerrors.ErrorResponse(err, err.Error())
+		}
+		query.ProductID = &productID
+	}
+
+	list, err := s.reviewUC.ListReviews(ctx, query, utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage())))
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("reviewUC.ListReviews: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.ListReviewsResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Reviews:    list.ToProtoList(),
+	}, nil
+}
+
+func (s *ReviewGRPCService) ModerateReview(ctx context.Context, req *productService.ModerateReviewRequest) (*productService.ModerateReviewResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ReviewGRPCService.ModerateReview")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	reviewID, err := primitive.ObjectIDFromHex(req.GetReviewId())
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	moderated, err := s.reviewUC.ModerateReview(ctx, &models.ReviewModeration{
+		ReviewID:         reviewID,
+		Status:           req.GetStatus(),
+		ModerationNote:   req.GetModerationNote(),
+		VerifiedPurchase: req.VerifiedPurchase,
+	})
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("reviewUC.ModerateReview: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.ModerateReviewResponse{Review: moderated.ToProto()}, nil
+}
+
+func (s *ReviewGRPCService) MarkReviewHelpful(ctx context.Context, req *productService.MarkReviewHelpfulRequest) (*productService.MarkReviewHelpfulResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ReviewGRPCService.MarkReviewHelpful")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	reviewID, err := primitive.ObjectIDFromHex(req.GetReviewId())
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	marked, err := s.reviewUC.MarkReviewHelpful(ctx, reviewID)
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("reviewUC.MarkReviewHelpful: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.MarkReviewHelpfulResponse{Review: marked.ToProto()}, nil
+}
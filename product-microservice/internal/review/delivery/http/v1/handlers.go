@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/middleware"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/review"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type reviewHandlers struct {
+	log      logger.Logger
+	reviewUC review.UseCase
+	group    *echo.Group
+	mw       middleware.MiddlewareManager
+}
+
+func NewReviewHandlers(log logger.Logger, reviewUC review.UseCase, group *echo.Group, mw middleware.MiddlewareManager) *reviewHandlers {
+	return &reviewHandlers{
+		log:      log,
+		reviewUC: reviewUC,
+		group:    group,
+		mw:       mw,
+	}
+}
+
+func (h *reviewHandlers) CreateReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "reviewHandlers.CreateReview")
+		defer span.Finish()
+
+		var rev models.Review
+		if err := c.Bind(&rev); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		rev.ProductID = productID
+
+		created, err := h.reviewUC.CreateReview(ctx, &rev)
+		if err != nil {
+			h.log.Errorf("reviewUC.CreateReview: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created)
+	}
+}
+
+func (h *reviewHandlers) ListProductReviews() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "reviewHandlers.ListProductReviews")
+		defer span.Finish()
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		return h.listReviews(ctx, c, &models.ReviewQuery{
+			ProductID: &productID,
+			Status:    c.QueryParam("status"),
+			Sort:      c.QueryParam("sort"),
+		})
+	}
+}
+
+func (h *reviewHandlers) ListReviews() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "reviewHandlers.ListReviews")
+		defer span.Finish()
+
+		return h.listReviews(ctx, c, &models.ReviewQuery{
+			Status: c.QueryParam("status"),
+			Sort:   c.QueryParam("sort"),
+		})
+	}
+}
+
+func (h *reviewHandlers) ModerateReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "reviewHandlers.ModerateReview")
+		defer span.Finish()
+
+		var moderation models.ReviewModeration
+		if err := c.Bind(&moderation); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		reviewID, err := primitive.ObjectIDFromHex(c.Param("review_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		moderation.ReviewID = reviewID
+
+		moderated, err := h.reviewUC.ModerateReview(ctx, &moderation)
+		if err != nil {
+			h.log.Errorf("reviewUC.ModerateReview: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, moderated)
+	}
+}
+
+func (h *reviewHandlers) MarkReviewHelpful() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "reviewHandlers.MarkReviewHelpful")
+		defer span.Finish()
+
+		reviewID, err := primitive.ObjectIDFromHex(c.Param("review_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		marked, err := h.reviewUC.MarkReviewHelpful(ctx, reviewID)
+		if err != nil {
+			h.log.Errorf("reviewUC.MarkReviewHelpful: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, marked)
+	}
+}
+
+func (h *reviewHandlers) listReviews(ctx context.Context, c echo.Context, query *models.ReviewQuery) error {
+	pq := &utils.Pagination{}
+	if err := pq.SetSize(c.QueryParam("size")); err != nil {
+		return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+	}
+	if err := pq.SetPage(c.QueryParam("page")); err != nil {
+		return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+	}
+
+	list, err := h.reviewUC.ListReviews(ctx, query, pq)
+	if err != nil {
+		h.log.Errorf("reviewUC.ListReviews: %v", err)
+		return httpErrors.ErrorCtxResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,10 @@
+package v1
+
+// MapRoutes reviews routes, the session user is resolved for every review route
+func (h *reviewHandlers) MapRoutes() {
+	h.group.POST("/:product_id/reviews", h.CreateReview(), h.mw.AuthSession)
+	h.group.GET("/:product_id/reviews", h.ListProductReviews(), h.mw.AuthSession)
+	h.group.GET("/reviews", h.ListReviews(), h.mw.AuthSession)
+	h.group.PUT("/reviews/:review_id/moderation", h.ModerateReview(), h.mw.AuthSession)
+	h.group.POST("/reviews/:review_id/helpful", h.MarkReviewHelpful(), h.mw.AuthSession)
+}
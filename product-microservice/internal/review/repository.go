@@ -0,0 +1,20 @@
+package review
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Review repository interface
+type MongoRepository interface {
+	CreateReview(ctx context.Context, review *models.Review) (*models.Review, error)
+	GetReviewByID(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error)
+	ListReviews(ctx context.Context, query *models.ReviewQuery, pagination *utils.Pagination) (*models.ReviewsList, error)
+	Moderate(ctx context.Context, fromStatus string, moderation *models.ReviewModeration, moderatedBy string) (*models.Review, error)
+	AddHelpfulVote(ctx context.Context, reviewID primitive.ObjectID, userID string) (bool, error)
+	IncHelpfulCount(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error)
+	RatingTotals(ctx context.Context, productID primitive.ObjectID) (int64, int64, error)
+}
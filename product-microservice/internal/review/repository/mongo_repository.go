@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	reviewsDB             = "products"
+	reviewsCollection     = "reviews"
+	reviewVotesCollection = "review_votes"
+)
+
+type reviewMongoRepo struct {
+	mongoDB *mongo.Client
+}
+
+// ReviewMongo Constructor
+func NewReviewMongoRepository(mongoDB *mongo.Client) *reviewMongoRepo {
+	return &reviewMongoRepo{
+		mongoDB: mongoDB,
+	}
+}
+
+func (r *reviewMongoRepo) reviews() *mongo.Collection {
+	return r.mongoDB.Database(reviewsDB).Collection(reviewsCollection)
+}
+
+func (r *reviewMongoRepo) votes() *mongo.Collection {
+	return r.mongoDB.Database(reviewsDB).Collection(reviewVotesCollection)
+}
+
+// CreateIndexes one review per user and product, one helpful vote per user and review, listings by status and order
+func (r *reviewMongoRepo) CreateIndexes(ctx context.Context) error {
+	if _, err := r.reviews().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}, {Key: "helpful_count", Value: -1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "reviews Indexes.CreateMany")
+	}
+
+	if _, err := r.votes().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "review_id", Value: 1}, {Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return errors.Wrap(err, "review_votes Indexes.CreateOne")
+	}
+
+	return nil
+}
+
+func (r *reviewMongoRepo) CreateReview(ctx context.Context, review *models.Review) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.CreateReview")
+	defer span.Finish()
+
+	review.CreatedAt = time.Now().UTC()
+	review.UpdatedAt = time.Now().UTC()
+
+	result, err := r.reviews().InsertOne(ctx, review)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.Wrap(productErrors.ErrReviewExists, "InsertOne")
+		}
+		return nil, errors.Wrap(err, "InsertOne failed")
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, errors.Wrap(productErrors.ErrObjectIDTypeConversion, "InsertOne")
+	}
+	review.ReviewID = objectID
+
+	return review, nil
+}
+
+func (r *reviewMongoRepo) GetReviewByID(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.GetReviewByID")
+	defer span.Finish()
+
+	var review models.Review
+	if err := r.reviews().FindOne(ctx, bson.M{"_id": reviewID}).Decode(&review); err != nil {
+		return nil, errors.Wrap(err, "FindOne failed")
+	}
+
+	return &review, nil
+}
+
+// ListReviews with the status, most helpful or most recent first
+func (r *reviewMongoRepo) ListReviews(ctx context.Context, query *models.ReviewQuery, pagination *utils.Pagination) (*models.ReviewsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.ListReviews")
+	defer span.Finish()
+
+	filter := bson.M{"status": query.Status}
+	if query.ProductID != nil {
+		filter["product_id"] = *query.ProductID
+	}
+
+	count, err := r.reviews().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "CountDocuments failed")
+	}
+
+	if count == 0 {
+		return &models.ReviewsList{Reviews: make([]*models.Review, 0)}, nil
+	}
+
+	sort := bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	if query.Sort == models.ReviewSortHelpful {
+		sort = append(bson.D{{Key: "helpful_count", Value: -1}}, sort...)
+	}
+
+	opts := options.Find().
+		SetSort(sort).
+		SetLimit(int64(pagination.GetLimit())).
+		SetSkip(int64(pagination.GetOffset()))
+
+	cursor, err := r.reviews().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	reviews := make([]*models.Review, 0, pagination.GetSize())
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return &models.ReviewsList{
+		TotalCount: count,
+		TotalPages: int64(pagination.GetTotalPages(int(count))),
+		Page:       int64(pagination.GetPage()),
+		Size:       int64(pagination.GetSize()),
+		HasMore:    pagination.GetHasMore(int(count)),
+		Reviews:    reviews,
+	}, nil
+}
+
+// Moderate sets the moderation decision if the review is still in fromStatus, ErrNoDocuments when another moderator was first
+func (r *reviewMongoRepo) Moderate(ctx context.Context, fromStatus string, moderation *models.ReviewModeration, moderatedBy string) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.Moderate")
+	defer span.Finish()
+
+	now := time.Now().UTC()
+	set := bson.M{
+		"status":          moderation.Status,
+		"moderation_note": moderation.ModerationNote,
+		"moderated_by":    moderatedBy,
+		"moderated_at":    now,
+		"updated_at":      now,
+	}
+	if moderation.VerifiedPurchase != nil {
+		set["verified_purchase"] = *moderation.VerifiedPurchase
+	}
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	var review models.Review
+	if err := r.reviews().FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": moderation.ReviewID, "status": fromStatus},
+		bson.M{"$set": set},
+		&opts,
+	).Decode(&review); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &review, nil
+}
+
+// AddHelpfulVote records the vote of the user, false when the user already voted
+func (r *reviewMongoRepo) AddHelpfulVote(ctx context.Context, reviewID primitive.ObjectID, userID string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.AddHelpfulVote")
+	defer span.Finish()
+
+	if _, err := r.votes().InsertOne(ctx, bson.M{
+		"review_id":  reviewID,
+		"user_id":    userID,
+		"created_at": time.Now().UTC(),
+	}); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "InsertOne failed")
+	}
+
+	return true, nil
+}
+
+func (r *reviewMongoRepo) IncHelpfulCount(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.IncHelpfulCount")
+	defer span.Finish()
+
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+	}
+
+	var review models.Review
+	if err := r.reviews().FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": reviewID},
+		bson.M{"$inc": bson.M{"helpful_count": 1}},
+		&opts,
+	).Decode(&review); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &review, nil
+}
+
+// RatingTotals sum and count of the ratings of the accepted reviews of the product
+func (r *reviewMongoRepo) RatingTotals(ctx context.Context, productID primitive.ObjectID) (int64, int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewMongoRepo.RatingTotals")
+	defer span.Finish()
+
+	cursor, err := r.reviews().Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"product_id": productID, "status": models.ReviewStatusAccepted}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"sum":   bson.M{"$sum": "$rating"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "Aggregate failed")
+	}
+	defer cursor.Close(ctx)
+
+	var totals []struct {
+		Sum   int64 `bson:"sum"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return 0, 0, errors.Wrap(err, "cursor.All failed")
+	}
+	if len(totals) == 0 {
+		return 0, 0, nil
+	}
+
+	return totals[0].Sum, totals[0].Count, nil
+}
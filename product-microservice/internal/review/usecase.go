@@ -0,0 +1,17 @@
+package review
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UseCase review
+type UseCase interface {
+	CreateReview(ctx context.Context, review *models.Review) (*models.Review, error)
+	ListReviews(ctx context.Context, query *models.ReviewQuery, pagination *utils.Pagination) (*models.ReviewsList, error)
+	ModerateReview(ctx context.Context, moderation *models.ReviewModeration) (*models.Review, error)
+	MarkReviewHelpful(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error)
+}
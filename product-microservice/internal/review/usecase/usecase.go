@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/auth"
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/product"
+	"github.com/chuuch/product-microservice/internal/review"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"github.com/chuuch/product-microservice/pkg/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Attempts to moderate a review that other moderators keep changing
+const maxModerationAttempts = 3
+
+var moderatedReviews = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "products_moderated_reviews_total",
+	Help: "Total number of moderated product reviews",
+}, []string{"status"})
+
+type reviewUC struct {
+	reviewRepo   review.MongoRepository
+	productRepo  product.MongoRepository
+	productCache product.Cache
+	log          logger.Logger
+	validate     *validator.Validate
+}
+
+func NewReviewUC(
+	reviewRepo review.MongoRepository,
+	productRepo product.MongoRepository,
+	productCache product.Cache,
+	log logger.Logger,
+	validate *validator.Validate,
+) *reviewUC {
+	return &reviewUC{
+		reviewRepo:   reviewRepo,
+		productRepo:  productRepo,
+		productCache: productCache,
+		log:          log,
+		validate:     validate,
+	}
+}
+
+// CreateReview by the session user, pending until a moderator accepts it
+func (u *reviewUC) CreateReview(ctx context.Context, review *models.Review) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewUC.CreateReview")
+	defer span.Finish()
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, errors.Wrap(productErrors.ErrUnauthenticated, "auth.UserFromContext")
+	}
+
+	if _, err := u.productRepo.GetProductByID(ctx, review.ProductID); err != nil {
+		return nil, errors.Wrap(err, "productRepo.GetProductByID failed")
+	}
+
+	review.UserID = user.UserID
+	review.Status = models.ReviewStatusPending
+	review.VerifiedPurchase = false
+	review.HelpfulCount = 0
+	review.ModerationNote = ""
+	review.ModeratedBy = ""
+	review.ModeratedAt = nil
+
+	if err := u.validate.StructCtx(ctx, review); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx failed")
+	}
+
+	return u.reviewRepo.CreateReview(ctx, review)
+}
+
+// ListReviews accepted reviews of a product, other statuses and all products listings are the admin moderation queue
+func (u *reviewUC) ListReviews(ctx context.Context, query *models.ReviewQuery, pagination *utils.Pagination) (*models.ReviewsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewUC.ListReviews")
+	defer span.Finish()
+
+	if query.Sort == "" {
+		query.Sort = models.ReviewSortHelpful
+	}
+	if query.Status == "" {
+		query.Status = models.ReviewStatusAccepted
+		if query.ProductID == nil {
+			query.Status = models.ReviewStatusPending
+		}
+	}
+
+	if err := u.validate.StructCtx(ctx, query); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx failed")
+	}
+
+	if query.ProductID == nil || query.Status != models.ReviewStatusAccepted {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.reviewRepo.ListReviews(ctx, query, pagination)
+}
+
+// ModerateReview accepts or rejects the review and moves it in or out of the product rating
+func (u *reviewUC) ModerateReview(ctx context.Context, moderation *models.ReviewModeration) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewUC.ModerateReview")
+	defer span.Finish()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := u.validate.StructCtx(ctx, moderation); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx failed")
+	}
+
+	moderator := auth.UserFromContext(ctx)
+
+	var err error
+	for attempt := 0; attempt < maxModerationAttempts; attempt++ {
+		var current *models.Review
+		current, err = u.reviewRepo.GetReviewByID(ctx, moderation.ReviewID)
+		if err != nil {
+			return nil, errors.Wrap(err, "reviewRepo.GetReviewByID failed")
+		}
+
+		// Moderate only applies if the status is still the one read, concurrent moderations retry
+		var moderated *models.Review
+		moderated, err = u.reviewRepo.Moderate(ctx, current.Status, moderation, moderator.UserID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reviewRepo.Moderate failed")
+		}
+
+		if err := u.updateRating(ctx, current, moderated); err != nil {
+			return nil, err
+		}
+
+		moderatedReviews.WithLabelValues(moderated.Status).Inc()
+		return moderated, nil
+	}
+
+	return nil, errors.Wrap(err, "review changed concurrently")
+}
+
+// MarkReviewHelpful counts the vote of the session user once
+func (u *reviewUC) MarkReviewHelpful(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reviewUC.MarkReviewHelpful")
+	defer span.Finish()
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, errors.Wrap(productErrors.ErrUnauthenticated, "auth.UserFromContext")
+	}
+
+	current, err := u.reviewRepo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, errors.Wrap(err, "reviewRepo.GetReviewByID failed")
+	}
+
+	if current.Status != models.ReviewStatusAccepted {
+		return nil, errors.Wrapf(productErrors.ErrReviewNotAccepted, "status %s", current.Status)
+	}
+
+	voted, err := u.reviewRepo.AddHelpfulVote(ctx, reviewID, user.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "reviewRepo.AddHelpfulVote failed")
+	}
+
+	if !voted {
+		return current, nil
+	}
+
+	return u.reviewRepo.IncHelpfulCount(ctx, reviewID)
+}
+
+// updateRating recomputes the product rating from its accepted reviews when the review was or is accepted.
+// Moderating an accepted review again heals a rating a failed earlier update left behind. The rating is only written
+// if the product did not change since it was read, so a concurrent moderation never overwrites it with older totals.
+func (u *reviewUC) updateRating(ctx context.Context, previous, moderated *models.Review) error {
+	if previous.Status != models.ReviewStatusAccepted && moderated.Status != models.ReviewStatusAccepted {
+		return nil
+	}
+
+	var err error
+	for attempt := 0; attempt < maxModerationAttempts; attempt++ {
+		var prod *models.Product
+		prod, err = u.productRepo.GetProductByID(ctx, moderated.ProductID)
+		if err != nil {
+			return errors.Wrap(err, "productRepo.GetProductByID failed")
+		}
+
+		var sum, count int64
+		sum, count, err = u.reviewRepo.RatingTotals(ctx, moderated.ProductID)
+		if err != nil {
+			return errors.Wrap(err, "reviewRepo.RatingTotals failed")
+		}
+
+		_, err = u.productRepo.SetRating(ctx, moderated.ProductID, sum, count, prod.Version)
+		if errors.Is(err, productErrors.ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "productRepo.SetRating failed")
+		}
+
+		if err := u.productCache.InvalidateProduct(ctx, moderated.ProductID); err != nil {
+			u.log.Errorf("productCache.InvalidateProduct: %v", err)
+		}
+		return nil
+	}
+
+	return errors.Wrap(err, "product changed concurrently")
+}
@@ -9,8 +9,32 @@ import (
 	"time"
 
 	"github.com/chuuch/product-microservice/config"
+	authClient "github.com/chuuch/product-microservice/internal/auth/client"
+	catalogGRPC "github.com/chuuch/product-microservice/internal/catalog/delivery/gRPC"
+	catalogUseCase "github.com/chuuch/product-microservice/internal/catalog/usecase"
+	consumerHttpV1 "github.com/chuuch/product-microservice/internal/consumer/delivery/http/v1"
+	consumerUseCase "github.com/chuuch/product-microservice/internal/consumer/usecase"
+	currencyGRPC "github.com/chuuch/product-microservice/internal/currency/delivery/gRPC"
+	currencyHttpV1 "github.com/chuuch/product-microservice/internal/currency/delivery/http/v1"
+	currencyRepository "github.com/chuuch/product-microservice/internal/currency/repository"
+	currencyUseCase "github.com/chuuch/product-microservice/internal/currency/usecase"
+	deadLetterGRPC "github.com/chuuch/product-microservice/internal/deadletter/delivery/gRPC"
+	deadLetterKafka "github.com/chuuch/product-microservice/internal/deadletter/delivery/kafka"
+	deadLetterRepository "github.com/chuuch/product-microservice/internal/deadletter/repository"
+	deadLetterUseCase "github.com/chuuch/product-microservice/internal/deadletter/usecase"
+	idempotencyRepository "github.com/chuuch/product-microservice/internal/idempotency/repository"
 	"github.com/chuuch/product-microservice/internal/interceptors"
 	"github.com/chuuch/product-microservice/internal/middleware"
+	"github.com/chuuch/product-microservice/internal/models"
+	operationHttpV1 "github.com/chuuch/product-microservice/internal/operation/delivery/http/v1"
+	operationRepository "github.com/chuuch/product-microservice/internal/operation/repository"
+	operationUseCase "github.com/chuuch/product-microservice/internal/operation/usecase"
+	pricingGRPC "github.com/chuuch/product-microservice/internal/pricing/delivery/gRPC"
+	pricingHttpV1 "github.com/chuuch/product-microservice/internal/pricing/delivery/http/v1"
+	pricingRepository "github.com/chuuch/product-microservice/internal/pricing/repository"
+	"github.com/chuuch/product-microservice/internal/pricing/scheduler"
+	pricingUseCase "github.com/chuuch/product-microservice/internal/pricing/usecase"
+	productCache "github.com/chuuch/product-microservice/internal/product/cache"
 	"github.com/chuuch/product-microservice/internal/product/repository"
 	"github.com/chuuch/product-microservice/internal/product/usecase"
 	"github.com/chuuch/product-microservice/pkg/logger"
@@ -33,6 +57,14 @@ import (
 	productService "github.com/chuuch/product-microservice/internal/product/delivery/gRPC"
 	productHttpV1 "github.com/chuuch/product-microservice/internal/product/delivery/http/v1"
 	"github.com/chuuch/product-microservice/internal/product/delivery/kafka"
+	reviewGRPC "github.com/chuuch/product-microservice/internal/review/delivery/gRPC"
+	reviewHttpV1 "github.com/chuuch/product-microservice/internal/review/delivery/http/v1"
+	reviewRepository "github.com/chuuch/product-microservice/internal/review/repository"
+	reviewUseCase "github.com/chuuch/product-microservice/internal/review/usecase"
+	subscriptionGRPC "github.com/chuuch/product-microservice/internal/subscription/delivery/gRPC"
+	subscriptionHttpV1 "github.com/chuuch/product-microservice/internal/subscription/delivery/http/v1"
+	subscriptionRepository "github.com/chuuch/product-microservice/internal/subscription/repository"
+	subscriptionUseCase "github.com/chuuch/product-microservice/internal/subscription/usecase"
 	productsService "github.com/chuuch/product-microservice/proto/product"
 )
 
@@ -63,16 +95,94 @@ func (s *Server) Start() error {
 
 	validate := validator.New()
 
+	if s.cfg.Currency.Default != "" {
+		models.DefaultCurrency = s.cfg.Currency.Default
+	}
+
 	productsProducer := kafka.NewProductsProducer(s.logger, s.cfg)
 	productsProducer.Run()
 	defer productsProducer.Close()
 
+	currencyMongoRepo := currencyRepository.NewCurrencyMongoRepository(s.mongoDB)
+	if err := currencyMongoRepo.CreateIndexes(ctx); err != nil {
+		return errors.Wrap(err, "currencyMongoRepo.CreateIndexes")
+	}
+	currencyUC := currencyUseCase.NewCurrencyUC(currencyMongoRepo, s.logger, validate)
+
+	subscriptionMongoRepo := subscriptionRepository.NewSubscriptionMongoRepository(s.mongoDB)
+	if err := subscriptionMongoRepo.CreateIndexes(ctx); err != nil {
+		return errors.Wrap(err, "subscriptionMongoRepo.CreateIndexes")
+	}
+	subscriptionUC := subscriptionUseCase.NewSubscriptionUC(subscriptionMongoRepo, s.logger, validate, productsProducer)
+
 	productMongoRepo := repository.NewProductMongoRepository(s.mongoDB)
-	productRedisRepo := repository.NewProductRedisRepository(s.redis)
-	productUC := usecase.NewProductUC(productMongoRepo, s.logger, validate, productRedisRepo, productsProducer)
+	var searchCacheTTL time.Duration
+	if s.cfg.Search.CacheEnabled {
+		searchCacheTTL = s.cfg.Search.CacheTTL * time.Second
+	}
+	productRedisRepo := repository.NewProductRedisRepository(s.redis, s.cfg.Cache.TTL*time.Second, s.cfg.Cache.TTLJitter*time.Second)
+	productCache := productCache.NewProductCache(
+		productRedisRepo,
+		s.logger,
+		s.cfg.Cache.LocalSize,
+		s.cfg.Cache.LocalTTL*time.Second,
+		s.cfg.Cache.NegativeTTL*time.Second,
+		searchCacheTTL,
+	)
+	go productCache.Run(ctx)
+	skuMongoRepo := repository.NewSKUMongoRepository(s.mongoDB)
+	if err := skuMongoRepo.CreateIndexes(ctx); err != nil {
+		return errors.Wrap(err, "skuMongoRepo.CreateIndexes")
+	}
+
+	pricingMongoRepo := pricingRepository.NewPricingMongoRepository(s.mongoDB)
+	if err := pricingMongoRepo.CreateIndexes(ctx); err != nil {
+		return errors.Wrap(err, "pricingMongoRepo.CreateIndexes")
+	}
+	pricingUC := pricingUseCase.NewPricingUC(pricingMongoRepo, productMongoRepo, productCache, subscriptionUC, s.logger, validate)
+
+	operationRedisRepo := operationRepository.NewOperationRedisRepository(s.redis)
+	operationUC := operationUseCase.NewOperationUC(operationRedisRepo, s.logger)
+	idempotencyRedisRepo := idempotencyRepository.NewIdempotencyRedisRepository(s.redis, s.cfg.Idempotency.TTL*time.Second)
 
-	im := interceptors.NewInterceptorManager(s.logger, s.cfg)
-	mw := middleware.NewMiddlewareManager(s.logger, s.cfg)
+	productUC := usecase.NewProductUC(
+		productMongoRepo,
+		skuMongoRepo,
+		s.logger,
+		validate,
+		productCache,
+		productsProducer,
+		currencyUC,
+		pricingUC,
+		operationUC,
+		idempotencyRedisRepo,
+	)
+
+	reviewMongoRepo := reviewRepository.NewReviewMongoRepository(s.mongoDB)
+	if err := reviewMongoRepo.CreateIndexes(ctx); err != nil {
+		return errors.Wrap(err, "reviewMongoRepo.CreateIndexes")
+	}
+	reviewUC := reviewUseCase.NewReviewUC(reviewMongoRepo, productMongoRepo, productCache, s.logger, validate)
+
+	catalogUC := catalogUseCase.NewCatalogUC(productUC, s.logger, validate)
+
+	deadLetterMongoRepo := deadLetterRepository.NewDeadLetterMongoRepository(s.mongoDB)
+	if err := deadLetterMongoRepo.CreateIndexes(ctx); err != nil {
+		return errors.Wrap(err, "deadLetterMongoRepo.CreateIndexes")
+	}
+	deadLetterUC := deadLetterUseCase.NewDeadLetterUC(deadLetterMongoRepo, productsProducer, s.logger, validate)
+
+	productsConsumerGroup := kafka.NewProductsConsumerGroup(s.cfg.Kafka.Brokers, s.cfg.Kafka.GroupID, s.cfg, productUC, subscriptionUC, operationUC, s.logger, validate)
+	consumerUC := consumerUseCase.NewConsumerUC(productsConsumerGroup, s.logger, validate)
+
+	userClient, err := authClient.NewUserClient(s.cfg.Auth.GRPCAddr)
+	if err != nil {
+		return errors.Wrap(err, "authClient.NewUserClient")
+	}
+	defer userClient.Close()
+
+	im := interceptors.NewInterceptorManager(s.logger, s.cfg, userClient)
+	mw := middleware.NewMiddlewareManager(s.logger, s.cfg, userClient)
 
 	l, err := net.Listen("tcp", s.cfg.Server.Port)
 	if err != nil {
@@ -94,24 +204,63 @@ func (s *Server) Start() error {
 			grpc_prometheus.UnaryServerInterceptor,
 			grpcrecovery.UnaryServerInterceptor(),
 			im.Logger,
+			im.Auth,
+		),
+		grpc.ChainStreamInterceptor(
+			grpc_ctxtags.StreamServerInterceptor(),
+			grpc_opentracing.StreamServerInterceptor(),
+			grpc_prometheus.StreamServerInterceptor,
+			grpcrecovery.StreamServerInterceptor(),
+			im.StreamLogger,
+			im.StreamAuth,
 		))
 
 	productService := productService.NewProductGRPCService(productUC, s.logger, validate)
 	productsService.RegisterProductServiceServer(grpcServer, productService)
+	subscriptionService := subscriptionGRPC.NewSubscriptionGRPCService(subscriptionUC, s.logger)
+	productsService.RegisterSubscriptionServiceServer(grpcServer, subscriptionService)
+	currencyService := currencyGRPC.NewCurrencyGRPCService(currencyUC, s.logger)
+	productsService.RegisterCurrencyServiceServer(grpcServer, currencyService)
+	pricingService := pricingGRPC.NewPricingGRPCService(pricingUC, s.logger)
+	productsService.RegisterPricingServiceServer(grpcServer, pricingService)
+	reviewService := reviewGRPC.NewReviewGRPCService(reviewUC, s.logger)
+	productsService.RegisterReviewServiceServer(grpcServer, reviewService)
+	catalogService := catalogGRPC.NewCatalogGRPCService(catalogUC, s.logger)
+	productsService.RegisterCatalogServiceServer(grpcServer, catalogService)
+	deadLetterService := deadLetterGRPC.NewDeadLetterGRPCService(deadLetterUC, s.logger)
+	productsService.RegisterDeadLetterServiceServer(grpcServer, deadLetterService)
 	grpc_prometheus.Register(grpcServer)
 
 	v1 := s.echo.Group("/api/v1")
 	v1.Use(mw.Metrics)
 	productHandlers := productHttpV1.NewProductHandlers(s.logger, productUC, validate, v1, mw)
 	productHandlers.MapRoutes()
+	subscriptionHandlers := subscriptionHttpV1.NewSubscriptionHandlers(s.logger, subscriptionUC, v1)
+	subscriptionHandlers.MapRoutes()
+	currencyHandlers := currencyHttpV1.NewCurrencyHandlers(s.logger, currencyUC, v1)
+	currencyHandlers.MapRoutes()
+	pricingHandlers := pricingHttpV1.NewPricingHandlers(s.logger, pricingUC, v1)
+	pricingHandlers.MapRoutes()
+	reviewHandlers := reviewHttpV1.NewReviewHandlers(s.logger, reviewUC, v1, mw)
+	reviewHandlers.MapRoutes()
+	operationHandlers := operationHttpV1.NewOperationHandlers(s.logger, operationUC, v1)
+	operationHandlers.MapRoutes()
+	consumerHandlers := consumerHttpV1.NewConsumerHandlers(s.logger, consumerUC, v1, mw)
+	consumerHandlers.MapRoutes()
 
 	go func() {
 		s.logger.Infof("HTTP Server is running on port: %s", s.cfg.Http.Port)
 		s.StartHTTP()
 	}()
 
-	productsConsumerGroup := kafka.NewProductsConsumerGroup(s.cfg.Kafka.Brokers, "products_group", s.cfg, productUC, s.logger, validate)
 	productsConsumerGroup.RunConsumers(ctx, cancel)
+	deadLettersConsumer := deadLetterKafka.NewDeadLettersConsumer(s.cfg.Kafka.Brokers, s.cfg.Kafka.DeadLetterTopic, deadLetterUC, s.logger)
+	go deadLettersConsumer.Run(ctx)
+
+	if s.cfg.Pricing.SchedulerEnabled {
+		priceScheduler := scheduler.NewPriceScheduler(pricingUC, s.logger, s.cfg.Pricing.SchedulerInterval*time.Second)
+		go priceScheduler.Run(ctx)
+	}
 
 	go func() {
 		s.logger.Infof("GRPC Server is running on port: %s", s.cfg.Server.Port)
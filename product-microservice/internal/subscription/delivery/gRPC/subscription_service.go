@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/subscription"
+	grpcerrors "github.com/chuuch/product-microservice/pkg/grpc_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	productService "github.com/chuuch/product-microservice/proto/product"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SubscriptionGRPCService gRPC service
+type SubscriptionGRPCService struct {
+	productService.UnimplementedSubscriptionServiceServer
+	subscriptionUC subscription.UseCase
+	log            logger.Logger
+}
+
+// SubscriptionGRPCService constructor
+func NewSubscriptionGRPCService(subscriptionUC subscription.UseCase, log logger.Logger) *SubscriptionGRPCService {
+	return &SubscriptionGRPCService{
+		subscriptionUC: subscriptionUC,
+		log:            log,
+	}
+}
+
+func (s *SubscriptionGRPCService) Subscribe(ctx context.Context, req *productService.SubscribeRequest) (*productService.SubscribeResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "SubscriptionGRPCService.Subscribe")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	created, err := s.subscriptionUC.Subscribe(ctx, &models.Subscription{
+		UserID:    req.GetUserId(),
+		Email:     req.GetEmail(),
+		ProductID: productID,
+		Kind:      req.GetKind(),
+		Threshold: models.MoneyPtrFromProto(req.GetThreshold()),
+	})
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("subscriptionUC.Subscribe: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.SubscribeResponse{Subscription: created.ToProto()}, nil
+}
+
+func (s *SubscriptionGRPCService) Unsubscribe(ctx context.Context, req *productService.UnsubscribeRequest) (*productService.UnsubscribeResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "SubscriptionGRPCService.Unsubscribe")
+	defer span.Finish()
+	incommingMessages.Inc()
+
+	productID, err := primitive.ObjectIDFromHex(req.GetProductId())
+	if err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	if err := s.subscriptionUC.Unsubscribe(ctx, req.GetUserId(), productID, req.GetKind()); err != nil {
+		errorMessages.Inc()
+		s.log.Errorf("subscriptionUC.Unsubscribe: %v", err)
+		return nil, grpcerrors.ErrorResponse(err, err.Error())
+	}
+
+	successMessages.Inc()
+
+	return &productService.UnsubscribeResponse{}, nil
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/chuuch/product-microservice/internal/subscription"
+	httpErrors "github.com/chuuch/product-microservice/pkg/http_errors"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type subscriptionHandlers struct {
+	log            logger.Logger
+	subscriptionUC subscription.UseCase
+	group          *echo.Group
+}
+
+func NewSubscriptionHandlers(log logger.Logger, subscriptionUC subscription.UseCase, group *echo.Group) *subscriptionHandlers {
+	return &subscriptionHandlers{
+		log:            log,
+		subscriptionUC: subscriptionUC,
+		group:          group,
+	}
+}
+
+func (h *subscriptionHandlers) Subscribe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "subscriptionHandlers.Subscribe")
+		defer span.Finish()
+
+		var sub models.Subscription
+		if err := c.Bind(&sub); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+		sub.ProductID = productID
+
+		created, err := h.subscriptionUC.Subscribe(ctx, &sub)
+		if err != nil {
+			h.log.Errorf("subscriptionUC.Subscribe: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created)
+	}
+}
+
+func (h *subscriptionHandlers) Unsubscribe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "subscriptionHandlers.Unsubscribe")
+		defer span.Finish()
+
+		productID, err := primitive.ObjectIDFromHex(c.Param("product_id"))
+		if err != nil {
+			h.log.Errorf("primitive.ObjectIDFromHex: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+		}
+
+		if err := h.subscriptionUC.Unsubscribe(ctx, c.QueryParam("user_id"), productID, c.Param("kind")); err != nil {
+			h.log.Errorf("subscriptionUC.Unsubscribe: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,7 @@
+package v1
+
+// MapRoutes subscriptions routes
+func (h *subscriptionHandlers) MapRoutes() {
+	h.group.POST("/:product_id/subscriptions", h.Subscribe())
+	h.group.DELETE("/:product_id/subscriptions/:kind", h.Unsubscribe())
+}
@@ -0,0 +1,17 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Subscription repository interface
+type MongoRepository interface {
+	Subscribe(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error)
+	Unsubscribe(ctx context.Context, userID string, productID primitive.ObjectID, kind string) error
+	FindArmed(ctx context.Context, productID primitive.ObjectID, kind string, price models.Money) ([]*models.Subscription, error)
+	Claim(ctx context.Context, subscriptionID primitive.ObjectID) (bool, error)
+	Release(ctx context.Context, subscriptionID primitive.ObjectID) error
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	subscriptionsDB         = "products"
+	subscriptionsCollection = "subscriptions"
+)
+
+type subscriptionMongoRepo struct {
+	mongoDB *mongo.Client
+}
+
+// SubscriptionMongo Constructor
+func NewSubscriptionMongoRepository(mongoDB *mongo.Client) *subscriptionMongoRepo {
+	return &subscriptionMongoRepo{
+		mongoDB: mongoDB,
+	}
+}
+
+func (s *subscriptionMongoRepo) collection() *mongo.Collection {
+	return s.mongoDB.Database(subscriptionsDB).Collection(subscriptionsCollection)
+}
+
+// CreateIndexes one subscription per user, product and kind, armed lookups by product
+func (s *subscriptionMongoRepo) CreateIndexes(ctx context.Context) error {
+	_, err := s.collection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "kind", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "kind", Value: 1}, {Key: "fired_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "Indexes.CreateMany")
+	}
+	return nil
+}
+
+// Subscribe upserts the subscription, subscribing again re-arms a fired one
+func (s *subscriptionMongoRepo) Subscribe(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionMongoRepo.Subscribe")
+	defer span.Finish()
+
+	now := time.Now().UTC()
+	filter := bson.M{
+		"user_id":    subscription.UserID,
+		"product_id": subscription.ProductID,
+		"kind":       subscription.Kind,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"email":      subscription.Email,
+			"threshold":  subscription.Threshold,
+			"fired_at":   nil,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	after := options.After
+	upsert := true
+	opts := options.FindOneAndUpdateOptions{
+		ReturnDocument: &after,
+		Upsert:         &upsert,
+	}
+
+	var sub models.Subscription
+	if err := s.collection().FindOneAndUpdate(ctx, filter, update, &opts).Decode(&sub); err != nil {
+		return nil, errors.Wrap(err, "FindOneAndUpdate failed")
+	}
+
+	return &sub, nil
+}
+
+func (s *subscriptionMongoRepo) Unsubscribe(ctx context.Context, userID string, productID primitive.ObjectID, kind string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionMongoRepo.Unsubscribe")
+	defer span.Finish()
+
+	result, err := s.collection().DeleteOne(ctx, bson.M{"user_id": userID, "product_id": productID, "kind": kind})
+	if err != nil {
+		return errors.Wrap(err, "DeleteOne failed")
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(mongo.ErrNoDocuments, "DeleteOne")
+	}
+
+	return nil
+}
+
+// FindArmed subscriptions of the product that have not fired, price drops filtered by threshold
+func (s *subscriptionMongoRepo) FindArmed(ctx context.Context, productID primitive.ObjectID, kind string, price models.Money) ([]*models.Subscription, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionMongoRepo.FindArmed")
+	defer span.Finish()
+
+	filter := bson.M{
+		"product_id": productID,
+		"kind":       kind,
+		"fired_at":   nil,
+	}
+	if kind == models.SubscriptionKindPriceDrop {
+		filter["$or"] = bson.A{
+			bson.M{"threshold": nil},
+			bson.M{"threshold.currency": price.Currency, "threshold.amount": bson.M{"$gte": price.Amount}},
+		}
+	}
+
+	cursor, err := s.collection().Find(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "Find failed")
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := make([]*models.Subscription, 0)
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, errors.Wrap(err, "cursor.All failed")
+	}
+
+	return subscriptions, nil
+}
+
+// Claim marks the subscription fired, false when another worker or a redelivered event already did
+func (s *subscriptionMongoRepo) Claim(ctx context.Context, subscriptionID primitive.ObjectID) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionMongoRepo.Claim")
+	defer span.Finish()
+
+	result, err := s.collection().UpdateOne(
+		ctx,
+		bson.M{"_id": subscriptionID, "fired_at": nil},
+		bson.M{"$set": bson.M{"fired_at": time.Now().UTC()}},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "UpdateOne failed")
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// Release re-arms a claimed subscription whose notification could not be published
+func (s *subscriptionMongoRepo) Release(ctx context.Context, subscriptionID primitive.ObjectID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionMongoRepo.Release")
+	defer span.Finish()
+
+	if _, err := s.collection().UpdateOne(
+		ctx,
+		bson.M{"_id": subscriptionID},
+		bson.M{"$set": bson.M{"fired_at": nil}},
+	); err != nil {
+		return errors.Wrap(err, "UpdateOne failed")
+	}
+
+	return nil
+}
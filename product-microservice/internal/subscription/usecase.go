@@ -0,0 +1,15 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UseCase subscription
+type UseCase interface {
+	Subscribe(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error)
+	Unsubscribe(ctx context.Context, userID string, productID primitive.ObjectID, kind string) error
+	NotifySubscribers(ctx context.Context, previous, updated *models.Product) error
+}
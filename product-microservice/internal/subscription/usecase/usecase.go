@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chuuch/product-microservice/internal/models"
+	productKafka "github.com/chuuch/product-microservice/internal/product/delivery/kafka"
+	"github.com/chuuch/product-microservice/internal/subscription"
+	"github.com/chuuch/product-microservice/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var firedSubscriptions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "products_fired_subscriptions_total",
+	Help: "Total number of fired product subscriptions",
+}, []string{"kind"})
+
+type subscriptionUC struct {
+	subscriptionRepo subscription.MongoRepository
+	log              logger.Logger
+	validate         *validator.Validate
+	productsProducer productKafka.ProductsProducer
+}
+
+func NewSubscriptionUC(
+	subscriptionRepo subscription.MongoRepository,
+	log logger.Logger,
+	validate *validator.Validate,
+	productsProducer productKafka.ProductsProducer,
+) *subscriptionUC {
+	return &subscriptionUC{
+		subscriptionRepo: subscriptionRepo,
+		log:              log,
+		validate:         validate,
+		productsProducer: productsProducer,
+	}
+}
+
+func (u *subscriptionUC) Subscribe(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionUC.Subscribe")
+	defer span.Finish()
+
+	if err := u.validate.StructCtx(ctx, subscription); err != nil {
+		return nil, errors.Wrap(err, "validate.StructCtx")
+	}
+
+	return u.subscriptionRepo.Subscribe(ctx, subscription)
+}
+
+func (u *subscriptionUC) Unsubscribe(ctx context.Context, userID string, productID primitive.ObjectID, kind string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionUC.Unsubscribe")
+	defer span.Finish()
+
+	return u.subscriptionRepo.Unsubscribe(ctx, userID, productID, kind)
+}
+
+// NotifySubscribers compares the product before and after an update and publishes a notification for each fired subscription
+func (u *subscriptionUC) NotifySubscribers(ctx context.Context, previous, updated *models.Product) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "subscriptionUC.NotifySubscribers")
+	defer span.Finish()
+
+	if previous == nil || updated == nil {
+		return nil
+	}
+
+	if previous.Quantity <= 0 && updated.Quantity > 0 {
+		if err := u.notify(ctx, models.SubscriptionKindBackInStock, previous, updated); err != nil {
+			return err
+		}
+	}
+
+	// A currency change is not a price drop
+	if cmp, err := updated.Price.Compare(previous.Price); err == nil && updated.Price.Amount > 0 && cmp < 0 {
+		if err := u.notify(ctx, models.SubscriptionKindPriceDrop, previous, updated); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *subscriptionUC) notify(ctx context.Context, kind string, previous, updated *models.Product) error {
+	subscriptions, err := u.subscriptionRepo.FindArmed(ctx, updated.ProductID, kind, updated.Price)
+	if err != nil {
+		return errors.Wrap(err, "subscriptionRepo.FindArmed")
+	}
+
+	for _, sub := range subscriptions {
+		// Claiming first dedups concurrent workers and redelivered update events
+		claimed, err := u.subscriptionRepo.Claim(ctx, sub.SubscriptionID)
+		if err != nil {
+			return errors.Wrap(err, "subscriptionRepo.Claim")
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := u.publish(ctx, sub, previous, updated); err != nil {
+			if err := u.subscriptionRepo.Release(ctx, sub.SubscriptionID); err != nil {
+				u.log.Errorf("subscriptionRepo.Release: %v", err)
+			}
+			return err
+		}
+
+		firedSubscriptions.WithLabelValues(kind).Inc()
+		u.log.Infof("Fired %s subscription: %s, product: %s", kind, sub.SubscriptionID.Hex(), updated.ProductID.Hex())
+	}
+
+	return nil
+}
+
+func (u *subscriptionUC) publish(ctx context.Context, sub *models.Subscription, previous, updated *models.Product) error {
+	notificationBytes, err := json.Marshal(&models.ProductNotification{
+		SubscriptionID: sub.SubscriptionID.Hex(),
+		Kind:           sub.Kind,
+		UserID:         sub.UserID,
+		Email:          sub.Email,
+		ProductID:      updated.ProductID.Hex(),
+		Name:           updated.Name,
+		Price:          updated.Price.String(),
+		OldPrice:       previous.Price.String(),
+		Quantity:       updated.Quantity,
+	})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal failed")
+	}
+
+	return u.productsProducer.PublishNotification(ctx, kafka.Message{
+		Key:   []byte(updated.ProductID.Hex()),
+		Value: notificationBytes,
+		Time:  time.Now().UTC(),
+	})
+}
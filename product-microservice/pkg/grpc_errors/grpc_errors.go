@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"strings"
 
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -22,8 +24,41 @@ var (
 // Parse error and get code
 func ParseGRPCError(err error) codes.Code {
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
+	case errors.Is(err, sql.ErrNoRows) || errors.Is(err, mongo.ErrNoDocuments):
 		return codes.NotFound
+	case errors.Is(err, productErrors.ErrOperationNotFound),
+		errors.Is(err, productErrors.ErrDeadLetterNotFound),
+		errors.Is(err, productErrors.ErrConsumerNotFound):
+		return codes.NotFound
+	case errors.Is(err, productErrors.ErrInsufficientStock),
+		errors.Is(err, productErrors.ErrScheduleOverlap),
+		errors.Is(err, productErrors.ErrScheduleNotCancellable),
+		errors.Is(err, productErrors.ErrReviewNotAccepted),
+		errors.Is(err, productErrors.ErrVersionConflict):
+		return codes.FailedPrecondition
+	case errors.Is(err, productErrors.ErrInvalidVariantOptions),
+		errors.Is(err, productErrors.ErrCurrencyMismatch),
+		errors.Is(err, productErrors.ErrNoCurrencyRate),
+		errors.Is(err, productErrors.ErrInvalidCurrencyRate),
+		errors.Is(err, productErrors.ErrInvalidSchedule),
+		errors.Is(err, productErrors.ErrInvalidUpdateMask),
+		errors.Is(err, productErrors.ErrProductIDRequired),
+		errors.Is(err, productErrors.ErrTooManyProductIDs),
+		errors.Is(err, productErrors.ErrInvalidCatalogFile),
+		errors.Is(err, productErrors.ErrInvalidIdempotencyKey),
+		errors.Is(err, productErrors.ErrTooManyMessageIDs),
+		errors.Is(err, productErrors.ErrInvalidWorkers):
+		return codes.InvalidArgument
+	case errors.Is(err, productErrors.ErrSKUCodeExists),
+		errors.Is(err, productErrors.ErrReviewExists):
+		return codes.AlreadyExists
+	case errors.Is(err, productErrors.ErrRequestInProgress),
+		errors.Is(err, productErrors.ErrDeadLetterReplaying):
+		return codes.Aborted
+	case errors.Is(err, productErrors.ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, productErrors.ErrForbidden):
+		return codes.PermissionDenied
 	case errors.Is(err, context.Canceled):
 		return codes.Canceled
 	case errors.Is(err, context.DeadlineExceeded):
@@ -34,7 +69,7 @@ func ParseGRPCError(err error) codes.Code {
 		return codes.Unauthenticated
 	case errors.Is(err, ErrInvalidSessionId):
 		return codes.PermissionDenied
-	case strings.Contains(err.Error(), "Validate"):
+	case strings.Contains(err.Error(), "Validate") || strings.Contains(err.Error(), "validate"):
 		return codes.InvalidArgument
 	case strings.Contains(err.Error(), "redis"):
 		return codes.NotFound
@@ -65,6 +100,8 @@ func MapGRPCErrStatusCodeToHttpStatus(code codes.Code) int {
 		return http.StatusGatewayTimeout
 	case codes.InvalidArgument:
 		return http.StatusBadRequest
+	case codes.FailedPrecondition, codes.Aborted:
+		return http.StatusConflict
 	}
 
 	return http.StatusInternalServerError
@@ -72,5 +109,5 @@ func MapGRPCErrStatusCodeToHttpStatus(code codes.Code) int {
 
 // Error Response GRPC error response
 func ErrorResponse(err error, msg string) error {
-	return status.Errorf(ParseGRPCError(err), fmt.Sprintf("%s: %v", msg, err))
+	return status.Error(ParseGRPCError(err), fmt.Sprintf("%s: %v", msg, err))
 }
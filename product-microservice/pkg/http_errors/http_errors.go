@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"strings"
 
+	productErrors "github.com/chuuch/product-microservice/pkg/product_errors"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
@@ -25,6 +27,7 @@ const (
 	ErrInvalidEmail     = "Invalid email"
 	ErrInvalidPassword  = "Invalid password"
 	ErrInvalidField     = "Invalid field"
+	ErrUnsupportedMedia = "Unsupported media type"
 )
 
 var (
@@ -160,10 +163,43 @@ func NewInternalServerError(causes interface{}) RestErr {
 // Parser of error string messages returns RestError
 func ParseErrors(err error) RestErr {
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
+	case errors.Is(err, sql.ErrNoRows) || errors.Is(err, mongo.ErrNoDocuments):
 		return NewRestError(http.StatusNotFound, ErrNotFound, nil)
+	case errors.Is(err, productErrors.ErrOperationNotFound) || errors.Is(err, productErrors.ErrDeadLetterNotFound) ||
+		errors.Is(err, productErrors.ErrConsumerNotFound):
+		return NewRestError(http.StatusNotFound, ErrNotFound, err.Error())
 	case errors.Is(err, context.DeadlineExceeded):
 		return NewRestError(http.StatusRequestTimeout, ErrRequestTimeout, nil)
+	case errors.Is(err, productErrors.ErrInsufficientStock):
+		return NewRestError(http.StatusConflict, productErrors.ErrInsufficientStock.Error(), nil)
+	case errors.Is(err, productErrors.ErrScheduleOverlap) || errors.Is(err, productErrors.ErrScheduleNotCancellable):
+		return NewRestError(http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, productErrors.ErrReviewNotAccepted),
+		errors.Is(err, productErrors.ErrRequestInProgress),
+		errors.Is(err, productErrors.ErrDeadLetterReplaying):
+		return NewRestError(http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, productErrors.ErrVersionConflict):
+		return NewRestError(http.StatusPreconditionFailed, productErrors.ErrVersionConflict.Error(), nil)
+	case errors.Is(err, productErrors.ErrSKUCodeExists),
+		errors.Is(err, productErrors.ErrReviewExists):
+		return NewRestError(http.StatusConflict, ErrAlreadyExists, err.Error())
+	case errors.Is(err, productErrors.ErrInvalidVariantOptions),
+		errors.Is(err, productErrors.ErrCurrencyMismatch),
+		errors.Is(err, productErrors.ErrNoCurrencyRate),
+		errors.Is(err, productErrors.ErrInvalidCurrencyRate),
+		errors.Is(err, productErrors.ErrInvalidSchedule),
+		errors.Is(err, productErrors.ErrInvalidUpdateMask),
+		errors.Is(err, productErrors.ErrProductIDRequired),
+		errors.Is(err, productErrors.ErrTooManyProductIDs),
+		errors.Is(err, productErrors.ErrInvalidCatalogFile),
+		errors.Is(err, productErrors.ErrInvalidIdempotencyKey),
+		errors.Is(err, productErrors.ErrTooManyMessageIDs),
+		errors.Is(err, productErrors.ErrInvalidWorkers):
+		return NewRestError(http.StatusBadRequest, ErrInvalidField, err.Error())
+	case errors.Is(err, productErrors.ErrUnauthenticated):
+		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, nil)
+	case errors.Is(err, productErrors.ErrForbidden):
+		return NewRestError(http.StatusForbidden, ErrForbidden, nil)
 	case errors.Is(err, Unauthorized):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, nil)
 	case errors.Is(err, WrongCredentials):
@@ -0,0 +1,92 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache size bounded least recently used cache with per entry expiry, safe for concurrent use
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is the most recently used
+	entries map[K]*list.Element
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// New cache holding at most size entries
+func New[K comparable, V any](size int) *Cache[K, V] {
+	return &Cache[K, V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[K]*list.Element, size),
+	}
+}
+
+// Get value of the key, expired entries are removed and missed
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := element.Value.(*entry[K, V])
+	if time.Now().After(e.expiresAt) {
+		c.remove(element)
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return e.value, true
+}
+
+// Set value of the key for ttl, the least recently used entry is evicted when the cache is full
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete key
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len number of entries, expired ones included until they are read or evicted
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,121 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache_GetSet(t *testing.T) {
+	c := New[string, int](2)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get on an empty cache hit")
+	}
+
+	c.Set("a", 1, time.Minute)
+	c.Set("a", 2, time.Minute)
+	if got, ok := c.Get("a"); !ok || got != 2 {
+		t.Fatalf("Get(a) = %v, %v, want 2, true", got, ok)
+	}
+	if got := c.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1", got)
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	tests := []struct {
+		name    string
+		touch   string // read before the third entry is set
+		evicted string
+		kept    []string
+	}{
+		{name: "oldest", evicted: "a", kept: []string{"b", "c"}},
+		{name: "read moves to front", touch: "a", evicted: "b", kept: []string{"a", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string, int](2)
+			c.Set("a", 1, time.Minute)
+			c.Set("b", 2, time.Minute)
+			if tt.touch != "" {
+				c.Get(tt.touch)
+			}
+			c.Set("c", 3, time.Minute)
+
+			if _, ok := c.Get(tt.evicted); ok {
+				t.Fatalf("Get(%s) hit, want evicted", tt.evicted)
+			}
+			for _, key := range tt.kept {
+				if _, ok := c.Get(key); !ok {
+					t.Fatalf("Get(%s) missed, want kept", key)
+				}
+			}
+			if got := c.Len(); got != 2 {
+				t.Fatalf("Len() = %d, want 2", got)
+			}
+		})
+	}
+}
+
+func TestCache_Expiry(t *testing.T) {
+	c := New[string, int](2)
+	c.Set("a", 1, -time.Second)
+	c.Set("b", 2, time.Minute)
+
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2 before the expired entry is read", got)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) hit an expired entry")
+	}
+	if got := c.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1 after the expired entry is read", got)
+	}
+
+	c.Set("a", 3, time.Minute)
+	if got, ok := c.Get("a"); !ok || got != 3 {
+		t.Fatalf("Get(a) = %v, %v, want 3, true after it is set again", got, ok)
+	}
+}
+
+func TestCache_Delete(t *testing.T) {
+	c := New[string, int](2)
+	c.Set("a", 1, time.Minute)
+
+	c.Delete("a")
+	c.Delete("missing")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) hit a deleted entry")
+	}
+	if got := c.Len(); got != 0 {
+		t.Fatalf("Len() = %d, want 0", got)
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	c := New[int, int](16)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 1000 {
+				key := (i*1000 + j) % 32
+				c.Set(key, j, time.Minute)
+				c.Get(key)
+				if j%10 == 0 {
+					c.Delete(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.Len(); got > 16 {
+		t.Fatalf("Len() = %d, want at most 16", got)
+	}
+}
@@ -4,4 +4,31 @@ import "errors"
 
 var (
 	ErrObjectIDTypeConversion = errors.New("object id type conversion failed")
+	ErrInsufficientStock      = errors.New("insufficient stock")
+	ErrInvalidVariantOptions  = errors.New("sku options do not match the product variant options")
+	ErrSKUCodeExists          = errors.New("sku code already exists")
+	ErrCurrencyMismatch       = errors.New("currency mismatch")
+	ErrNoCurrencyRate         = errors.New("no conversion rate for currency")
+	ErrInvalidCurrencyRate    = errors.New("conversion rate must be a positive decimal")
+	ErrInvalidSchedule        = errors.New("scheduled price change must end after it starts")
+	ErrScheduleOverlap        = errors.New("scheduled price change overlaps another schedule of the product")
+	ErrScheduleNotCancellable = errors.New("scheduled price change already ended")
+	ErrUnauthenticated        = errors.New("no valid session")
+	ErrForbidden              = errors.New("not allowed for the user role")
+	ErrReviewExists           = errors.New("user already reviewed the product")
+	ErrReviewNotAccepted      = errors.New("review is not accepted")
+	ErrInvalidUpdateMask      = errors.New("update mask has a field that cannot be updated")
+	ErrProductIDRequired      = errors.New("product id is required")
+	ErrVersionConflict        = errors.New("product version does not match the expected version")
+	ErrTooManyProductIDs      = errors.New("too many product ids in one request")
+	ErrInvalidCatalogFile     = errors.New("invalid catalog file")
+	ErrInvalidCatalogRow      = errors.New("invalid catalog row")
+	ErrOperationNotFound      = errors.New("operation not found or expired")
+	ErrInvalidIdempotencyKey  = errors.New("invalid idempotency key")
+	ErrRequestInProgress      = errors.New("a request with the idempotency key is still in progress")
+	ErrDeadLetterNotFound     = errors.New("dead letter not found")
+	ErrDeadLetterReplaying    = errors.New("dead letter is being replayed")
+	ErrTooManyMessageIDs      = errors.New("too many message ids in one request")
+	ErrConsumerNotFound       = errors.New("no consumer of the topic")
+	ErrInvalidWorkers         = errors.New("invalid number of consumer workers")
 )
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) to the target document
+func MergePatch(target, patch []byte) ([]byte, error) {
+	var targetValue, patchValue any
+	if err := json.Unmarshal(target, &targetValue); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal target")
+	}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal patch")
+	}
+
+	merged, err := json.Marshal(mergeValue(targetValue, patchValue))
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+	return merged, nil
+}
+
+func mergeValue(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,539 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: product/catalog.proto
+
+package productService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CatalogImportOptions is the first message of an ImportCatalog stream
+type CatalogImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// csv or jsonl
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// product column to file column or key, e.g. name to Title
+	ColumnMapping map[string]string `protobuf:"bytes,2,rep,name=column_mapping,json=columnMapping,proto3" json:"column_mapping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// validates every row without writing
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// rows already committed by an earlier run, from a previous committed_rows
+	SkipRows int64 `protobuf:"varint,4,opt,name=skip_rows,json=skipRows,proto3" json:"skip_rows,omitempty"`
+	// identifies the file, e.g. its checksum, the product ids are derived from it and the row
+	// so rows a crashed run inserted after its last committed_rows are not imported twice
+	ImportId      string `protobuf:"bytes,5,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogImportOptions) Reset() {
+	*x = CatalogImportOptions{}
+	mi := &file_product_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogImportOptions) ProtoMessage() {}
+
+func (x *CatalogImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_product_catalog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogImportOptions.ProtoReflect.Descriptor instead.
+func (*CatalogImportOptions) Descriptor() ([]byte, []int) {
+	return file_product_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CatalogImportOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *CatalogImportOptions) GetColumnMapping() map[string]string {
+	if x != nil {
+		return x.ColumnMapping
+	}
+	return nil
+}
+
+func (x *CatalogImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CatalogImportOptions) GetSkipRows() int64 {
+	if x != nil {
+		return x.SkipRows
+	}
+	return 0
+}
+
+func (x *CatalogImportOptions) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+// ImportCatalogRequest is one message of an ImportCatalog stream, the options followed by the file data
+type ImportCatalogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportCatalogRequest_Options
+	//	*ImportCatalogRequest_Data
+	Payload       isImportCatalogRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCatalogRequest) Reset() {
+	*x = ImportCatalogRequest{}
+	mi := &file_product_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCatalogRequest) ProtoMessage() {}
+
+func (x *ImportCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_catalog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ImportCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_product_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImportCatalogRequest) GetPayload() isImportCatalogRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportCatalogRequest) GetOptions() *CatalogImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportCatalogRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportCatalogRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportCatalogRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isImportCatalogRequest_Payload interface {
+	isImportCatalogRequest_Payload()
+}
+
+type ImportCatalogRequest_Options struct {
+	Options *CatalogImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportCatalogRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ImportCatalogRequest_Options) isImportCatalogRequest_Payload() {}
+
+func (*ImportCatalogRequest_Data) isImportCatalogRequest_Payload() {}
+
+// CatalogRowError is the error of a rejected row
+type CatalogRowError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// data row number from 1, the CSV header is not counted
+	Row           int64  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogRowError) Reset() {
+	*x = CatalogRowError{}
+	mi := &file_product_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogRowError) ProtoMessage() {}
+
+func (x *CatalogRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_product_catalog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogRowError.ProtoReflect.Descriptor instead.
+func (*CatalogRowError) Descriptor() ([]byte, []int) {
+	return file_product_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CatalogRowError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *CatalogRowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ImportCatalogResponse is the progress sent after each committed chunk of rows
+type ImportCatalogResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every row up to this one is imported or reported, resume with it as skip_rows
+	CommittedRows int64 `protobuf:"varint,1,opt,name=committed_rows,json=committedRows,proto3" json:"committed_rows,omitempty"`
+	ImportedCount int64 `protobuf:"varint,2,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"`
+	FailedCount   int64 `protobuf:"varint,3,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	// errors of the rows of this chunk
+	Errors []*CatalogRowError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	// the last message of the stream
+	Done          bool `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCatalogResponse) Reset() {
+	*x = ImportCatalogResponse{}
+	mi := &file_product_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCatalogResponse) ProtoMessage() {}
+
+func (x *ImportCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCatalogResponse.ProtoReflect.Descriptor instead.
+func (*ImportCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_product_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImportCatalogResponse) GetCommittedRows() int64 {
+	if x != nil {
+		return x.CommittedRows
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetImportedCount() int64 {
+	if x != nil {
+		return x.ImportedCount
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetErrors() []*CatalogRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportCatalogResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// ExportCatalogRequest is the request for the ExportCatalog method
+type ExportCatalogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// csv or jsonl
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// optional category filter
+	CategoryId string `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// product column to file column or key
+	ColumnMapping map[string]string `protobuf:"bytes,3,rep,name=column_mapping,json=columnMapping,proto3" json:"column_mapping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCatalogRequest) Reset() {
+	*x = ExportCatalogRequest{}
+	mi := &file_product_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCatalogRequest) ProtoMessage() {}
+
+func (x *ExportCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ExportCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_product_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportCatalogRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportCatalogRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ExportCatalogRequest) GetColumnMapping() map[string]string {
+	if x != nil {
+		return x.ColumnMapping
+	}
+	return nil
+}
+
+// ExportCatalogChunk is a part of the exported file
+type ExportCatalogChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCatalogChunk) Reset() {
+	*x = ExportCatalogChunk{}
+	mi := &file_product_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCatalogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCatalogChunk) ProtoMessage() {}
+
+func (x *ExportCatalogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_product_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCatalogChunk.ProtoReflect.Descriptor instead.
+func (*ExportCatalogChunk) Descriptor() ([]byte, []int) {
+	return file_product_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportCatalogChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_product_catalog_proto protoreflect.FileDescriptor
+
+const file_product_catalog_proto_rawDesc = "" +
+	"\n" +
+	"\x15product/catalog.proto\x12\x0eproductService\"\xa3\x02\n" +
+	"\x14CatalogImportOptions\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12^\n" +
+	"\x0ecolumn_mapping\x18\x02 \x03(\v27.productService.CatalogImportOptions.ColumnMappingEntryR\rcolumnMapping\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x1b\n" +
+	"\tskip_rows\x18\x04 \x01(\x03R\bskipRows\x12\x1b\n" +
+	"\timport_id\x18\x05 \x01(\tR\bimportId\x1a@\n" +
+	"\x12ColumnMappingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"y\n" +
+	"\x14ImportCatalogRequest\x12@\n" +
+	"\aoptions\x18\x01 \x01(\v2$.productService.CatalogImportOptionsH\x00R\aoptions\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"9\n" +
+	"\x0fCatalogRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xd5\x01\n" +
+	"\x15ImportCatalogResponse\x12%\n" +
+	"\x0ecommitted_rows\x18\x01 \x01(\x03R\rcommittedRows\x12%\n" +
+	"\x0eimported_count\x18\x02 \x01(\x03R\rimportedCount\x12!\n" +
+	"\ffailed_count\x18\x03 \x01(\x03R\vfailedCount\x127\n" +
+	"\x06errors\x18\x04 \x03(\v2\x1f.productService.CatalogRowErrorR\x06errors\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\"\xf1\x01\n" +
+	"\x14ExportCatalogRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12^\n" +
+	"\x0ecolumn_mapping\x18\x03 \x03(\v27.productService.ExportCatalogRequest.ColumnMappingEntryR\rcolumnMapping\x1a@\n" +
+	"\x12ColumnMappingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"(\n" +
+	"\x12ExportCatalogChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2\xcf\x01\n" +
+	"\x0eCatalogService\x12`\n" +
+	"\rImportCatalog\x12$.productService.ImportCatalogRequest\x1a%.productService.ImportCatalogResponse(\x010\x01\x12[\n" +
+	"\rExportCatalog\x12$.productService.ExportCatalogRequest\x1a\".productService.ExportCatalogChunk0\x01B\x12Z\x10.;productServiceb\x06proto3"
+
+var (
+	file_product_catalog_proto_rawDescOnce sync.Once
+	file_product_catalog_proto_rawDescData []byte
+)
+
+func file_product_catalog_proto_rawDescGZIP() []byte {
+	file_product_catalog_proto_rawDescOnce.Do(func() {
+		file_product_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_product_catalog_proto_rawDesc), len(file_product_catalog_proto_rawDesc)))
+	})
+	return file_product_catalog_proto_rawDescData
+}
+
+var file_product_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_product_catalog_proto_goTypes = []any{
+	(*CatalogImportOptions)(nil),  // 0: productService.CatalogImportOptions
+	(*ImportCatalogRequest)(nil),  // 1: productService.ImportCatalogRequest
+	(*CatalogRowError)(nil),       // 2: productService.CatalogRowError
+	(*ImportCatalogResponse)(nil), // 3: productService.ImportCatalogResponse
+	(*ExportCatalogRequest)(nil),  // 4: productService.ExportCatalogRequest
+	(*ExportCatalogChunk)(nil),    // 5: productService.ExportCatalogChunk
+	nil,                           // 6: productService.CatalogImportOptions.ColumnMappingEntry
+	nil,                           // 7: productService.ExportCatalogRequest.ColumnMappingEntry
+}
+var file_product_catalog_proto_depIdxs = []int32{
+	6, // 0: productService.CatalogImportOptions.column_mapping:type_name -> productService.CatalogImportOptions.ColumnMappingEntry
+	0, // 1: productService.ImportCatalogRequest.options:type_name -> productService.CatalogImportOptions
+	2, // 2: productService.ImportCatalogResponse.errors:type_name -> productService.CatalogRowError
+	7, // 3: productService.ExportCatalogRequest.column_mapping:type_name -> productService.ExportCatalogRequest.ColumnMappingEntry
+	1, // 4: productService.CatalogService.ImportCatalog:input_type -> productService.ImportCatalogRequest
+	4, // 5: productService.CatalogService.ExportCatalog:input_type -> productService.ExportCatalogRequest
+	3, // 6: productService.CatalogService.ImportCatalog:output_type -> productService.ImportCatalogResponse
+	5, // 7: productService.CatalogService.ExportCatalog:output_type -> productService.ExportCatalogChunk
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_product_catalog_proto_init() }
+func file_product_catalog_proto_init() {
+	if File_product_catalog_proto != nil {
+		return
+	}
+	file_product_catalog_proto_msgTypes[1].OneofWrappers = []any{
+		(*ImportCatalogRequest_Options)(nil),
+		(*ImportCatalogRequest_Data)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_catalog_proto_rawDesc), len(file_product_catalog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_product_catalog_proto_goTypes,
+		DependencyIndexes: file_product_catalog_proto_depIdxs,
+		MessageInfos:      file_product_catalog_proto_msgTypes,
+	}.Build()
+	File_product_catalog_proto = out.File
+	file_product_catalog_proto_goTypes = nil
+	file_product_catalog_proto_depIdxs = nil
+}
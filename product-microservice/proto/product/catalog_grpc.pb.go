@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: product/catalog.proto
+
+package productService
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CatalogService_ImportCatalog_FullMethodName = "/productService.CatalogService/ImportCatalog"
+	CatalogService_ExportCatalog_FullMethodName = "/productService.CatalogService/ExportCatalog"
+)
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CatalogService is the service for bulk catalog import and export
+type CatalogServiceClient interface {
+	// ImportCatalog is the method to load products from a CSV or JSONL file, progress is streamed back per chunk
+	ImportCatalog(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportCatalogRequest, ImportCatalogResponse], error)
+	// ExportCatalog is the method to dump the catalog or a category as CSV or JSONL
+	ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCatalogChunk], error)
+}
+
+type catalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogServiceClient(cc grpc.ClientConnInterface) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) ImportCatalog(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportCatalogRequest, ImportCatalogResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], CatalogService_ImportCatalog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportCatalogRequest, ImportCatalogResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportCatalogClient = grpc.BidiStreamingClient[ImportCatalogRequest, ImportCatalogResponse]
+
+func (c *catalogServiceClient) ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCatalogChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[1], CatalogService_ExportCatalog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCatalogRequest, ExportCatalogChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportCatalogClient = grpc.ServerStreamingClient[ExportCatalogChunk]
+
+// CatalogServiceServer is the server API for CatalogService service.
+// All implementations must embed UnimplementedCatalogServiceServer
+// for forward compatibility.
+//
+// CatalogService is the service for bulk catalog import and export
+type CatalogServiceServer interface {
+	// ImportCatalog is the method to load products from a CSV or JSONL file, progress is streamed back per chunk
+	ImportCatalog(grpc.BidiStreamingServer[ImportCatalogRequest, ImportCatalogResponse]) error
+	// ExportCatalog is the method to dump the catalog or a category as CSV or JSONL
+	ExportCatalog(*ExportCatalogRequest, grpc.ServerStreamingServer[ExportCatalogChunk]) error
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogServiceServer struct{}
+
+func (UnimplementedCatalogServiceServer) ImportCatalog(grpc.BidiStreamingServer[ImportCatalogRequest, ImportCatalogResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportCatalog not implemented")
+}
+func (UnimplementedCatalogServiceServer) ExportCatalog(*ExportCatalogRequest, grpc.ServerStreamingServer[ExportCatalogChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportCatalog not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	// If the following call panics, it indicates UnimplementedCatalogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_ImportCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CatalogServiceServer).ImportCatalog(&grpc.GenericServerStream[ImportCatalogRequest, ImportCatalogResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportCatalogServer = grpc.BidiStreamingServer[ImportCatalogRequest, ImportCatalogResponse]
+
+func _CatalogService_ExportCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCatalogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).ExportCatalog(m, &grpc.GenericServerStream[ExportCatalogRequest, ExportCatalogChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportCatalogServer = grpc.ServerStreamingServer[ExportCatalogChunk]
+
+// CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "productService.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportCatalog",
+			Handler:       _CatalogService_ImportCatalog_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCatalog",
+			Handler:       _CatalogService_ExportCatalog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "product/catalog.proto",
+}
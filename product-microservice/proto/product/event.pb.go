@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: product/event.proto
+
+package productService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProductEvent is the envelope of the messages of the product topics
+type ProductEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// create_product or update_product
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// schema version of the payload, consumers reject versions newer than they know
+	Version    int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// id of the product the event is about
+	AggregateId string `protobuf:"bytes,5,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	// service and host that published the event
+	Producer string `protobuf:"bytes,6,opt,name=producer,proto3" json:"producer,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ProductEvent_Product
+	Payload       isProductEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductEvent) Reset() {
+	*x = ProductEvent{}
+	mi := &file_product_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductEvent) ProtoMessage() {}
+
+func (x *ProductEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_product_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductEvent.ProtoReflect.Descriptor instead.
+func (*ProductEvent) Descriptor() ([]byte, []int) {
+	return file_product_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProductEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProductEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ProductEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *ProductEvent) GetPayload() isProductEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ProductEvent) GetProduct() *Product {
+	if x != nil {
+		if x, ok := x.Payload.(*ProductEvent_Product); ok {
+			return x.Product
+		}
+	}
+	return nil
+}
+
+type isProductEvent_Payload interface {
+	isProductEvent_Payload()
+}
+
+type ProductEvent_Product struct {
+	Product *Product `protobuf:"bytes,7,opt,name=product,proto3,oneof"`
+}
+
+func (*ProductEvent_Product) isProductEvent_Payload() {}
+
+var File_product_event_proto protoreflect.FileDescriptor
+
+const file_product_event_proto_rawDesc = "" +
+	"\n" +
+	"\x13product/event.proto\x12\x0eproductService\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15product/product.proto\"\x93\x02\n" +
+	"\fProductEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12!\n" +
+	"\faggregate_id\x18\x05 \x01(\tR\vaggregateId\x12\x1a\n" +
+	"\bproducer\x18\x06 \x01(\tR\bproducer\x123\n" +
+	"\aproduct\x18\a \x01(\v2\x17.productService.ProductH\x00R\aproductB\t\n" +
+	"\apayloadB\x12Z\x10.;productServiceb\x06proto3"
+
+var (
+	file_product_event_proto_rawDescOnce sync.Once
+	file_product_event_proto_rawDescData []byte
+)
+
+func file_product_event_proto_rawDescGZIP() []byte {
+	file_product_event_proto_rawDescOnce.Do(func() {
+		file_product_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_product_event_proto_rawDesc), len(file_product_event_proto_rawDesc)))
+	})
+	return file_product_event_proto_rawDescData
+}
+
+var file_product_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_product_event_proto_goTypes = []any{
+	(*ProductEvent)(nil),          // 0: productService.ProductEvent
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*Product)(nil),               // 2: productService.Product
+}
+var file_product_event_proto_depIdxs = []int32{
+	1, // 0: productService.ProductEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2, // 1: productService.ProductEvent.product:type_name -> productService.Product
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_product_event_proto_init() }
+func file_product_event_proto_init() {
+	if File_product_event_proto != nil {
+		return
+	}
+	file_product_product_proto_init()
+	file_product_event_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProductEvent_Product)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_event_proto_rawDesc), len(file_product_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_product_event_proto_goTypes,
+		DependencyIndexes: file_product_event_proto_depIdxs,
+		MessageInfos:      file_product_event_proto_msgTypes,
+	}.Build()
+	File_product_event_proto = out.File
+	file_product_event_proto_goTypes = nil
+	file_product_event_proto_depIdxs = nil
+}
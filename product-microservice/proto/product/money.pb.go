@@ -0,0 +1,423 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: product/money.proto
+
+package productService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Money is an exact amount in the minor units of its currency, 1999 USD is 19.99
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 4217 code
+	CurrencyCode  string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_product_money_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_product_money_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_product_money_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// CurrencyRate is the conversion rate from the base to the quote currency
+type CurrencyRate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Quote string                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	// decimal string, 1 base = rate quote
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyRate) Reset() {
+	*x = CurrencyRate{}
+	mi := &file_product_money_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyRate) ProtoMessage() {}
+
+func (x *CurrencyRate) ProtoReflect() protoreflect.Message {
+	mi := &file_product_money_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyRate.ProtoReflect.Descriptor instead.
+func (*CurrencyRate) Descriptor() ([]byte, []int) {
+	return file_product_money_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CurrencyRate) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *CurrencyRate) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *CurrencyRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *CurrencyRate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// SetCurrencyRateRequest is the request for the SetCurrencyRate method
+type SetCurrencyRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         string                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCurrencyRateRequest) Reset() {
+	*x = SetCurrencyRateRequest{}
+	mi := &file_product_money_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCurrencyRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCurrencyRateRequest) ProtoMessage() {}
+
+func (x *SetCurrencyRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_money_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCurrencyRateRequest.ProtoReflect.Descriptor instead.
+func (*SetCurrencyRateRequest) Descriptor() ([]byte, []int) {
+	return file_product_money_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetCurrencyRateRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *SetCurrencyRateRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *SetCurrencyRateRequest) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+// SetCurrencyRateResponse is the response for the SetCurrencyRate method
+type SetCurrencyRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rate          *CurrencyRate          `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCurrencyRateResponse) Reset() {
+	*x = SetCurrencyRateResponse{}
+	mi := &file_product_money_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCurrencyRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCurrencyRateResponse) ProtoMessage() {}
+
+func (x *SetCurrencyRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_money_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCurrencyRateResponse.ProtoReflect.Descriptor instead.
+func (*SetCurrencyRateResponse) Descriptor() ([]byte, []int) {
+	return file_product_money_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetCurrencyRateResponse) GetRate() *CurrencyRate {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+// ListCurrencyRatesRequest is the request for the ListCurrencyRates method
+type ListCurrencyRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrencyRatesRequest) Reset() {
+	*x = ListCurrencyRatesRequest{}
+	mi := &file_product_money_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrencyRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrencyRatesRequest) ProtoMessage() {}
+
+func (x *ListCurrencyRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_money_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrencyRatesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrencyRatesRequest) Descriptor() ([]byte, []int) {
+	return file_product_money_proto_rawDescGZIP(), []int{4}
+}
+
+// ListCurrencyRatesResponse is the response for the ListCurrencyRates method
+type ListCurrencyRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*CurrencyRate        `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrencyRatesResponse) Reset() {
+	*x = ListCurrencyRatesResponse{}
+	mi := &file_product_money_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrencyRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrencyRatesResponse) ProtoMessage() {}
+
+func (x *ListCurrencyRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_money_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrencyRatesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrencyRatesResponse) Descriptor() ([]byte, []int) {
+	return file_product_money_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCurrencyRatesResponse) GetRates() []*CurrencyRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+var File_product_money_proto protoreflect.FileDescriptor
+
+const file_product_money_proto_rawDesc = "" +
+	"\n" +
+	"\x13product/money.proto\x12\x0eproductService\x1a\x1fgoogle/protobuf/timestamp.proto\"D\n" +
+	"\x05Money\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\x87\x01\n" +
+	"\fCurrencyRate\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x14\n" +
+	"\x05quote\x18\x02 \x01(\tR\x05quote\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"V\n" +
+	"\x16SetCurrencyRateRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x14\n" +
+	"\x05quote\x18\x02 \x01(\tR\x05quote\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\"K\n" +
+	"\x17SetCurrencyRateResponse\x120\n" +
+	"\x04rate\x18\x01 \x01(\v2\x1c.productService.CurrencyRateR\x04rate\"\x1a\n" +
+	"\x18ListCurrencyRatesRequest\"O\n" +
+	"\x19ListCurrencyRatesResponse\x122\n" +
+	"\x05rates\x18\x01 \x03(\v2\x1c.productService.CurrencyRateR\x05rates2\xdf\x01\n" +
+	"\x0fCurrencyService\x12b\n" +
+	"\x0fSetCurrencyRate\x12&.productService.SetCurrencyRateRequest\x1a'.productService.SetCurrencyRateResponse\x12h\n" +
+	"\x11ListCurrencyRates\x12(.productService.ListCurrencyRatesRequest\x1a).productService.ListCurrencyRatesResponseB\x12Z\x10.;productServiceb\x06proto3"
+
+var (
+	file_product_money_proto_rawDescOnce sync.Once
+	file_product_money_proto_rawDescData []byte
+)
+
+func file_product_money_proto_rawDescGZIP() []byte {
+	file_product_money_proto_rawDescOnce.Do(func() {
+		file_product_money_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_product_money_proto_rawDesc), len(file_product_money_proto_rawDesc)))
+	})
+	return file_product_money_proto_rawDescData
+}
+
+var file_product_money_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_product_money_proto_goTypes = []any{
+	(*Money)(nil),                     // 0: productService.Money
+	(*CurrencyRate)(nil),              // 1: productService.CurrencyRate
+	(*SetCurrencyRateRequest)(nil),    // 2: productService.SetCurrencyRateRequest
+	(*SetCurrencyRateResponse)(nil),   // 3: productService.SetCurrencyRateResponse
+	(*ListCurrencyRatesRequest)(nil),  // 4: productService.ListCurrencyRatesRequest
+	(*ListCurrencyRatesResponse)(nil), // 5: productService.ListCurrencyRatesResponse
+	(*timestamppb.Timestamp)(nil),     // 6: google.protobuf.Timestamp
+}
+var file_product_money_proto_depIdxs = []int32{
+	6, // 0: productService.CurrencyRate.updated_at:type_name -> google.protobuf.Timestamp
+	1, // 1: productService.SetCurrencyRateResponse.rate:type_name -> productService.CurrencyRate
+	1, // 2: productService.ListCurrencyRatesResponse.rates:type_name -> productService.CurrencyRate
+	2, // 3: productService.CurrencyService.SetCurrencyRate:input_type -> productService.SetCurrencyRateRequest
+	4, // 4: productService.CurrencyService.ListCurrencyRates:input_type -> productService.ListCurrencyRatesRequest
+	3, // 5: productService.CurrencyService.SetCurrencyRate:output_type -> productService.SetCurrencyRateResponse
+	5, // 6: productService.CurrencyService.ListCurrencyRates:output_type -> productService.ListCurrencyRatesResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_product_money_proto_init() }
+func file_product_money_proto_init() {
+	if File_product_money_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_money_proto_rawDesc), len(file_product_money_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_product_money_proto_goTypes,
+		DependencyIndexes: file_product_money_proto_depIdxs,
+		MessageInfos:      file_product_money_proto_msgTypes,
+	}.Build()
+	File_product_money_proto = out.File
+	file_product_money_proto_goTypes = nil
+	file_product_money_proto_depIdxs = nil
+}
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: product/money.proto
+
+package productService
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CurrencyService_SetCurrencyRate_FullMethodName   = "/productService.CurrencyService/SetCurrencyRate"
+	CurrencyService_ListCurrencyRates_FullMethodName = "/productService.CurrencyService/ListCurrencyRates"
+)
+
+// CurrencyServiceClient is the client API for CurrencyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CurrencyService is the service for the conversion rate table
+type CurrencyServiceClient interface {
+	// SetCurrencyRate is the method to create or replace a conversion rate
+	SetCurrencyRate(ctx context.Context, in *SetCurrencyRateRequest, opts ...grpc.CallOption) (*SetCurrencyRateResponse, error)
+	// ListCurrencyRates is the method to list all conversion rates
+	ListCurrencyRates(ctx context.Context, in *ListCurrencyRatesRequest, opts ...grpc.CallOption) (*ListCurrencyRatesResponse, error)
+}
+
+type currencyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCurrencyServiceClient(cc grpc.ClientConnInterface) CurrencyServiceClient {
+	return &currencyServiceClient{cc}
+}
+
+func (c *currencyServiceClient) SetCurrencyRate(ctx context.Context, in *SetCurrencyRateRequest, opts ...grpc.CallOption) (*SetCurrencyRateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCurrencyRateResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_SetCurrencyRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) ListCurrencyRates(ctx context.Context, in *ListCurrencyRatesRequest, opts ...grpc.CallOption) (*ListCurrencyRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurrencyRatesResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_ListCurrencyRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CurrencyServiceServer is the server API for CurrencyService service.
+// All implementations must embed UnimplementedCurrencyServiceServer
+// for forward compatibility.
+//
+// CurrencyService is the service for the conversion rate table
+type CurrencyServiceServer interface {
+	// SetCurrencyRate is the method to create or replace a conversion rate
+	SetCurrencyRate(context.Context, *SetCurrencyRateRequest) (*SetCurrencyRateResponse, error)
+	// ListCurrencyRates is the method to list all conversion rates
+	ListCurrencyRates(context.Context, *ListCurrencyRatesRequest) (*ListCurrencyRatesResponse, error)
+	mustEmbedUnimplementedCurrencyServiceServer()
+}
+
+// UnimplementedCurrencyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCurrencyServiceServer struct{}
+
+func (UnimplementedCurrencyServiceServer) SetCurrencyRate(context.Context, *SetCurrencyRateRequest) (*SetCurrencyRateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCurrencyRate not implemented")
+}
+func (UnimplementedCurrencyServiceServer) ListCurrencyRates(context.Context, *ListCurrencyRatesRequest) (*ListCurrencyRatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCurrencyRates not implemented")
+}
+func (UnimplementedCurrencyServiceServer) mustEmbedUnimplementedCurrencyServiceServer() {}
+func (UnimplementedCurrencyServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeCurrencyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CurrencyServiceServer will
+// result in compilation errors.
+type UnsafeCurrencyServiceServer interface {
+	mustEmbedUnimplementedCurrencyServiceServer()
+}
+
+func RegisterCurrencyServiceServer(s grpc.ServiceRegistrar, srv CurrencyServiceServer) {
+	// If the following call panics, it indicates UnimplementedCurrencyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CurrencyService_ServiceDesc, srv)
+}
+
+func _CurrencyService_SetCurrencyRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCurrencyRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).SetCurrencyRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_SetCurrencyRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).SetCurrencyRate(ctx, req.(*SetCurrencyRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ListCurrencyRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrencyRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ListCurrencyRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_ListCurrencyRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ListCurrencyRates(ctx, req.(*ListCurrencyRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CurrencyService_ServiceDesc is the grpc.ServiceDesc for CurrencyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CurrencyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "productService.CurrencyService",
+	HandlerType: (*CurrencyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetCurrencyRate",
+			Handler:    _CurrencyService_SetCurrencyRate_Handler,
+		},
+		{
+			MethodName: "ListCurrencyRates",
+			Handler:    _CurrencyService_ListCurrencyRates_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "product/money.proto",
+}
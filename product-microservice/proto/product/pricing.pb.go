@@ -0,0 +1,742 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: product/pricing.proto
+
+package productService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PriceChange is an entry of the price history of a product
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceChangeId string                 `protobuf:"bytes,1,opt,name=price_change_id,json=priceChangeId,proto3" json:"price_change_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// unset for the first price of a product
+	OldPrice *Money `protobuf:"bytes,3,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice *Money `protobuf:"bytes,4,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	// create, update, schedule or revert
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,6,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_product_pricing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PriceChange) GetPriceChangeId() string {
+	if x != nil {
+		return x.PriceChangeId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOldPrice() *Money {
+	if x != nil {
+		return x.OldPrice
+	}
+	return nil
+}
+
+func (x *PriceChange) GetNewPrice() *Money {
+	if x != nil {
+		return x.NewPrice
+	}
+	return nil
+}
+
+func (x *PriceChange) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PriceChange) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+// ScheduledPriceChange is a price applied at start_at and reverted at end_at
+type ScheduledPriceChange struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	ProductId  string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price      *Money                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	StartAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// unset keeps the price after it is applied
+	EndAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	// pending, active, completed or cancelled
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	PreviousPrice *Money                 `protobuf:"bytes,7,opt,name=previous_price,json=previousPrice,proto3" json:"previous_price,omitempty"`
+	AppliedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledPriceChange) Reset() {
+	*x = ScheduledPriceChange{}
+	mi := &file_product_pricing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPriceChange) ProtoMessage() {}
+
+func (x *ScheduledPriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPriceChange.ProtoReflect.Descriptor instead.
+func (*ScheduledPriceChange) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScheduledPriceChange) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ScheduledPriceChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ScheduledPriceChange) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledPriceChange) GetPreviousPrice() *Money {
+	if x != nil {
+		return x.PreviousPrice
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ScheduledPriceChange) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// GetPriceHistoryRequest is the request for the GetPriceHistory method
+type GetPriceHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_product_pricing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPriceHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// GetPriceHistoryResponse is the response for the GetPriceHistory method
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount    int64                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	PriceChanges  []*PriceChange         `protobuf:"bytes,6,rep,name=price_changes,json=priceChanges,proto3" json:"price_changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_product_pricing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPriceHistoryResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryResponse) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetPriceHistoryResponse) GetPriceChanges() []*PriceChange {
+	if x != nil {
+		return x.PriceChanges
+	}
+	return nil
+}
+
+// SchedulePriceChangeRequest is the request for the SchedulePriceChange method
+type SchedulePriceChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price         *Money                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeRequest) Reset() {
+	*x = SchedulePriceChangeRequest{}
+	mi := &file_product_pricing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeRequest) ProtoMessage() {}
+
+func (x *SchedulePriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SchedulePriceChangeRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SchedulePriceChangeRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *SchedulePriceChangeRequest) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *SchedulePriceChangeRequest) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+// SchedulePriceChangeResponse is the response for the SchedulePriceChange method
+type SchedulePriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *ScheduledPriceChange  `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeResponse) Reset() {
+	*x = SchedulePriceChangeResponse{}
+	mi := &file_product_pricing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeResponse) ProtoMessage() {}
+
+func (x *SchedulePriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SchedulePriceChangeResponse) GetSchedule() *ScheduledPriceChange {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+// CancelScheduledPriceChangeRequest is the request for the CancelScheduledPriceChange method
+type CancelScheduledPriceChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledPriceChangeRequest) Reset() {
+	*x = CancelScheduledPriceChangeRequest{}
+	mi := &file_product_pricing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledPriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledPriceChangeRequest) ProtoMessage() {}
+
+func (x *CancelScheduledPriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledPriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelScheduledPriceChangeRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+// CancelScheduledPriceChangeResponse is the response for the CancelScheduledPriceChange method
+type CancelScheduledPriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *ScheduledPriceChange  `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledPriceChangeResponse) Reset() {
+	*x = CancelScheduledPriceChangeResponse{}
+	mi := &file_product_pricing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledPriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledPriceChangeResponse) ProtoMessage() {}
+
+func (x *CancelScheduledPriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_pricing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledPriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_product_pricing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelScheduledPriceChangeResponse) GetSchedule() *ScheduledPriceChange {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+var File_product_pricing_proto protoreflect.FileDescriptor
+
+const file_product_pricing_proto_rawDesc = "" +
+	"\n" +
+	"\x15product/pricing.proto\x12\x0eproductService\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13product/money.proto\"\xb0\x02\n" +
+	"\vPriceChange\x12&\n" +
+	"\x0fprice_change_id\x18\x01 \x01(\tR\rpriceChangeId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x122\n" +
+	"\told_price\x18\x03 \x01(\v2\x15.productService.MoneyR\boldPrice\x122\n" +
+	"\tnew_price\x18\x04 \x01(\v2\x15.productService.MoneyR\bnewPrice\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12\x1f\n" +
+	"\vschedule_id\x18\x06 \x01(\tR\n" +
+	"scheduleId\x129\n" +
+	"\n" +
+	"changed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"\xab\x04\n" +
+	"\x14ScheduledPriceChange\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12+\n" +
+	"\x05price\x18\x03 \x01(\v2\x15.productService.MoneyR\x05price\x125\n" +
+	"\bstart_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12<\n" +
+	"\x0eprevious_price\x18\a \x01(\v2\x15.productService.MoneyR\rpreviousPrice\x129\n" +
+	"\n" +
+	"applied_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\x125\n" +
+	"\bended_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"_\n" +
+	"\x16GetPriceHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"\xe0\x01\n" +
+	"\x17GetPriceHistoryResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
+	"totalPages\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\x12@\n" +
+	"\rprice_changes\x18\x06 \x03(\v2\x1b.productService.PriceChangeR\fpriceChanges\"\xd2\x01\n" +
+	"\x1aSchedulePriceChangeRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12+\n" +
+	"\x05price\x18\x02 \x01(\v2\x15.productService.MoneyR\x05price\x125\n" +
+	"\bstart_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\"_\n" +
+	"\x1bSchedulePriceChangeResponse\x12@\n" +
+	"\bschedule\x18\x01 \x01(\v2$.productService.ScheduledPriceChangeR\bschedule\"D\n" +
+	"!CancelScheduledPriceChangeRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"f\n" +
+	"\"CancelScheduledPriceChangeResponse\x12@\n" +
+	"\bschedule\x18\x01 \x01(\v2$.productService.ScheduledPriceChangeR\bschedule2\xea\x02\n" +
+	"\x0ePricingService\x12b\n" +
+	"\x0fGetPriceHistory\x12&.productService.GetPriceHistoryRequest\x1a'.productService.GetPriceHistoryResponse\x12n\n" +
+	"\x13SchedulePriceChange\x12*.productService.SchedulePriceChangeRequest\x1a+.productService.SchedulePriceChangeResponse\x12\x83\x01\n" +
+	"\x1aCancelScheduledPriceChange\x121.productService.CancelScheduledPriceChangeRequest\x1a2.productService.CancelScheduledPriceChangeResponseB\x12Z\x10.;productServiceb\x06proto3"
+
+var (
+	file_product_pricing_proto_rawDescOnce sync.Once
+	file_product_pricing_proto_rawDescData []byte
+)
+
+func file_product_pricing_proto_rawDescGZIP() []byte {
+	file_product_pricing_proto_rawDescOnce.Do(func() {
+		file_product_pricing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_product_pricing_proto_rawDesc), len(file_product_pricing_proto_rawDesc)))
+	})
+	return file_product_pricing_proto_rawDescData
+}
+
+var file_product_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_product_pricing_proto_goTypes = []any{
+	(*PriceChange)(nil),                        // 0: productService.PriceChange
+	(*ScheduledPriceChange)(nil),               // 1: productService.ScheduledPriceChange
+	(*GetPriceHistoryRequest)(nil),             // 2: productService.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),            // 3: productService.GetPriceHistoryResponse
+	(*SchedulePriceChangeRequest)(nil),         // 4: productService.SchedulePriceChangeRequest
+	(*SchedulePriceChangeResponse)(nil),        // 5: productService.SchedulePriceChangeResponse
+	(*CancelScheduledPriceChangeRequest)(nil),  // 6: productService.CancelScheduledPriceChangeRequest
+	(*CancelScheduledPriceChangeResponse)(nil), // 7: productService.CancelScheduledPriceChangeResponse
+	(*Money)(nil),                              // 8: productService.Money
+	(*timestamppb.Timestamp)(nil),              // 9: google.protobuf.Timestamp
+}
+var file_product_pricing_proto_depIdxs = []int32{
+	8,  // 0: productService.PriceChange.old_price:type_name -> productService.Money
+	8,  // 1: productService.PriceChange.new_price:type_name -> productService.Money
+	9,  // 2: productService.PriceChange.changed_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: productService.ScheduledPriceChange.price:type_name -> productService.Money
+	9,  // 4: productService.ScheduledPriceChange.start_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: productService.ScheduledPriceChange.end_at:type_name -> google.protobuf.Timestamp
+	8,  // 6: productService.ScheduledPriceChange.previous_price:type_name -> productService.Money
+	9,  // 7: productService.ScheduledPriceChange.applied_at:type_name -> google.protobuf.Timestamp
+	9,  // 8: productService.ScheduledPriceChange.ended_at:type_name -> google.protobuf.Timestamp
+	9,  // 9: productService.ScheduledPriceChange.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 10: productService.ScheduledPriceChange.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: productService.GetPriceHistoryResponse.price_changes:type_name -> productService.PriceChange
+	8,  // 12: productService.SchedulePriceChangeRequest.price:type_name -> productService.Money
+	9,  // 13: productService.SchedulePriceChangeRequest.start_at:type_name -> google.protobuf.Timestamp
+	9,  // 14: productService.SchedulePriceChangeRequest.end_at:type_name -> google.protobuf.Timestamp
+	1,  // 15: productService.SchedulePriceChangeResponse.schedule:type_name -> productService.ScheduledPriceChange
+	1,  // 16: productService.CancelScheduledPriceChangeResponse.schedule:type_name -> productService.ScheduledPriceChange
+	2,  // 17: productService.PricingService.GetPriceHistory:input_type -> productService.GetPriceHistoryRequest
+	4,  // 18: productService.PricingService.SchedulePriceChange:input_type -> productService.SchedulePriceChangeRequest
+	6,  // 19: productService.PricingService.CancelScheduledPriceChange:input_type -> productService.CancelScheduledPriceChangeRequest
+	3,  // 20: productService.PricingService.GetPriceHistory:output_type -> productService.GetPriceHistoryResponse
+	5,  // 21: productService.PricingService.SchedulePriceChange:output_type -> productService.SchedulePriceChangeResponse
+	7,  // 22: productService.PricingService.CancelScheduledPriceChange:output_type -> productService.CancelScheduledPriceChangeResponse
+	20, // [20:23] is the sub-list for method output_type
+	17, // [17:20] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_product_pricing_proto_init() }
+func file_product_pricing_proto_init() {
+	if File_product_pricing_proto != nil {
+		return
+	}
+	file_product_money_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_pricing_proto_rawDesc), len(file_product_pricing_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_product_pricing_proto_goTypes,
+		DependencyIndexes: file_product_pricing_proto_depIdxs,
+		MessageInfos:      file_product_pricing_proto_msgTypes,
+	}.Build()
+	File_product_pricing_proto = out.File
+	file_product_pricing_proto_goTypes = nil
+	file_product_pricing_proto_depIdxs = nil
+}
@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: product/pricing.proto
+
+package productService
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PricingService_GetPriceHistory_FullMethodName            = "/productService.PricingService/GetPriceHistory"
+	PricingService_SchedulePriceChange_FullMethodName        = "/productService.PricingService/SchedulePriceChange"
+	PricingService_CancelScheduledPriceChange_FullMethodName = "/productService.PricingService/CancelScheduledPriceChange"
+)
+
+// PricingServiceClient is the client API for PricingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PricingService is the service for price history and scheduled price changes
+type PricingServiceClient interface {
+	// GetPriceHistory is the method to list the price changes of a product, newest first
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	// SchedulePriceChange is the method to plan a price for a time window
+	SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*SchedulePriceChangeResponse, error)
+	// CancelScheduledPriceChange is the method to cancel a pending schedule, an active one is reverted
+	CancelScheduledPriceChange(ctx context.Context, in *CancelScheduledPriceChangeRequest, opts ...grpc.CallOption) (*CancelScheduledPriceChangeResponse, error)
+}
+
+type pricingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPricingServiceClient(cc grpc.ClientConnInterface) PricingServiceClient {
+	return &pricingServiceClient{cc}
+}
+
+func (c *pricingServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, PricingService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*SchedulePriceChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchedulePriceChangeResponse)
+	err := c.cc.Invoke(ctx, PricingService_SchedulePriceChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) CancelScheduledPriceChange(ctx context.Context, in *CancelScheduledPriceChangeRequest, opts ...grpc.CallOption) (*CancelScheduledPriceChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledPriceChangeResponse)
+	err := c.cc.Invoke(ctx, PricingService_CancelScheduledPriceChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PricingServiceServer is the server API for PricingService service.
+// All implementations must embed UnimplementedPricingServiceServer
+// for forward compatibility.
+//
+// PricingService is the service for price history and scheduled price changes
+type PricingServiceServer interface {
+	// GetPriceHistory is the method to list the price changes of a product, newest first
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	// SchedulePriceChange is the method to plan a price for a time window
+	SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*SchedulePriceChangeResponse, error)
+	// CancelScheduledPriceChange is the method to cancel a pending schedule, an active one is reverted
+	CancelScheduledPriceChange(context.Context, *CancelScheduledPriceChangeRequest) (*CancelScheduledPriceChangeResponse, error)
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+// UnimplementedPricingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPricingServiceServer struct{}
+
+func (UnimplementedPricingServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedPricingServiceServer) SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*SchedulePriceChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SchedulePriceChange not implemented")
+}
+func (UnimplementedPricingServiceServer) CancelScheduledPriceChange(context.Context, *CancelScheduledPriceChangeRequest) (*CancelScheduledPriceChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelScheduledPriceChange not implemented")
+}
+func (UnimplementedPricingServiceServer) mustEmbedUnimplementedPricingServiceServer() {}
+func (UnimplementedPricingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePricingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PricingServiceServer will
+// result in compilation errors.
+type UnsafePricingServiceServer interface {
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+func RegisterPricingServiceServer(s grpc.ServiceRegistrar, srv PricingServiceServer) {
+	// If the following call panics, it indicates UnimplementedPricingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PricingService_ServiceDesc, srv)
+}
+
+func _PricingService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_SchedulePriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePriceChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SchedulePriceChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SchedulePriceChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SchedulePriceChange(ctx, req.(*SchedulePriceChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_CancelScheduledPriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledPriceChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).CancelScheduledPriceChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_CancelScheduledPriceChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).CancelScheduledPriceChange(ctx, req.(*CancelScheduledPriceChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PricingService_ServiceDesc is the grpc.ServiceDesc for PricingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PricingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "productService.PricingService",
+	HandlerType: (*PricingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _PricingService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "SchedulePriceChange",
+			Handler:    _PricingService_SchedulePriceChange_Handler,
+		},
+		{
+			MethodName: "CancelScheduledPriceChange",
+			Handler:    _PricingService_CancelScheduledPriceChange_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "product/pricing.proto",
+}
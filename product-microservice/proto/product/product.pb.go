@@ -9,6 +9,7 @@ package productService
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -24,18 +25,27 @@ const (
 
 // Product is the message for the Product microservice
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	Photos        []string               `protobuf:"bytes,7,rep,name=photos,proto3" json:"photos,omitempty"`
-	Quantity      int64                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Rating        int64                  `protobuf:"varint,9,opt,name=rating,proto3" json:"rating,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId  string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl    string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Photos      []string               `protobuf:"bytes,7,rep,name=photos,proto3" json:"photos,omitempty"`
+	Quantity    int64                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// option axes, e.g. size and color
+	Options  []*VariantOption `protobuf:"bytes,12,rep,name=options,proto3" json:"options,omitempty"`
+	Variants []*SKU           `protobuf:"bytes,13,rep,name=variants,proto3" json:"variants,omitempty"`
+	Price    *Money           `protobuf:"bytes,14,opt,name=price,proto3" json:"price,omitempty"`
+	// explicit prices in other currencies, converted with the rate table otherwise
+	Prices []*Money `protobuf:"bytes,15,rep,name=prices,proto3" json:"prices,omitempty"`
+	// computed from the accepted reviews
+	RatingAverage float64 `protobuf:"fixed64,16,opt,name=rating_average,json=ratingAverage,proto3" json:"rating_average,omitempty"`
+	RatingCount   int64   `protobuf:"varint,17,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	// incremented by every write
+	Version       int64 `protobuf:"varint,18,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,13 +108,6 @@ func (x *Product) GetDescription() string {
 	return ""
 }
 
-func (x *Product) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
 func (x *Product) GetImageUrl() string {
 	if x != nil {
 		return x.ImageUrl
@@ -126,27 +129,241 @@ func (x *Product) GetQuantity() int64 {
 	return 0
 }
 
-func (x *Product) GetRating() int64 {
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetOptions() []*VariantOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Product) GetVariants() []*SKU {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Product) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Product) GetPrices() []*Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *Product) GetRatingAverage() float64 {
+	if x != nil {
+		return x.RatingAverage
+	}
+	return 0
+}
+
+func (x *Product) GetRatingCount() int64 {
 	if x != nil {
-		return x.Rating
+		return x.RatingCount
 	}
 	return 0
 }
 
-func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// VariantOption is an option axis of a product with its allowed values
+type VariantOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantOption) Reset() {
+	*x = VariantOption{}
+	mi := &file_product_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantOption) ProtoMessage() {}
+
+func (x *VariantOption) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantOption.ProtoReflect.Descriptor instead.
+func (*VariantOption) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VariantOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VariantOption) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// SKU is a sellable variant of a product with its own stock
+type SKU struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SkuId     string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Code      string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	// one value per product option axis
+	Options   map[string]string      `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Quantity  int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Barcode   string                 `protobuf:"bytes,7,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Images    []string               `protobuf:"bytes,8,rep,name=images,proto3" json:"images,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// overrides the product price when set
+	Price         *Money `protobuf:"bytes,11,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SKU) Reset() {
+	*x = SKU{}
+	mi := &file_product_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SKU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SKU) ProtoMessage() {}
+
+func (x *SKU) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SKU.ProtoReflect.Descriptor instead.
+func (*SKU) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SKU) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *SKU) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SKU) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SKU) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *SKU) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SKU) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *SKU) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *SKU) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *SKU) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return nil
 }
 
+func (x *SKU) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
 // Empty is the message for the Empty microservice
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -156,7 +373,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_product_product_proto_msgTypes[1]
+	mi := &file_product_product_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -168,7 +385,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[1]
+	mi := &file_product_product_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -181,7 +398,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{1}
+	return file_product_product_proto_rawDescGZIP(), []int{3}
 }
 
 // CreateRequest is the request for the Create method
@@ -190,18 +407,19 @@ type CreateRequest struct {
 	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
 	ImageUrl      string                 `protobuf:"bytes,5,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
 	Photos        []string               `protobuf:"bytes,6,rep,name=photos,proto3" json:"photos,omitempty"`
 	Quantity      int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Rating        int64                  `protobuf:"varint,8,opt,name=rating,proto3" json:"rating,omitempty"`
+	Options       []*VariantOption       `protobuf:"bytes,9,rep,name=options,proto3" json:"options,omitempty"`
+	Price         *Money                 `protobuf:"bytes,10,opt,name=price,proto3" json:"price,omitempty"`
+	Prices        []*Money               `protobuf:"bytes,11,rep,name=prices,proto3" json:"prices,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateRequest) Reset() {
 	*x = CreateRequest{}
-	mi := &file_product_product_proto_msgTypes[2]
+	mi := &file_product_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -213,7 +431,7 @@ func (x *CreateRequest) String() string {
 func (*CreateRequest) ProtoMessage() {}
 
 func (x *CreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[2]
+	mi := &file_product_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,7 +444,7 @@ func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{2}
+	return file_product_product_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateRequest) GetCategoryId() string {
@@ -250,13 +468,6 @@ func (x *CreateRequest) GetDescription() string {
 	return ""
 }
 
-func (x *CreateRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
 func (x *CreateRequest) GetImageUrl() string {
 	if x != nil {
 		return x.ImageUrl
@@ -278,11 +489,25 @@ func (x *CreateRequest) GetQuantity() int64 {
 	return 0
 }
 
-func (x *CreateRequest) GetRating() int64 {
+func (x *CreateRequest) GetOptions() []*VariantOption {
 	if x != nil {
-		return x.Rating
+		return x.Options
 	}
-	return 0
+	return nil
+}
+
+func (x *CreateRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetPrices() []*Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
 }
 
 // CreateResponse is the response for the Create method
@@ -295,7 +520,7 @@ type CreateResponse struct {
 
 func (x *CreateResponse) Reset() {
 	*x = CreateResponse{}
-	mi := &file_product_product_proto_msgTypes[3]
+	mi := &file_product_product_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -307,7 +532,7 @@ func (x *CreateResponse) String() string {
 func (*CreateResponse) ProtoMessage() {}
 
 func (x *CreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[3]
+	mi := &file_product_product_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -320,7 +545,7 @@ func (x *CreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{3}
+	return file_product_product_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateResponse) GetProduct() *Product {
@@ -332,23 +557,29 @@ func (x *CreateResponse) GetProduct() *Product {
 
 // UpdateRequest is the request for the Update method
 type UpdateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	Photos        []string               `protobuf:"bytes,7,rep,name=photos,proto3" json:"photos,omitempty"`
-	Quantity      int64                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Rating        int64                  `protobuf:"varint,9,opt,name=rating,proto3" json:"rating,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId  string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl    string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Photos      []string               `protobuf:"bytes,7,rep,name=photos,proto3" json:"photos,omitempty"`
+	Quantity    int64                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Options     []*VariantOption       `protobuf:"bytes,10,rep,name=options,proto3" json:"options,omitempty"`
+	Price       *Money                 `protobuf:"bytes,11,opt,name=price,proto3" json:"price,omitempty"`
+	Prices      []*Money               `protobuf:"bytes,12,rep,name=prices,proto3" json:"prices,omitempty"`
+	// fields to change, unset changes the populated fields and "*" replaces every updatable field,
+	// empty values of the mask fields clear them
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,13,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// the update fails with FailedPrecondition unless the product is at this version, 0 updates any version
+	ExpectedVersion int64 `protobuf:"varint,14,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateRequest) Reset() {
 	*x = UpdateRequest{}
-	mi := &file_product_product_proto_msgTypes[4]
+	mi := &file_product_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +591,7 @@ func (x *UpdateRequest) String() string {
 func (*UpdateRequest) ProtoMessage() {}
 
 func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[4]
+	mi := &file_product_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +604,7 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{4}
+	return file_product_product_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateRequest) GetProductId() string {
@@ -404,13 +635,6 @@ func (x *UpdateRequest) GetDescription() string {
 	return ""
 }
 
-func (x *UpdateRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
 func (x *UpdateRequest) GetImageUrl() string {
 	if x != nil {
 		return x.ImageUrl
@@ -432,9 +656,37 @@ func (x *UpdateRequest) GetQuantity() int64 {
 	return 0
 }
 
-func (x *UpdateRequest) GetRating() int64 {
+func (x *UpdateRequest) GetOptions() []*VariantOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetPrices() []*Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetExpectedVersion() int64 {
 	if x != nil {
-		return x.Rating
+		return x.ExpectedVersion
 	}
 	return 0
 }
@@ -449,7 +701,7 @@ type UpdateResponse struct {
 
 func (x *UpdateResponse) Reset() {
 	*x = UpdateResponse{}
-	mi := &file_product_product_proto_msgTypes[5]
+	mi := &file_product_product_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -461,7 +713,7 @@ func (x *UpdateResponse) String() string {
 func (*UpdateResponse) ProtoMessage() {}
 
 func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[5]
+	mi := &file_product_product_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +726,7 @@ func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{5}
+	return file_product_product_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateResponse) GetProduct() *Product {
@@ -484,29 +736,212 @@ func (x *UpdateResponse) GetProduct() *Product {
 	return nil
 }
 
-// FindByIDRequest is the request for the FindByID method
-type FindByIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+// UpsertRequest is the request for the Upsert method
+type UpsertRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId  string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl    string                 `protobuf:"bytes,5,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Photos      []string               `protobuf:"bytes,6,rep,name=photos,proto3" json:"photos,omitempty"`
+	Quantity    int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Options     []*VariantOption       `protobuf:"bytes,8,rep,name=options,proto3" json:"options,omitempty"`
+	Price       *Money                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`
+	Prices      []*Money               `protobuf:"bytes,10,rep,name=prices,proto3" json:"prices,omitempty"`
+	// fields to change when the product exists like the update mask of UpdateRequest,
+	// an insert always needs the whole product
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,11,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FindByIDRequest) Reset() {
-	*x = FindByIDRequest{}
-	mi := &file_product_product_proto_msgTypes[6]
+func (x *UpsertRequest) Reset() {
+	*x = UpsertRequest{}
+	mi := &file_product_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindByIDRequest) String() string {
+func (x *UpsertRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindByIDRequest) ProtoMessage() {}
+func (*UpsertRequest) ProtoMessage() {}
 
-func (x *FindByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[6]
+func (x *UpsertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRequest.ProtoReflect.Descriptor instead.
+func (*UpsertRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpsertRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetPhotos() []string {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+func (x *UpsertRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *UpsertRequest) GetOptions() []*VariantOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *UpsertRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *UpsertRequest) GetPrices() []*Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *UpsertRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// UpsertResponse is the response for the Upsert method
+type UpsertResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// true when the product did not exist
+	Inserted      bool `protobuf:"varint,2,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertResponse) Reset() {
+	*x = UpsertResponse{}
+	mi := &file_product_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertResponse) ProtoMessage() {}
+
+func (x *UpsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertResponse.ProtoReflect.Descriptor instead.
+func (*UpsertResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpsertResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpsertResponse) GetInserted() bool {
+	if x != nil {
+		return x.Inserted
+	}
+	return false
+}
+
+// FindByIDRequest is the request for the FindByID method
+type FindByIDRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// optional ISO 4217 code the prices are returned in
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindByIDRequest) Reset() {
+	*x = FindByIDRequest{}
+	mi := &file_product_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByIDRequest) ProtoMessage() {}
+
+func (x *FindByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +954,7 @@ func (x *FindByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindByIDRequest.ProtoReflect.Descriptor instead.
 func (*FindByIDRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{6}
+	return file_product_product_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *FindByIDRequest) GetProductId() string {
@@ -529,6 +964,13 @@ func (x *FindByIDRequest) GetProductId() string {
 	return ""
 }
 
+func (x *FindByIDRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 // FindByIDResponse is the response for the FindByID method
 type FindByIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -539,7 +981,7 @@ type FindByIDResponse struct {
 
 func (x *FindByIDResponse) Reset() {
 	*x = FindByIDResponse{}
-	mi := &file_product_product_proto_msgTypes[7]
+	mi := &file_product_product_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -551,7 +993,7 @@ func (x *FindByIDResponse) String() string {
 func (*FindByIDResponse) ProtoMessage() {}
 
 func (x *FindByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[7]
+	mi := &file_product_product_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +1006,7 @@ func (x *FindByIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindByIDResponse.ProtoReflect.Descriptor instead.
 func (*FindByIDResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{7}
+	return file_product_product_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FindByIDResponse) GetProduct() *Product {
@@ -576,17 +1018,19 @@ func (x *FindByIDResponse) GetProduct() *Product {
 
 // SearchRequest is the request for the Search method
 type SearchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page  int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// optional ISO 4217 code the prices are returned in
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchRequest) Reset() {
 	*x = SearchRequest{}
-	mi := &file_product_product_proto_msgTypes[8]
+	mi := &file_product_product_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -598,7 +1042,7 @@ func (x *SearchRequest) String() string {
 func (*SearchRequest) ProtoMessage() {}
 
 func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[8]
+	mi := &file_product_product_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,7 +1055,7 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{8}
+	return file_product_product_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SearchRequest) GetQuery() string {
@@ -635,6 +1079,13 @@ func (x *SearchRequest) GetSize() int64 {
 	return 0
 }
 
+func (x *SearchRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 // SearchResponse is the response for the Search method
 type SearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -650,7 +1101,7 @@ type SearchResponse struct {
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
-	mi := &file_product_product_proto_msgTypes[9]
+	mi := &file_product_product_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -662,7 +1113,7 @@ func (x *SearchResponse) String() string {
 func (*SearchResponse) ProtoMessage() {}
 
 func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[9]
+	mi := &file_product_product_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,7 +1126,7 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
 func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{9}
+	return file_product_product_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SearchResponse) GetTotalCount() int64 {
@@ -720,78 +1171,942 @@ func (x *SearchResponse) GetProducts() []*Product {
 	return nil
 }
 
-var File_product_product_proto protoreflect.FileDescriptor
+// GetProductsByIDsRequest is the request for the GetProductsByIDs method
+type GetProductsByIDsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// optional ISO 4217 code the prices are returned in
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_product_product_proto_rawDesc = "" +
-	"\n" +
-	"\x15product/product.proto\x12\x0eproductService\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x02\n" +
-	"\aProduct\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
-	"\vcategory_id\x18\x02 \x01(\tR\n" +
-	"categoryId\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1b\n" +
-	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x16\n" +
-	"\x06photos\x18\a \x03(\tR\x06photos\x12\x1a\n" +
-	"\bquantity\x18\b \x01(\x03R\bquantity\x12\x16\n" +
-	"\x06rating\x18\t \x01(\x03R\x06rating\x129\n" +
-	"\n" +
-	"created_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\a\n" +
-	"\x05Empty\"\xe5\x01\n" +
-	"\rCreateRequest\x12\x1f\n" +
-	"\vcategory_id\x18\x01 \x01(\tR\n" +
-	"categoryId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1b\n" +
-	"\timage_url\x18\x05 \x01(\tR\bimageUrl\x12\x16\n" +
-	"\x06photos\x18\x06 \x03(\tR\x06photos\x12\x1a\n" +
-	"\bquantity\x18\a \x01(\x03R\bquantity\x12\x16\n" +
-	"\x06rating\x18\b \x01(\x03R\x06rating\"C\n" +
-	"\x0eCreateResponse\x121\n" +
-	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\"\x84\x02\n" +
-	"\rUpdateRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
-	"\vcategory_id\x18\x02 \x01(\tR\n" +
-	"categoryId\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1b\n" +
-	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x16\n" +
-	"\x06photos\x18\a \x03(\tR\x06photos\x12\x1a\n" +
-	"\bquantity\x18\b \x01(\x03R\bquantity\x12\x16\n" +
-	"\x06rating\x18\t \x01(\x03R\x06rating\"C\n" +
-	"\x0eUpdateResponse\x121\n" +
-	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\"0\n" +
-	"\x0fFindByIDRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\"E\n" +
-	"\x10FindByIDResponse\x121\n" +
-	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\"M\n" +
-	"\rSearchRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x12\n" +
-	"\x04size\x18\x03 \x01(\x03R\x04size\"\xca\x01\n" +
-	"\x0eSearchResponse\x12\x1f\n" +
-	"\vtotal_count\x18\x01 \x01(\x03R\n" +
-	"totalCount\x12\x1f\n" +
-	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
-	"totalPages\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x19\n" +
-	"\bhas_more\x18\x05 \x01(\bR\ahasMore\x123\n" +
-	"\bproducts\x18\x06 \x03(\v2\x17.productService.ProductR\bproducts2\xcf\x02\n" +
+func (x *GetProductsByIDsRequest) Reset() {
+	*x = GetProductsByIDsRequest{}
+	mi := &file_product_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIDsRequest) ProtoMessage() {}
+
+func (x *GetProductsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProductsByIDsRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *GetProductsByIDsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// GetProductsByIDsResponse is the response for the GetProductsByIDs method
+type GetProductsByIDsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// in the order of the request, without variants
+	Products      []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	MissingIds    []string   `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIDsResponse) Reset() {
+	*x = GetProductsByIDsResponse{}
+	mi := &file_product_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIDsResponse) ProtoMessage() {}
+
+func (x *GetProductsByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProductsByIDsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetProductsByIDsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+// ListProductsRequest is the request for the ListProducts method
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// optional category filter
+	CategoryId string `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// resumes a scan after the last received product, products are streamed in id order
+	AfterProductId string `protobuf:"bytes,2,opt,name=after_product_id,json=afterProductId,proto3" json:"after_product_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_product_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetAfterProductId() string {
+	if x != nil {
+		return x.AfterProductId
+	}
+	return ""
+}
+
+// BatchCreateResult is the outcome of one product of a BatchCreateProducts stream
+type BatchCreateResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// position of the request in the stream, from 0
+	Index     int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ProductId string `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// empty when the product was created
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateResult) Reset() {
+	*x = BatchCreateResult{}
+	mi := &file_product_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateResult) ProtoMessage() {}
+
+func (x *BatchCreateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateResult.ProtoReflect.Descriptor instead.
+func (*BatchCreateResult) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchCreateResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchCreateResult) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BatchCreateResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// BatchCreateProductsResponse is the response for the BatchCreateProducts method
+type BatchCreateProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedCount  int64                  `protobuf:"varint,1,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	FailedCount   int64                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	Results       []*BatchCreateResult   `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateProductsResponse) Reset() {
+	*x = BatchCreateProductsResponse{}
+	mi := &file_product_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateProductsResponse) ProtoMessage() {}
+
+func (x *BatchCreateProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateProductsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchCreateProductsResponse) GetCreatedCount() int64 {
+	if x != nil {
+		return x.CreatedCount
+	}
+	return 0
+}
+
+func (x *BatchCreateProductsResponse) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *BatchCreateProductsResponse) GetResults() []*BatchCreateResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// CreateSKURequest is the request for the CreateSKU method
+type CreateSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku           *SKU                   `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSKURequest) Reset() {
+	*x = CreateSKURequest{}
+	mi := &file_product_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSKURequest) ProtoMessage() {}
+
+func (x *CreateSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSKURequest.ProtoReflect.Descriptor instead.
+func (*CreateSKURequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateSKURequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CreateSKURequest) GetSku() *SKU {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+// CreateSKUResponse is the response for the CreateSKU method
+type CreateSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           *SKU                   `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSKUResponse) Reset() {
+	*x = CreateSKUResponse{}
+	mi := &file_product_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSKUResponse) ProtoMessage() {}
+
+func (x *CreateSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSKUResponse.ProtoReflect.Descriptor instead.
+func (*CreateSKUResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateSKUResponse) GetSku() *SKU {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+// UpdateSKURequest is the request for the UpdateSKU method
+type UpdateSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkuId         string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	Sku           *SKU                   `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSKURequest) Reset() {
+	*x = UpdateSKURequest{}
+	mi := &file_product_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSKURequest) ProtoMessage() {}
+
+func (x *UpdateSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSKURequest.ProtoReflect.Descriptor instead.
+func (*UpdateSKURequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateSKURequest) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *UpdateSKURequest) GetSku() *SKU {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+// UpdateSKUResponse is the response for the UpdateSKU method
+type UpdateSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           *SKU                   `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSKUResponse) Reset() {
+	*x = UpdateSKUResponse{}
+	mi := &file_product_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSKUResponse) ProtoMessage() {}
+
+func (x *UpdateSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSKUResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSKUResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateSKUResponse) GetSku() *SKU {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+// DeleteSKURequest is the request for the DeleteSKU method
+type DeleteSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkuId         string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSKURequest) Reset() {
+	*x = DeleteSKURequest{}
+	mi := &file_product_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSKURequest) ProtoMessage() {}
+
+func (x *DeleteSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSKURequest.ProtoReflect.Descriptor instead.
+func (*DeleteSKURequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteSKURequest) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+// DeleteSKUResponse is the response for the DeleteSKU method
+type DeleteSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSKUResponse) Reset() {
+	*x = DeleteSKUResponse{}
+	mi := &file_product_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSKUResponse) ProtoMessage() {}
+
+func (x *DeleteSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSKUResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSKUResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{24}
+}
+
+// ListSKUsRequest is the request for the ListSKUs method
+type ListSKUsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSKUsRequest) Reset() {
+	*x = ListSKUsRequest{}
+	mi := &file_product_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSKUsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSKUsRequest) ProtoMessage() {}
+
+func (x *ListSKUsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSKUsRequest.ProtoReflect.Descriptor instead.
+func (*ListSKUsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSKUsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// ListSKUsResponse is the response for the ListSKUs method
+type ListSKUsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skus          []*SKU                 `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSKUsResponse) Reset() {
+	*x = ListSKUsResponse{}
+	mi := &file_product_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSKUsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSKUsResponse) ProtoMessage() {}
+
+func (x *ListSKUsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSKUsResponse.ProtoReflect.Descriptor instead.
+func (*ListSKUsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListSKUsResponse) GetSkus() []*SKU {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+// AdjustStockRequest is the request for the AdjustStock method
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	SkuId string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	// negative to reserve or sell, positive to restock
+	Delta         int64 `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_product_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AdjustStockRequest) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+// AdjustStockResponse is the response for the AdjustStock method
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           *SKU                   `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_product_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AdjustStockResponse) GetSku() *SKU {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+var File_product_product_proto protoreflect.FileDescriptor
+
+const file_product_product_proto_rawDesc = "" +
+	"\n" +
+	"\x15product/product.proto\x12\x0eproductService\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13product/money.proto\"\xfc\x04\n" +
+	"\aProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06photos\x18\a \x03(\tR\x06photos\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x03R\bquantity\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
+	"\aoptions\x18\f \x03(\v2\x1d.productService.VariantOptionR\aoptions\x12/\n" +
+	"\bvariants\x18\r \x03(\v2\x13.productService.SKUR\bvariants\x12+\n" +
+	"\x05price\x18\x0e \x01(\v2\x15.productService.MoneyR\x05price\x12-\n" +
+	"\x06prices\x18\x0f \x03(\v2\x15.productService.MoneyR\x06prices\x12%\n" +
+	"\x0erating_average\x18\x10 \x01(\x01R\rratingAverage\x12!\n" +
+	"\frating_count\x18\x11 \x01(\x03R\vratingCount\x12\x18\n" +
+	"\aversion\x18\x12 \x01(\x03R\aversionJ\x04\b\x05\x10\x06J\x04\b\t\x10\n" +
+	"\";\n" +
+	"\rVariantOption\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"\xbe\x03\n" +
+	"\x03SKU\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12:\n" +
+	"\aoptions\x18\x04 \x03(\v2 .productService.SKU.OptionsEntryR\aoptions\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12\x18\n" +
+	"\abarcode\x18\a \x01(\tR\abarcode\x12\x16\n" +
+	"\x06images\x18\b \x03(\tR\x06images\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
+	"\x05price\x18\v \x01(\v2\x15.productService.MoneyR\x05price\x1a:\n" +
+	"\fOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x05\x10\x06\"\a\n" +
+	"\x05Empty\"\xd8\x02\n" +
+	"\rCreateRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x05 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06photos\x18\x06 \x03(\tR\x06photos\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x03R\bquantity\x127\n" +
+	"\aoptions\x18\t \x03(\v2\x1d.productService.VariantOptionR\aoptions\x12+\n" +
+	"\x05price\x18\n" +
+	" \x01(\v2\x15.productService.MoneyR\x05price\x12-\n" +
+	"\x06prices\x18\v \x03(\v2\x15.productService.MoneyR\x06pricesJ\x04\b\x04\x10\x05J\x04\b\b\x10\t\"C\n" +
+	"\x0eCreateResponse\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\"\xdf\x03\n" +
+	"\rUpdateRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06photos\x18\a \x03(\tR\x06photos\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x03R\bquantity\x127\n" +
+	"\aoptions\x18\n" +
+	" \x03(\v2\x1d.productService.VariantOptionR\aoptions\x12+\n" +
+	"\x05price\x18\v \x01(\v2\x15.productService.MoneyR\x05price\x12-\n" +
+	"\x06prices\x18\f \x03(\v2\x15.productService.MoneyR\x06prices\x12;\n" +
+	"\vupdate_mask\x18\r \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\x0e \x01(\x03R\x0fexpectedVersionJ\x04\b\x05\x10\x06J\x04\b\t\x10\n" +
+	"\"C\n" +
+	"\x0eUpdateResponse\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\"\xa8\x03\n" +
+	"\rUpsertRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x05 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06photos\x18\x06 \x03(\tR\x06photos\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x03R\bquantity\x127\n" +
+	"\aoptions\x18\b \x03(\v2\x1d.productService.VariantOptionR\aoptions\x12+\n" +
+	"\x05price\x18\t \x01(\v2\x15.productService.MoneyR\x05price\x12-\n" +
+	"\x06prices\x18\n" +
+	" \x03(\v2\x15.productService.MoneyR\x06prices\x12;\n" +
+	"\vupdate_mask\x18\v \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"_\n" +
+	"\x0eUpsertResponse\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\x12\x1a\n" +
+	"\binserted\x18\x02 \x01(\bR\binserted\"L\n" +
+	"\x0fFindByIDRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"E\n" +
+	"\x10FindByIDResponse\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.productService.ProductR\aproduct\"i\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\xca\x01\n" +
+	"\x0eSearchResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
+	"totalPages\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\x123\n" +
+	"\bproducts\x18\x06 \x03(\v2\x17.productService.ProductR\bproducts\"V\n" +
+	"\x17GetProductsByIDsRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"p\n" +
+	"\x18GetProductsByIDsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.productService.ProductR\bproducts\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"`\n" +
+	"\x13ListProductsRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12(\n" +
+	"\x10after_product_id\x18\x02 \x01(\tR\x0eafterProductId\"^\n" +
+	"\x11BatchCreateResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xa2\x01\n" +
+	"\x1bBatchCreateProductsResponse\x12#\n" +
+	"\rcreated_count\x18\x01 \x01(\x03R\fcreatedCount\x12!\n" +
+	"\ffailed_count\x18\x02 \x01(\x03R\vfailedCount\x12;\n" +
+	"\aresults\x18\x03 \x03(\v2!.productService.BatchCreateResultR\aresults\"X\n" +
+	"\x10CreateSKURequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12%\n" +
+	"\x03sku\x18\x02 \x01(\v2\x13.productService.SKUR\x03sku\":\n" +
+	"\x11CreateSKUResponse\x12%\n" +
+	"\x03sku\x18\x01 \x01(\v2\x13.productService.SKUR\x03sku\"P\n" +
+	"\x10UpdateSKURequest\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12%\n" +
+	"\x03sku\x18\x02 \x01(\v2\x13.productService.SKUR\x03sku\":\n" +
+	"\x11UpdateSKUResponse\x12%\n" +
+	"\x03sku\x18\x01 \x01(\v2\x13.productService.SKUR\x03sku\")\n" +
+	"\x10DeleteSKURequest\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\"\x13\n" +
+	"\x11DeleteSKUResponse\"0\n" +
+	"\x0fListSKUsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\";\n" +
+	"\x10ListSKUsResponse\x12'\n" +
+	"\x04skus\x18\x01 \x03(\v2\x13.productService.SKUR\x04skus\"A\n" +
+	"\x12AdjustStockRequest\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\x03R\x05delta\"<\n" +
+	"\x13AdjustStockResponse\x12%\n" +
+	"\x03sku\x18\x01 \x01(\v2\x13.productService.SKUR\x03sku2\xd8\b\n" +
 	"\x0eProductService\x12N\n" +
 	"\rCreateProduct\x12\x1d.productService.CreateRequest\x1a\x1e.productService.CreateResponse\x12N\n" +
-	"\rUpdateProduct\x12\x1d.productService.UpdateRequest\x1a\x1e.productService.UpdateResponse\x12M\n" +
+	"\rUpdateProduct\x12\x1d.productService.UpdateRequest\x1a\x1e.productService.UpdateResponse\x12N\n" +
+	"\rUpsertProduct\x12\x1d.productService.UpsertRequest\x1a\x1e.productService.UpsertResponse\x12M\n" +
 	"\bFindByID\x12\x1f.productService.FindByIDRequest\x1a .productService.FindByIDResponse\x12N\n" +
-	"\rSearchProduct\x12\x1d.productService.SearchRequest\x1a\x1e.productService.SearchResponseB\x12Z\x10.;productServiceb\x06proto3"
+	"\rSearchProduct\x12\x1d.productService.SearchRequest\x1a\x1e.productService.SearchResponse\x12e\n" +
+	"\x10GetProductsByIDs\x12'.productService.GetProductsByIDsRequest\x1a(.productService.GetProductsByIDsResponse\x12N\n" +
+	"\fListProducts\x12#.productService.ListProductsRequest\x1a\x17.productService.Product0\x01\x12c\n" +
+	"\x13BatchCreateProducts\x12\x1d.productService.CreateRequest\x1a+.productService.BatchCreateProductsResponse(\x01\x12P\n" +
+	"\tCreateSKU\x12 .productService.CreateSKURequest\x1a!.productService.CreateSKUResponse\x12P\n" +
+	"\tUpdateSKU\x12 .productService.UpdateSKURequest\x1a!.productService.UpdateSKUResponse\x12P\n" +
+	"\tDeleteSKU\x12 .productService.DeleteSKURequest\x1a!.productService.DeleteSKUResponse\x12M\n" +
+	"\bListSKUs\x12\x1f.productService.ListSKUsRequest\x1a .productService.ListSKUsResponse\x12V\n" +
+	"\vAdjustStock\x12\".productService.AdjustStockRequest\x1a#.productService.AdjustStockResponseB\x12Z\x10.;productServiceb\x06proto3"
 
 var (
 	file_product_product_proto_rawDescOnce sync.Once
@@ -805,40 +2120,108 @@ func file_product_product_proto_rawDescGZIP() []byte {
 	return file_product_product_proto_rawDescData
 }
 
-var file_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_product_product_proto_goTypes = []any{
-	(*Product)(nil),               // 0: productService.Product
-	(*Empty)(nil),                 // 1: productService.Empty
-	(*CreateRequest)(nil),         // 2: productService.CreateRequest
-	(*CreateResponse)(nil),        // 3: productService.CreateResponse
-	(*UpdateRequest)(nil),         // 4: productService.UpdateRequest
-	(*UpdateResponse)(nil),        // 5: productService.UpdateResponse
-	(*FindByIDRequest)(nil),       // 6: productService.FindByIDRequest
-	(*FindByIDResponse)(nil),      // 7: productService.FindByIDResponse
-	(*SearchRequest)(nil),         // 8: productService.SearchRequest
-	(*SearchResponse)(nil),        // 9: productService.SearchResponse
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*Product)(nil),                     // 0: productService.Product
+	(*VariantOption)(nil),               // 1: productService.VariantOption
+	(*SKU)(nil),                         // 2: productService.SKU
+	(*Empty)(nil),                       // 3: productService.Empty
+	(*CreateRequest)(nil),               // 4: productService.CreateRequest
+	(*CreateResponse)(nil),              // 5: productService.CreateResponse
+	(*UpdateRequest)(nil),               // 6: productService.UpdateRequest
+	(*UpdateResponse)(nil),              // 7: productService.UpdateResponse
+	(*UpsertRequest)(nil),               // 8: productService.UpsertRequest
+	(*UpsertResponse)(nil),              // 9: productService.UpsertResponse
+	(*FindByIDRequest)(nil),             // 10: productService.FindByIDRequest
+	(*FindByIDResponse)(nil),            // 11: productService.FindByIDResponse
+	(*SearchRequest)(nil),               // 12: productService.SearchRequest
+	(*SearchResponse)(nil),              // 13: productService.SearchResponse
+	(*GetProductsByIDsRequest)(nil),     // 14: productService.GetProductsByIDsRequest
+	(*GetProductsByIDsResponse)(nil),    // 15: productService.GetProductsByIDsResponse
+	(*ListProductsRequest)(nil),         // 16: productService.ListProductsRequest
+	(*BatchCreateResult)(nil),           // 17: productService.BatchCreateResult
+	(*BatchCreateProductsResponse)(nil), // 18: productService.BatchCreateProductsResponse
+	(*CreateSKURequest)(nil),            // 19: productService.CreateSKURequest
+	(*CreateSKUResponse)(nil),           // 20: productService.CreateSKUResponse
+	(*UpdateSKURequest)(nil),            // 21: productService.UpdateSKURequest
+	(*UpdateSKUResponse)(nil),           // 22: productService.UpdateSKUResponse
+	(*DeleteSKURequest)(nil),            // 23: productService.DeleteSKURequest
+	(*DeleteSKUResponse)(nil),           // 24: productService.DeleteSKUResponse
+	(*ListSKUsRequest)(nil),             // 25: productService.ListSKUsRequest
+	(*ListSKUsResponse)(nil),            // 26: productService.ListSKUsResponse
+	(*AdjustStockRequest)(nil),          // 27: productService.AdjustStockRequest
+	(*AdjustStockResponse)(nil),         // 28: productService.AdjustStockResponse
+	nil,                                 // 29: productService.SKU.OptionsEntry
+	(*timestamppb.Timestamp)(nil),       // 30: google.protobuf.Timestamp
+	(*Money)(nil),                       // 31: productService.Money
+	(*fieldmaskpb.FieldMask)(nil),       // 32: google.protobuf.FieldMask
 }
 var file_product_product_proto_depIdxs = []int32{
-	10, // 0: productService.Product.created_at:type_name -> google.protobuf.Timestamp
-	10, // 1: productService.Product.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: productService.CreateResponse.product:type_name -> productService.Product
-	0,  // 3: productService.UpdateResponse.product:type_name -> productService.Product
-	0,  // 4: productService.FindByIDResponse.product:type_name -> productService.Product
-	0,  // 5: productService.SearchResponse.products:type_name -> productService.Product
-	2,  // 6: productService.ProductService.CreateProduct:input_type -> productService.CreateRequest
-	4,  // 7: productService.ProductService.UpdateProduct:input_type -> productService.UpdateRequest
-	6,  // 8: productService.ProductService.FindByID:input_type -> productService.FindByIDRequest
-	8,  // 9: productService.ProductService.SearchProduct:input_type -> productService.SearchRequest
-	3,  // 10: productService.ProductService.CreateProduct:output_type -> productService.CreateResponse
-	5,  // 11: productService.ProductService.UpdateProduct:output_type -> productService.UpdateResponse
-	7,  // 12: productService.ProductService.FindByID:output_type -> productService.FindByIDResponse
-	9,  // 13: productService.ProductService.SearchProduct:output_type -> productService.SearchResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	30, // 0: productService.Product.created_at:type_name -> google.protobuf.Timestamp
+	30, // 1: productService.Product.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: productService.Product.options:type_name -> productService.VariantOption
+	2,  // 3: productService.Product.variants:type_name -> productService.SKU
+	31, // 4: productService.Product.price:type_name -> productService.Money
+	31, // 5: productService.Product.prices:type_name -> productService.Money
+	29, // 6: productService.SKU.options:type_name -> productService.SKU.OptionsEntry
+	30, // 7: productService.SKU.created_at:type_name -> google.protobuf.Timestamp
+	30, // 8: productService.SKU.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 9: productService.SKU.price:type_name -> productService.Money
+	1,  // 10: productService.CreateRequest.options:type_name -> productService.VariantOption
+	31, // 11: productService.CreateRequest.price:type_name -> productService.Money
+	31, // 12: productService.CreateRequest.prices:type_name -> productService.Money
+	0,  // 13: productService.CreateResponse.product:type_name -> productService.Product
+	1,  // 14: productService.UpdateRequest.options:type_name -> productService.VariantOption
+	31, // 15: productService.UpdateRequest.price:type_name -> productService.Money
+	31, // 16: productService.UpdateRequest.prices:type_name -> productService.Money
+	32, // 17: productService.UpdateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 18: productService.UpdateResponse.product:type_name -> productService.Product
+	1,  // 19: productService.UpsertRequest.options:type_name -> productService.VariantOption
+	31, // 20: productService.UpsertRequest.price:type_name -> productService.Money
+	31, // 21: productService.UpsertRequest.prices:type_name -> productService.Money
+	32, // 22: productService.UpsertRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 23: productService.UpsertResponse.product:type_name -> productService.Product
+	0,  // 24: productService.FindByIDResponse.product:type_name -> productService.Product
+	0,  // 25: productService.SearchResponse.products:type_name -> productService.Product
+	0,  // 26: productService.GetProductsByIDsResponse.products:type_name -> productService.Product
+	17, // 27: productService.BatchCreateProductsResponse.results:type_name -> productService.BatchCreateResult
+	2,  // 28: productService.CreateSKURequest.sku:type_name -> productService.SKU
+	2,  // 29: productService.CreateSKUResponse.sku:type_name -> productService.SKU
+	2,  // 30: productService.UpdateSKURequest.sku:type_name -> productService.SKU
+	2,  // 31: productService.UpdateSKUResponse.sku:type_name -> productService.SKU
+	2,  // 32: productService.ListSKUsResponse.skus:type_name -> productService.SKU
+	2,  // 33: productService.AdjustStockResponse.sku:type_name -> productService.SKU
+	4,  // 34: productService.ProductService.CreateProduct:input_type -> productService.CreateRequest
+	6,  // 35: productService.ProductService.UpdateProduct:input_type -> productService.UpdateRequest
+	8,  // 36: productService.ProductService.UpsertProduct:input_type -> productService.UpsertRequest
+	10, // 37: productService.ProductService.FindByID:input_type -> productService.FindByIDRequest
+	12, // 38: productService.ProductService.SearchProduct:input_type -> productService.SearchRequest
+	14, // 39: productService.ProductService.GetProductsByIDs:input_type -> productService.GetProductsByIDsRequest
+	16, // 40: productService.ProductService.ListProducts:input_type -> productService.ListProductsRequest
+	4,  // 41: productService.ProductService.BatchCreateProducts:input_type -> productService.CreateRequest
+	19, // 42: productService.ProductService.CreateSKU:input_type -> productService.CreateSKURequest
+	21, // 43: productService.ProductService.UpdateSKU:input_type -> productService.UpdateSKURequest
+	23, // 44: productService.ProductService.DeleteSKU:input_type -> productService.DeleteSKURequest
+	25, // 45: productService.ProductService.ListSKUs:input_type -> productService.ListSKUsRequest
+	27, // 46: productService.ProductService.AdjustStock:input_type -> productService.AdjustStockRequest
+	5,  // 47: productService.ProductService.CreateProduct:output_type -> productService.CreateResponse
+	7,  // 48: productService.ProductService.UpdateProduct:output_type -> productService.UpdateResponse
+	9,  // 49: productService.ProductService.UpsertProduct:output_type -> productService.UpsertResponse
+	11, // 50: productService.ProductService.FindByID:output_type -> productService.FindByIDResponse
+	13, // 51: productService.ProductService.SearchProduct:output_type -> productService.SearchResponse
+	15, // 52: productService.ProductService.GetProductsByIDs:output_type -> productService.GetProductsByIDsResponse
+	0,  // 53: productService.ProductService.ListProducts:output_type -> productService.Product
+	18, // 54: productService.ProductService.BatchCreateProducts:output_type -> productService.BatchCreateProductsResponse
+	20, // 55: productService.ProductService.CreateSKU:output_type -> productService.CreateSKUResponse
+	22, // 56: productService.ProductService.UpdateSKU:output_type -> productService.UpdateSKUResponse
+	24, // 57: productService.ProductService.DeleteSKU:output_type -> productService.DeleteSKUResponse
+	26, // 58: productService.ProductService.ListSKUs:output_type -> productService.ListSKUsResponse
+	28, // 59: productService.ProductService.AdjustStock:output_type -> productService.AdjustStockResponse
+	47, // [47:60] is the sub-list for method output_type
+	34, // [34:47] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_product_product_proto_init() }
@@ -846,13 +2229,14 @@ func file_product_product_proto_init() {
 	if File_product_product_proto != nil {
 		return
 	}
+	file_product_money_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_product_proto_rawDesc), len(file_product_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
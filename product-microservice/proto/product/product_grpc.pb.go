@@ -19,10 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductService_CreateProduct_FullMethodName = "/productService.ProductService/CreateProduct"
-	ProductService_UpdateProduct_FullMethodName = "/productService.ProductService/UpdateProduct"
-	ProductService_FindByID_FullMethodName      = "/productService.ProductService/FindByID"
-	ProductService_SearchProduct_FullMethodName = "/productService.ProductService/SearchProduct"
+	ProductService_CreateProduct_FullMethodName       = "/productService.ProductService/CreateProduct"
+	ProductService_UpdateProduct_FullMethodName       = "/productService.ProductService/UpdateProduct"
+	ProductService_UpsertProduct_FullMethodName       = "/productService.ProductService/UpsertProduct"
+	ProductService_FindByID_FullMethodName            = "/productService.ProductService/FindByID"
+	ProductService_SearchProduct_FullMethodName       = "/productService.ProductService/SearchProduct"
+	ProductService_GetProductsByIDs_FullMethodName    = "/productService.ProductService/GetProductsByIDs"
+	ProductService_ListProducts_FullMethodName        = "/productService.ProductService/ListProducts"
+	ProductService_BatchCreateProducts_FullMethodName = "/productService.ProductService/BatchCreateProducts"
+	ProductService_CreateSKU_FullMethodName           = "/productService.ProductService/CreateSKU"
+	ProductService_UpdateSKU_FullMethodName           = "/productService.ProductService/UpdateSKU"
+	ProductService_DeleteSKU_FullMethodName           = "/productService.ProductService/DeleteSKU"
+	ProductService_ListSKUs_FullMethodName            = "/productService.ProductService/ListSKUs"
+	ProductService_AdjustStock_FullMethodName         = "/productService.ProductService/AdjustStock"
 )
 
 // ProductServiceClient is the client API for ProductService service.
@@ -33,12 +42,30 @@ const (
 type ProductServiceClient interface {
 	// Create is the method to create a new product
 	CreateProduct(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	// Update is the method to update a product
+	// Update is the method to update a product, it fails with NotFound for an unknown product
 	UpdateProduct(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// Upsert is the method for catalog sync jobs to update a product or insert it with the given id
+	UpsertProduct(ctx context.Context, in *UpsertRequest, opts ...grpc.CallOption) (*UpsertResponse, error)
 	// FindByID is the method to find a product by id
 	FindByID(ctx context.Context, in *FindByIDRequest, opts ...grpc.CallOption) (*FindByIDResponse, error)
 	// Search is the method to search for products
 	SearchProduct(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// GetProductsByIDs is the method to look up many products in one call, from the cache first
+	GetProductsByIDs(ctx context.Context, in *GetProductsByIDsRequest, opts ...grpc.CallOption) (*GetProductsByIDsResponse, error)
+	// ListProducts is the method to stream the whole catalog or a category
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Product], error)
+	// BatchCreateProducts is the method to create a stream of products with bulk writes
+	BatchCreateProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateRequest, BatchCreateProductsResponse], error)
+	// CreateSKU is the method to add a variant to a product
+	CreateSKU(ctx context.Context, in *CreateSKURequest, opts ...grpc.CallOption) (*CreateSKUResponse, error)
+	// UpdateSKU is the method to update a variant
+	UpdateSKU(ctx context.Context, in *UpdateSKURequest, opts ...grpc.CallOption) (*UpdateSKUResponse, error)
+	// DeleteSKU is the method to remove a variant
+	DeleteSKU(ctx context.Context, in *DeleteSKURequest, opts ...grpc.CallOption) (*DeleteSKUResponse, error)
+	// ListSKUs is the method to list the variants of a product
+	ListSKUs(ctx context.Context, in *ListSKUsRequest, opts ...grpc.CallOption) (*ListSKUsResponse, error)
+	// AdjustStock is the method to change the stock of a variant, it fails instead of going below zero
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
 }
 
 type productServiceClient struct {
@@ -69,6 +96,16 @@ func (c *productServiceClient) UpdateProduct(ctx context.Context, in *UpdateRequ
 	return out, nil
 }
 
+func (c *productServiceClient) UpsertProduct(ctx context.Context, in *UpsertRequest, opts ...grpc.CallOption) (*UpsertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertResponse)
+	err := c.cc.Invoke(ctx, ProductService_UpsertProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) FindByID(ctx context.Context, in *FindByIDRequest, opts ...grpc.CallOption) (*FindByIDResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FindByIDResponse)
@@ -89,6 +126,98 @@ func (c *productServiceClient) SearchProduct(ctx context.Context, in *SearchRequ
 	return out, nil
 }
 
+func (c *productServiceClient) GetProductsByIDs(ctx context.Context, in *GetProductsByIDsRequest, opts ...grpc.CallOption) (*GetProductsByIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductsByIDsResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductsByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Product], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[0], ProductService_ListProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListProductsRequest, Product]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_ListProductsClient = grpc.ServerStreamingClient[Product]
+
+func (c *productServiceClient) BatchCreateProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateRequest, BatchCreateProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[1], ProductService_BatchCreateProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateRequest, BatchCreateProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_BatchCreateProductsClient = grpc.ClientStreamingClient[CreateRequest, BatchCreateProductsResponse]
+
+func (c *productServiceClient) CreateSKU(ctx context.Context, in *CreateSKURequest, opts ...grpc.CallOption) (*CreateSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSKUResponse)
+	err := c.cc.Invoke(ctx, ProductService_CreateSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) UpdateSKU(ctx context.Context, in *UpdateSKURequest, opts ...grpc.CallOption) (*UpdateSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSKUResponse)
+	err := c.cc.Invoke(ctx, ProductService_UpdateSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) DeleteSKU(ctx context.Context, in *DeleteSKURequest, opts ...grpc.CallOption) (*DeleteSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSKUResponse)
+	err := c.cc.Invoke(ctx, ProductService_DeleteSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListSKUs(ctx context.Context, in *ListSKUsRequest, opts ...grpc.CallOption) (*ListSKUsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSKUsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListSKUs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductServiceServer is the server API for ProductService service.
 // All implementations must embed UnimplementedProductServiceServer
 // for forward compatibility.
@@ -97,12 +226,30 @@ func (c *productServiceClient) SearchProduct(ctx context.Context, in *SearchRequ
 type ProductServiceServer interface {
 	// Create is the method to create a new product
 	CreateProduct(context.Context, *CreateRequest) (*CreateResponse, error)
-	// Update is the method to update a product
+	// Update is the method to update a product, it fails with NotFound for an unknown product
 	UpdateProduct(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// Upsert is the method for catalog sync jobs to update a product or insert it with the given id
+	UpsertProduct(context.Context, *UpsertRequest) (*UpsertResponse, error)
 	// FindByID is the method to find a product by id
 	FindByID(context.Context, *FindByIDRequest) (*FindByIDResponse, error)
 	// Search is the method to search for products
 	SearchProduct(context.Context, *SearchRequest) (*SearchResponse, error)
+	// GetProductsByIDs is the method to look up many products in one call, from the cache first
+	GetProductsByIDs(context.Context, *GetProductsByIDsRequest) (*GetProductsByIDsResponse, error)
+	// ListProducts is the method to stream the whole catalog or a category
+	ListProducts(*ListProductsRequest, grpc.ServerStreamingServer[Product]) error
+	// BatchCreateProducts is the method to create a stream of products with bulk writes
+	BatchCreateProducts(grpc.ClientStreamingServer[CreateRequest, BatchCreateProductsResponse]) error
+	// CreateSKU is the method to add a variant to a product
+	CreateSKU(context.Context, *CreateSKURequest) (*CreateSKUResponse, error)
+	// UpdateSKU is the method to update a variant
+	UpdateSKU(context.Context, *UpdateSKURequest) (*UpdateSKUResponse, error)
+	// DeleteSKU is the method to remove a variant
+	DeleteSKU(context.Context, *DeleteSKURequest) (*DeleteSKUResponse, error)
+	// ListSKUs is the method to list the variants of a product
+	ListSKUs(context.Context, *ListSKUsRequest) (*ListSKUsResponse, error)
+	// AdjustStock is the method to change the stock of a variant, it fails instead of going below zero
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
 	mustEmbedUnimplementedProductServiceServer()
 }
 
@@ -119,12 +266,39 @@ func (UnimplementedProductServiceServer) CreateProduct(context.Context, *CreateR
 func (UnimplementedProductServiceServer) UpdateProduct(context.Context, *UpdateRequest) (*UpdateResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateProduct not implemented")
 }
+func (UnimplementedProductServiceServer) UpsertProduct(context.Context, *UpsertRequest) (*UpsertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertProduct not implemented")
+}
 func (UnimplementedProductServiceServer) FindByID(context.Context, *FindByIDRequest) (*FindByIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method FindByID not implemented")
 }
 func (UnimplementedProductServiceServer) SearchProduct(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchProduct not implemented")
 }
+func (UnimplementedProductServiceServer) GetProductsByIDs(context.Context, *GetProductsByIDsRequest) (*GetProductsByIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductsByIDs not implemented")
+}
+func (UnimplementedProductServiceServer) ListProducts(*ListProductsRequest, grpc.ServerStreamingServer[Product]) error {
+	return status.Error(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedProductServiceServer) BatchCreateProducts(grpc.ClientStreamingServer[CreateRequest, BatchCreateProductsResponse]) error {
+	return status.Error(codes.Unimplemented, "method BatchCreateProducts not implemented")
+}
+func (UnimplementedProductServiceServer) CreateSKU(context.Context, *CreateSKURequest) (*CreateSKUResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSKU not implemented")
+}
+func (UnimplementedProductServiceServer) UpdateSKU(context.Context, *UpdateSKURequest) (*UpdateSKUResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSKU not implemented")
+}
+func (UnimplementedProductServiceServer) DeleteSKU(context.Context, *DeleteSKURequest) (*DeleteSKUResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSKU not implemented")
+}
+func (UnimplementedProductServiceServer) ListSKUs(context.Context, *ListSKUsRequest) (*ListSKUsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSKUs not implemented")
+}
+func (UnimplementedProductServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustStock not implemented")
+}
 func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
 func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
 
@@ -182,6 +356,24 @@ func _ProductService_UpdateProduct_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_UpsertProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).UpsertProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_UpsertProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).UpsertProduct(ctx, req.(*UpsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_FindByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindByIDRequest)
 	if err := dec(in); err != nil {
@@ -218,6 +410,132 @@ func _ProductService_SearchProduct_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_GetProductsByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductsByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductsByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductsByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductsByIDs(ctx, req.(*GetProductsByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductServiceServer).ListProducts(m, &grpc.GenericServerStream[ListProductsRequest, Product]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_ListProductsServer = grpc.ServerStreamingServer[Product]
+
+func _ProductService_BatchCreateProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductServiceServer).BatchCreateProducts(&grpc.GenericServerStream[CreateRequest, BatchCreateProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_BatchCreateProductsServer = grpc.ClientStreamingServer[CreateRequest, BatchCreateProductsResponse]
+
+func _ProductService_CreateSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CreateSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CreateSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CreateSKU(ctx, req.(*CreateSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_UpdateSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).UpdateSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_UpdateSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).UpdateSKU(ctx, req.(*UpdateSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_DeleteSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).DeleteSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_DeleteSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).DeleteSKU(ctx, req.(*DeleteSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListSKUs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSKUsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListSKUs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListSKUs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListSKUs(ctx, req.(*ListSKUsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductService_ServiceDesc is the grpc.ServiceDesc for ProductService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -233,6 +551,10 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProduct",
 			Handler:    _ProductService_UpdateProduct_Handler,
 		},
+		{
+			MethodName: "UpsertProduct",
+			Handler:    _ProductService_UpsertProduct_Handler,
+		},
 		{
 			MethodName: "FindByID",
 			Handler:    _ProductService_FindByID_Handler,
@@ -241,7 +563,42 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchProduct",
 			Handler:    _ProductService_SearchProduct_Handler,
 		},
+		{
+			MethodName: "GetProductsByIDs",
+			Handler:    _ProductService_GetProductsByIDs_Handler,
+		},
+		{
+			MethodName: "CreateSKU",
+			Handler:    _ProductService_CreateSKU_Handler,
+		},
+		{
+			MethodName: "UpdateSKU",
+			Handler:    _ProductService_UpdateSKU_Handler,
+		},
+		{
+			MethodName: "DeleteSKU",
+			Handler:    _ProductService_DeleteSKU_Handler,
+		},
+		{
+			MethodName: "ListSKUs",
+			Handler:    _ProductService_ListSKUs_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _ProductService_AdjustStock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListProducts",
+			Handler:       _ProductService_ListProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BatchCreateProducts",
+			Handler:       _ProductService_BatchCreateProducts_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "product/product.proto",
 }
@@ -0,0 +1,798 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: product/review.proto
+
+package productService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Review is a rating of a product written by a user
+type Review struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId  string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 1 to 5 stars
+	Rating           int64  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Title            string `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Body             string `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	VerifiedPurchase bool   `protobuf:"varint,7,opt,name=verified_purchase,json=verifiedPurchase,proto3" json:"verified_purchase,omitempty"`
+	// pending, accepted or rejected
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	ModerationNote string                 `protobuf:"bytes,9,opt,name=moderation_note,json=moderationNote,proto3" json:"moderation_note,omitempty"`
+	HelpfulCount   int64                  `protobuf:"varint,10,opt,name=helpful_count,json=helpfulCount,proto3" json:"helpful_count,omitempty"`
+	ModeratedBy    string                 `protobuf:"bytes,11,opt,name=moderated_by,json=moderatedBy,proto3" json:"moderated_by,omitempty"`
+	ModeratedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=moderated_at,json=moderatedAt,proto3" json:"moderated_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_product_review_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Review) GetReviewId() string {
+	if x != nil {
+		return x.ReviewId
+	}
+	return ""
+}
+
+func (x *Review) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Review) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Review) GetRating() int64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Review) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Review) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Review) GetVerifiedPurchase() bool {
+	if x != nil {
+		return x.VerifiedPurchase
+	}
+	return false
+}
+
+func (x *Review) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Review) GetModerationNote() string {
+	if x != nil {
+		return x.ModerationNote
+	}
+	return ""
+}
+
+func (x *Review) GetHelpfulCount() int64 {
+	if x != nil {
+		return x.HelpfulCount
+	}
+	return 0
+}
+
+func (x *Review) GetModeratedBy() string {
+	if x != nil {
+		return x.ModeratedBy
+	}
+	return ""
+}
+
+func (x *Review) GetModeratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModeratedAt
+	}
+	return nil
+}
+
+func (x *Review) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Review) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CreateReviewRequest is the request for the CreateReview method, the author is the session user
+type CreateReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Rating        int64                  `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReviewRequest) Reset() {
+	*x = CreateReviewRequest{}
+	mi := &file_product_review_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReviewRequest) ProtoMessage() {}
+
+func (x *CreateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReviewRequest.ProtoReflect.Descriptor instead.
+func (*CreateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateReviewRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CreateReviewRequest) GetRating() int64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *CreateReviewRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateReviewRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// CreateReviewResponse is the response for the CreateReview method
+type CreateReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReviewResponse) Reset() {
+	*x = CreateReviewResponse{}
+	mi := &file_product_review_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReviewResponse) ProtoMessage() {}
+
+func (x *CreateReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReviewResponse.ProtoReflect.Descriptor instead.
+func (*CreateReviewResponse) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+// ListReviewsRequest is the request for the ListReviews method
+type ListReviewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unset lists the reviews of all products, admins only
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// defaults to accepted, other statuses are for admins only
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// helpful or recent, defaults to helpful
+	Sort          string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page          int64  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_product_review_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListReviewsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// ListReviewsResponse is the response for the ListReviews method
+type ListReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount    int64                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	Reviews       []*Review              `protobuf:"bytes,6,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_product_review_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsResponse) ProtoMessage() {}
+
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListReviewsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+// ModerateReviewRequest is the request for the ModerateReview method
+type ModerateReviewRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	// accepted or rejected
+	Status         string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ModerationNote string `protobuf:"bytes,3,opt,name=moderation_note,json=moderationNote,proto3" json:"moderation_note,omitempty"`
+	// unset keeps the current flag
+	VerifiedPurchase *bool `protobuf:"varint,4,opt,name=verified_purchase,json=verifiedPurchase,proto3,oneof" json:"verified_purchase,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ModerateReviewRequest) Reset() {
+	*x = ModerateReviewRequest{}
+	mi := &file_product_review_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateReviewRequest) ProtoMessage() {}
+
+func (x *ModerateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateReviewRequest.ProtoReflect.Descriptor instead.
+func (*ModerateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModerateReviewRequest) GetReviewId() string {
+	if x != nil {
+		return x.ReviewId
+	}
+	return ""
+}
+
+func (x *ModerateReviewRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ModerateReviewRequest) GetModerationNote() string {
+	if x != nil {
+		return x.ModerationNote
+	}
+	return ""
+}
+
+func (x *ModerateReviewRequest) GetVerifiedPurchase() bool {
+	if x != nil && x.VerifiedPurchase != nil {
+		return *x.VerifiedPurchase
+	}
+	return false
+}
+
+// ModerateReviewResponse is the response for the ModerateReview method
+type ModerateReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateReviewResponse) Reset() {
+	*x = ModerateReviewResponse{}
+	mi := &file_product_review_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateReviewResponse) ProtoMessage() {}
+
+func (x *ModerateReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateReviewResponse.ProtoReflect.Descriptor instead.
+func (*ModerateReviewResponse) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ModerateReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+// MarkReviewHelpfulRequest is the request for the MarkReviewHelpful method
+type MarkReviewHelpfulRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId      string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReviewHelpfulRequest) Reset() {
+	*x = MarkReviewHelpfulRequest{}
+	mi := &file_product_review_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReviewHelpfulRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReviewHelpfulRequest) ProtoMessage() {}
+
+func (x *MarkReviewHelpfulRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReviewHelpfulRequest.ProtoReflect.Descriptor instead.
+func (*MarkReviewHelpfulRequest) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MarkReviewHelpfulRequest) GetReviewId() string {
+	if x != nil {
+		return x.ReviewId
+	}
+	return ""
+}
+
+// MarkReviewHelpfulResponse is the response for the MarkReviewHelpful method
+type MarkReviewHelpfulResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReviewHelpfulResponse) Reset() {
+	*x = MarkReviewHelpfulResponse{}
+	mi := &file_product_review_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReviewHelpfulResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReviewHelpfulResponse) ProtoMessage() {}
+
+func (x *MarkReviewHelpfulResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_review_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReviewHelpfulResponse.ProtoReflect.Descriptor instead.
+func (*MarkReviewHelpfulResponse) Descriptor() ([]byte, []int) {
+	return file_product_review_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MarkReviewHelpfulResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+var File_product_review_proto protoreflect.FileDescriptor
+
+const file_product_review_proto_rawDesc = "" +
+	"\n" +
+	"\x14product/review.proto\x12\x0eproductService\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x04\n" +
+	"\x06Review\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x03R\x06rating\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x12+\n" +
+	"\x11verified_purchase\x18\a \x01(\bR\x10verifiedPurchase\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12'\n" +
+	"\x0fmoderation_note\x18\t \x01(\tR\x0emoderationNote\x12#\n" +
+	"\rhelpful_count\x18\n" +
+	" \x01(\x03R\fhelpfulCount\x12!\n" +
+	"\fmoderated_by\x18\v \x01(\tR\vmoderatedBy\x12=\n" +
+	"\fmoderated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vmoderatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"v\n" +
+	"\x13CreateReviewRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x03R\x06rating\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\"F\n" +
+	"\x14CreateReviewResponse\x12.\n" +
+	"\x06review\x18\x01 \x01(\v2\x16.productService.ReviewR\x06review\"\x87\x01\n" +
+	"\x12ListReviewsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\"\xcc\x01\n" +
+	"\x13ListReviewsResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
+	"totalPages\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\x120\n" +
+	"\areviews\x18\x06 \x03(\v2\x16.productService.ReviewR\areviews\"\xbd\x01\n" +
+	"\x15ModerateReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
+	"\x0fmoderation_note\x18\x03 \x01(\tR\x0emoderationNote\x120\n" +
+	"\x11verified_purchase\x18\x04 \x01(\bH\x00R\x10verifiedPurchase\x88\x01\x01B\x14\n" +
+	"\x12_verified_purchase\"H\n" +
+	"\x16ModerateReviewResponse\x12.\n" +
+	"\x06review\x18\x01 \x01(\v2\x16.productService.ReviewR\x06review\"7\n" +
+	"\x18MarkReviewHelpfulRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\"K\n" +
+	"\x19MarkReviewHelpfulResponse\x12.\n" +
+	"\x06review\x18\x01 \x01(\v2\x16.productService.ReviewR\x06review2\x8d\x03\n" +
+	"\rReviewService\x12Y\n" +
+	"\fCreateReview\x12#.productService.CreateReviewRequest\x1a$.productService.CreateReviewResponse\x12V\n" +
+	"\vListReviews\x12\".productService.ListReviewsRequest\x1a#.productService.ListReviewsResponse\x12_\n" +
+	"\x0eModerateReview\x12%.productService.ModerateReviewRequest\x1a&.productService.ModerateReviewResponse\x12h\n" +
+	"\x11MarkReviewHelpful\x12(.productService.MarkReviewHelpfulRequest\x1a).productService.MarkReviewHelpfulResponseB\x12Z\x10.;productServiceb\x06proto3"
+
+var (
+	file_product_review_proto_rawDescOnce sync.Once
+	file_product_review_proto_rawDescData []byte
+)
+
+func file_product_review_proto_rawDescGZIP() []byte {
+	file_product_review_proto_rawDescOnce.Do(func() {
+		file_product_review_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_product_review_proto_rawDesc), len(file_product_review_proto_rawDesc)))
+	})
+	return file_product_review_proto_rawDescData
+}
+
+var file_product_review_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_product_review_proto_goTypes = []any{
+	(*Review)(nil),                    // 0: productService.Review
+	(*CreateReviewRequest)(nil),       // 1: productService.CreateReviewRequest
+	(*CreateReviewResponse)(nil),      // 2: productService.CreateReviewResponse
+	(*ListReviewsRequest)(nil),        // 3: productService.ListReviewsRequest
+	(*ListReviewsResponse)(nil),       // 4: productService.ListReviewsResponse
+	(*ModerateReviewRequest)(nil),     // 5: productService.ModerateReviewRequest
+	(*ModerateReviewResponse)(nil),    // 6: productService.ModerateReviewResponse
+	(*MarkReviewHelpfulRequest)(nil),  // 7: productService.MarkReviewHelpfulRequest
+	(*MarkReviewHelpfulResponse)(nil), // 8: productService.MarkReviewHelpfulResponse
+	(*timestamppb.Timestamp)(nil),     // 9: google.protobuf.Timestamp
+}
+var file_product_review_proto_depIdxs = []int32{
+	9,  // 0: productService.Review.moderated_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: productService.Review.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: productService.Review.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: productService.CreateReviewResponse.review:type_name -> productService.Review
+	0,  // 4: productService.ListReviewsResponse.reviews:type_name -> productService.Review
+	0,  // 5: productService.ModerateReviewResponse.review:type_name -> productService.Review
+	0,  // 6: productService.MarkReviewHelpfulResponse.review:type_name -> productService.Review
+	1,  // 7: productService.ReviewService.CreateReview:input_type -> productService.CreateReviewRequest
+	3,  // 8: productService.ReviewService.ListReviews:input_type -> productService.ListReviewsRequest
+	5,  // 9: productService.ReviewService.ModerateReview:input_type -> productService.ModerateReviewRequest
+	7,  // 10: productService.ReviewService.MarkReviewHelpful:input_type -> productService.MarkReviewHelpfulRequest
+	2,  // 11: productService.ReviewService.CreateReview:output_type -> productService.CreateReviewResponse
+	4,  // 12: productService.ReviewService.ListReviews:output_type -> productService.ListReviewsResponse
+	6,  // 13: productService.ReviewService.ModerateReview:output_type -> productService.ModerateReviewResponse
+	8,  // 14: productService.ReviewService.MarkReviewHelpful:output_type -> productService.MarkReviewHelpfulResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_product_review_proto_init() }
+func file_product_review_proto_init() {
+	if File_product_review_proto != nil {
+		return
+	}
+	file_product_review_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_review_proto_rawDesc), len(file_product_review_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_product_review_proto_goTypes,
+		DependencyIndexes: file_product_review_proto_depIdxs,
+		MessageInfos:      file_product_review_proto_msgTypes,
+	}.Build()
+	File_product_review_proto = out.File
+	file_product_review_proto_goTypes = nil
+	file_product_review_proto_depIdxs = nil
+}